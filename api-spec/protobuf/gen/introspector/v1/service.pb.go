@@ -22,6 +22,183 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TxStage int32
+
+const (
+	TxStage_TX_STAGE_UNSPECIFIED TxStage = 0
+	// waiting to be processed.
+	TxStage_TX_STAGE_QUEUED TxStage = 1
+	// the arkade scripts were executed successfully.
+	TxStage_TX_STAGE_EXECUTED TxStage = 2
+	// the inputs were signed.
+	TxStage_TX_STAGE_SIGNED TxStage = 3
+	// the tx was accepted by arkd and is being finalized.
+	TxStage_TX_STAGE_SUBMITTED TxStage = 4
+	TxStage_TX_STAGE_FINALIZED TxStage = 5
+	TxStage_TX_STAGE_FAILED    TxStage = 6
+)
+
+// Enum value maps for TxStage.
+var (
+	TxStage_name = map[int32]string{
+		0: "TX_STAGE_UNSPECIFIED",
+		1: "TX_STAGE_QUEUED",
+		2: "TX_STAGE_EXECUTED",
+		3: "TX_STAGE_SIGNED",
+		4: "TX_STAGE_SUBMITTED",
+		5: "TX_STAGE_FINALIZED",
+		6: "TX_STAGE_FAILED",
+	}
+	TxStage_value = map[string]int32{
+		"TX_STAGE_UNSPECIFIED": 0,
+		"TX_STAGE_QUEUED":      1,
+		"TX_STAGE_EXECUTED":    2,
+		"TX_STAGE_SIGNED":      3,
+		"TX_STAGE_SUBMITTED":   4,
+		"TX_STAGE_FINALIZED":   5,
+		"TX_STAGE_FAILED":      6,
+	}
+)
+
+func (x TxStage) Enum() *TxStage {
+	p := new(TxStage)
+	*p = x
+	return p
+}
+
+func (x TxStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TxStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_introspector_v1_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TxStage) Type() protoreflect.EnumType {
+	return &file_introspector_v1_service_proto_enumTypes[0]
+}
+
+func (x TxStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TxStage.Descriptor instead.
+func (TxStage) EnumDescriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+type IntentEntryStatus int32
+
+const (
+	IntentEntryStatus_INTENT_ENTRY_STATUS_UNSPECIFIED IntentEntryStatus = 0
+	IntentEntryStatus_INTENT_ENTRY_STATUS_SIGNED      IntentEntryStatus = 1
+	// the entry is bound to a key that is not the introspector's.
+	IntentEntryStatus_INTENT_ENTRY_STATUS_SKIPPED IntentEntryStatus = 2
+	// the arkade script of the entry can't be read.
+	IntentEntryStatus_INTENT_ENTRY_STATUS_REJECTED IntentEntryStatus = 3
+)
+
+// Enum value maps for IntentEntryStatus.
+var (
+	IntentEntryStatus_name = map[int32]string{
+		0: "INTENT_ENTRY_STATUS_UNSPECIFIED",
+		1: "INTENT_ENTRY_STATUS_SIGNED",
+		2: "INTENT_ENTRY_STATUS_SKIPPED",
+		3: "INTENT_ENTRY_STATUS_REJECTED",
+	}
+	IntentEntryStatus_value = map[string]int32{
+		"INTENT_ENTRY_STATUS_UNSPECIFIED": 0,
+		"INTENT_ENTRY_STATUS_SIGNED":      1,
+		"INTENT_ENTRY_STATUS_SKIPPED":     2,
+		"INTENT_ENTRY_STATUS_REJECTED":    3,
+	}
+)
+
+func (x IntentEntryStatus) Enum() *IntentEntryStatus {
+	p := new(IntentEntryStatus)
+	*p = x
+	return p
+}
+
+func (x IntentEntryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IntentEntryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_introspector_v1_service_proto_enumTypes[1].Descriptor()
+}
+
+func (IntentEntryStatus) Type() protoreflect.EnumType {
+	return &file_introspector_v1_service_proto_enumTypes[1]
+}
+
+func (x IntentEntryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IntentEntryStatus.Descriptor instead.
+func (IntentEntryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+type DelegateStatus int32
+
+const (
+	DelegateStatus_DELEGATE_STATUS_UNSPECIFIED DelegateStatus = 0
+	// waiting for the refresh time or the next attempt.
+	DelegateStatus_DELEGATE_STATUS_PENDING DelegateStatus = 1
+	// the intent is registered in a batch session.
+	DelegateStatus_DELEGATE_STATUS_RUNNING DelegateStatus = 2
+	DelegateStatus_DELEGATE_STATUS_SETTLED DelegateStatus = 3
+	// the solver ran out of attempts.
+	DelegateStatus_DELEGATE_STATUS_FAILED DelegateStatus = 4
+)
+
+// Enum value maps for DelegateStatus.
+var (
+	DelegateStatus_name = map[int32]string{
+		0: "DELEGATE_STATUS_UNSPECIFIED",
+		1: "DELEGATE_STATUS_PENDING",
+		2: "DELEGATE_STATUS_RUNNING",
+		3: "DELEGATE_STATUS_SETTLED",
+		4: "DELEGATE_STATUS_FAILED",
+	}
+	DelegateStatus_value = map[string]int32{
+		"DELEGATE_STATUS_UNSPECIFIED": 0,
+		"DELEGATE_STATUS_PENDING":     1,
+		"DELEGATE_STATUS_RUNNING":     2,
+		"DELEGATE_STATUS_SETTLED":     3,
+		"DELEGATE_STATUS_FAILED":      4,
+	}
+)
+
+func (x DelegateStatus) Enum() *DelegateStatus {
+	p := new(DelegateStatus)
+	*p = x
+	return p
+}
+
+func (x DelegateStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DelegateStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_introspector_v1_service_proto_enumTypes[2].Descriptor()
+}
+
+func (DelegateStatus) Type() protoreflect.EnumType {
+	return &file_introspector_v1_service_proto_enumTypes[2]
+}
+
+func (x DelegateStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DelegateStatus.Descriptor instead.
+func (DelegateStatus) EnumDescriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
 type GetInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -62,7 +239,11 @@ type GetInfoResponse struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
 	Version string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	// hex-encoded compressed public key of the signer.
-	SignerPubkey  string `protobuf:"bytes,2,opt,name=signer_pubkey,json=signerPubkey,proto3" json:"signer_pubkey,omitempty"`
+	SignerPubkey string `protobuf:"bytes,2,opt,name=signer_pubkey,json=signerPubkey,proto3" json:"signer_pubkey,omitempty"`
+	// retired signer keys that still sign for the VTXOs bound to them.
+	DeprecatedSigners []*DeprecatedSigner `protobuf:"bytes,3,rep,name=deprecated_signers,json=deprecatedSigners,proto3" json:"deprecated_signers,omitempty"`
+	// arkd instances served by the introspector, the first is the default one.
+	ArkServers    []*ArkServer `protobuf:"bytes,4,rep,name=ark_servers,json=arkServers,proto3" json:"ark_servers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -111,19 +292,151 @@ func (x *GetInfoResponse) GetSignerPubkey() string {
 	return ""
 }
 
+func (x *GetInfoResponse) GetDeprecatedSigners() []*DeprecatedSigner {
+	if x != nil {
+		return x.DeprecatedSigners
+	}
+	return nil
+}
+
+func (x *GetInfoResponse) GetArkServers() []*ArkServer {
+	if x != nil {
+		return x.ArkServers
+	}
+	return nil
+}
+
+type ArkServer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// hex-encoded compressed signer public key of arkd, empty until arkd is reached.
+	SignerPubkey string `protobuf:"bytes,2,opt,name=signer_pubkey,json=signerPubkey,proto3" json:"signer_pubkey,omitempty"`
+	// unix timestamp of the last refresh of the arkd info, 0 if never reached.
+	UpdatedAt     int64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArkServer) Reset() {
+	*x = ArkServer{}
+	mi := &file_introspector_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArkServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArkServer) ProtoMessage() {}
+
+func (x *ArkServer) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArkServer.ProtoReflect.Descriptor instead.
+func (*ArkServer) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ArkServer) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ArkServer) GetSignerPubkey() string {
+	if x != nil {
+		return x.SignerPubkey
+	}
+	return ""
+}
+
+func (x *ArkServer) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type DeprecatedSigner struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hex-encoded compressed public key.
+	Pubkey string `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	// unix timestamp after which the key stops signing, 0 if not set.
+	SunsetAt      int64 `protobuf:"varint,2,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeprecatedSigner) Reset() {
+	*x = DeprecatedSigner{}
+	mi := &file_introspector_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeprecatedSigner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeprecatedSigner) ProtoMessage() {}
+
+func (x *DeprecatedSigner) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeprecatedSigner.ProtoReflect.Descriptor instead.
+func (*DeprecatedSigner) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeprecatedSigner) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+func (x *DeprecatedSigner) GetSunsetAt() int64 {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return 0
+}
+
 type SubmitTxRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// base64 psbt
 	ArkTx string `protobuf:"bytes,1,opt,name=ark_tx,json=arkTx,proto3" json:"ark_tx,omitempty"`
 	// base64 psbts
 	CheckpointTxs []string `protobuf:"bytes,2,rep,name=checkpoint_txs,json=checkpointTxs,proto3" json:"checkpoint_txs,omitempty"`
+	// if set, don't wait for the tx to be processed.
+	Async         bool `protobuf:"varint,3,opt,name=async,proto3" json:"async,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubmitTxRequest) Reset() {
 	*x = SubmitTxRequest{}
-	mi := &file_introspector_v1_service_proto_msgTypes[2]
+	mi := &file_introspector_v1_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -135,59 +448,1287 @@ func (x *SubmitTxRequest) String() string {
 func (*SubmitTxRequest) ProtoMessage() {}
 
 func (x *SubmitTxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[2]
+	mi := &file_introspector_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTxRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTxRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubmitTxRequest) GetArkTx() string {
+	if x != nil {
+		return x.ArkTx
+	}
+	return ""
+}
+
+func (x *SubmitTxRequest) GetCheckpointTxs() []string {
+	if x != nil {
+		return x.CheckpointTxs
+	}
+	return nil
+}
+
+func (x *SubmitTxRequest) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+type SubmitTxResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty in async mode, use GetTxStatus to get the signed txs.
+	SignedArkTx         string   `protobuf:"bytes,1,opt,name=signed_ark_tx,json=signedArkTx,proto3" json:"signed_ark_tx,omitempty"`
+	SignedCheckpointTxs []string `protobuf:"bytes,2,rep,name=signed_checkpoint_txs,json=signedCheckpointTxs,proto3" json:"signed_checkpoint_txs,omitempty"`
+	// id of the ark tx, to be used with GetTxStatus and WatchTx.
+	Txid          string `protobuf:"bytes,3,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTxResponse) Reset() {
+	*x = SubmitTxResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTxResponse) ProtoMessage() {}
+
+func (x *SubmitTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTxResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTxResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubmitTxResponse) GetSignedArkTx() string {
+	if x != nil {
+		return x.SignedArkTx
+	}
+	return ""
+}
+
+func (x *SubmitTxResponse) GetSignedCheckpointTxs() []string {
+	if x != nil {
+		return x.SignedCheckpointTxs
+	}
+	return nil
+}
+
+func (x *SubmitTxResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type GetTxStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTxStatusRequest) Reset() {
+	*x = GetTxStatusRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTxStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxStatusRequest) ProtoMessage() {}
+
+func (x *GetTxStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTxStatusRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTxStatusRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type GetTxStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *TxStatus              `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTxStatusResponse) Reset() {
+	*x = GetTxStatusResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTxStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxStatusResponse) ProtoMessage() {}
+
+func (x *GetTxStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTxStatusResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTxStatusResponse) GetStatus() *TxStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type WatchTxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTxRequest) Reset() {
+	*x = WatchTxRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTxRequest) ProtoMessage() {}
+
+func (x *WatchTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTxRequest.ProtoReflect.Descriptor instead.
+func (*WatchTxRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchTxRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type WatchTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *TxStatus              `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTxResponse) Reset() {
+	*x = WatchTxResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTxResponse) ProtoMessage() {}
+
+func (x *WatchTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTxResponse.ProtoReflect.Descriptor instead.
+func (*WatchTxResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchTxResponse) GetStatus() *TxStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type TxStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Txid  string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Stage TxStage                `protobuf:"varint,2,opt,name=stage,proto3,enum=introspector.v1.TxStage" json:"stage,omitempty"`
+	// set once the status won't change anymore.
+	Done bool `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	// reason of the failure.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// base64 psbts, set from the signed stage.
+	SignedArkTx         string   `protobuf:"bytes,5,opt,name=signed_ark_tx,json=signedArkTx,proto3" json:"signed_ark_tx,omitempty"`
+	SignedCheckpointTxs []string `protobuf:"bytes,6,rep,name=signed_checkpoint_txs,json=signedCheckpointTxs,proto3" json:"signed_checkpoint_txs,omitempty"`
+	UpdatedAt           int64    `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TxStatus) Reset() {
+	*x = TxStatus{}
+	mi := &file_introspector_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxStatus) ProtoMessage() {}
+
+func (x *TxStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxStatus.ProtoReflect.Descriptor instead.
+func (*TxStatus) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TxStatus) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *TxStatus) GetStage() TxStage {
+	if x != nil {
+		return x.Stage
+	}
+	return TxStage_TX_STAGE_UNSPECIFIED
+}
+
+func (x *TxStatus) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *TxStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TxStatus) GetSignedArkTx() string {
+	if x != nil {
+		return x.SignedArkTx
+	}
+	return ""
+}
+
+func (x *TxStatus) GetSignedCheckpointTxs() []string {
+	if x != nil {
+		return x.SignedCheckpointTxs
+	}
+	return nil
+}
+
+func (x *TxStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type SubmitIntentRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Intent *Intent                `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+	// if set, fail instead of returning a proof with unsigned entries.
+	Strict        bool `protobuf:"varint,2,opt,name=strict,proto3" json:"strict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitIntentRequest) Reset() {
+	*x = SubmitIntentRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitIntentRequest) ProtoMessage() {}
+
+func (x *SubmitIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitIntentRequest.ProtoReflect.Descriptor instead.
+func (*SubmitIntentRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubmitIntentRequest) GetIntent() *Intent {
+	if x != nil {
+		return x.Intent
+	}
+	return nil
+}
+
+func (x *SubmitIntentRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+type SubmitIntentResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SignedProof string                 `protobuf:"bytes,1,opt,name=signed_proof,json=signedProof,proto3" json:"signed_proof,omitempty"`
+	// outcome of each entry of the introspector packet.
+	Entries       []*IntentEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitIntentResponse) Reset() {
+	*x = SubmitIntentResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitIntentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitIntentResponse) ProtoMessage() {}
+
+func (x *SubmitIntentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitIntentResponse.ProtoReflect.Descriptor instead.
+func (*SubmitIntentResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubmitIntentResponse) GetSignedProof() string {
+	if x != nil {
+		return x.SignedProof
+	}
+	return ""
+}
+
+func (x *SubmitIntentResponse) GetEntries() []*IntentEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type IntentEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Vin    uint32                 `protobuf:"varint,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	Status IntentEntryStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=introspector.v1.IntentEntryStatus" json:"status,omitempty"`
+	// why the entry is not signed.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntentEntry) Reset() {
+	*x = IntentEntry{}
+	mi := &file_introspector_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntentEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntentEntry) ProtoMessage() {}
+
+func (x *IntentEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntentEntry.ProtoReflect.Descriptor instead.
+func (*IntentEntry) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *IntentEntry) GetVin() uint32 {
+	if x != nil {
+		return x.Vin
+	}
+	return 0
+}
+
+func (x *IntentEntry) GetStatus() IntentEntryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return IntentEntryStatus_INTENT_ENTRY_STATUS_UNSPECIFIED
+}
+
+func (x *IntentEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SubmitIntentDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intent        *Intent                `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitIntentDeletionRequest) Reset() {
+	*x = SubmitIntentDeletionRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitIntentDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitIntentDeletionRequest) ProtoMessage() {}
+
+func (x *SubmitIntentDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitIntentDeletionRequest.ProtoReflect.Descriptor instead.
+func (*SubmitIntentDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SubmitIntentDeletionRequest) GetIntent() *Intent {
+	if x != nil {
+		return x.Intent
+	}
+	return nil
+}
+
+type SubmitIntentDeletionResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SignedProof string                 `protobuf:"bytes,1,opt,name=signed_proof,json=signedProof,proto3" json:"signed_proof,omitempty"`
+	// outcome of each entry of the introspector packet.
+	Entries       []*IntentEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitIntentDeletionResponse) Reset() {
+	*x = SubmitIntentDeletionResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitIntentDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitIntentDeletionResponse) ProtoMessage() {}
+
+func (x *SubmitIntentDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitIntentDeletionResponse.ProtoReflect.Descriptor instead.
+func (*SubmitIntentDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SubmitIntentDeletionResponse) GetSignedProof() string {
+	if x != nil {
+		return x.SignedProof
+	}
+	return ""
+}
+
+func (x *SubmitIntentDeletionResponse) GetEntries() []*IntentEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SubmitFinalizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignedIntent  *Intent                `protobuf:"bytes,1,opt,name=signed_intent,json=signedIntent,proto3" json:"signed_intent,omitempty"`
+	Forfeits      []string               `protobuf:"bytes,2,rep,name=forfeits,proto3" json:"forfeits,omitempty"`
+	ConnectorTree []*TxTreeNode          `protobuf:"bytes,3,rep,name=connector_tree,json=connectorTree,proto3" json:"connector_tree,omitempty"`
+	CommitmentTx  string                 `protobuf:"bytes,4,opt,name=commitment_tx,json=commitmentTx,proto3" json:"commitment_tx,omitempty"`
+	// required to sign forfeits or boarding inputs if the intent has vtxo outputs.
+	VtxoTree      []*TxTreeNode `protobuf:"bytes,5,rep,name=vtxo_tree,json=vtxoTree,proto3" json:"vtxo_tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitFinalizationRequest) Reset() {
+	*x = SubmitFinalizationRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFinalizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFinalizationRequest) ProtoMessage() {}
+
+func (x *SubmitFinalizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFinalizationRequest.ProtoReflect.Descriptor instead.
+func (*SubmitFinalizationRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubmitFinalizationRequest) GetSignedIntent() *Intent {
+	if x != nil {
+		return x.SignedIntent
+	}
+	return nil
+}
+
+func (x *SubmitFinalizationRequest) GetForfeits() []string {
+	if x != nil {
+		return x.Forfeits
+	}
+	return nil
+}
+
+func (x *SubmitFinalizationRequest) GetConnectorTree() []*TxTreeNode {
+	if x != nil {
+		return x.ConnectorTree
+	}
+	return nil
+}
+
+func (x *SubmitFinalizationRequest) GetCommitmentTx() string {
+	if x != nil {
+		return x.CommitmentTx
+	}
+	return ""
+}
+
+func (x *SubmitFinalizationRequest) GetVtxoTree() []*TxTreeNode {
+	if x != nil {
+		return x.VtxoTree
+	}
+	return nil
+}
+
+type SubmitFinalizationResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SignedForfeits     []string               `protobuf:"bytes,1,rep,name=signed_forfeits,json=signedForfeits,proto3" json:"signed_forfeits,omitempty"`
+	SignedCommitmentTx string                 `protobuf:"bytes,2,opt,name=signed_commitment_tx,json=signedCommitmentTx,proto3" json:"signed_commitment_tx,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SubmitFinalizationResponse) Reset() {
+	*x = SubmitFinalizationResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFinalizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFinalizationResponse) ProtoMessage() {}
+
+func (x *SubmitFinalizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFinalizationResponse.ProtoReflect.Descriptor instead.
+func (*SubmitFinalizationResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubmitFinalizationResponse) GetSignedForfeits() []string {
+	if x != nil {
+		return x.SignedForfeits
+	}
+	return nil
+}
+
+func (x *SubmitFinalizationResponse) GetSignedCommitmentTx() string {
+	if x != nil {
+		return x.SignedCommitmentTx
+	}
+	return ""
+}
+
+type TxTreeNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Tx            string                 `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	Children      map[uint32]string      `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxTreeNode) Reset() {
+	*x = TxTreeNode{}
+	mi := &file_introspector_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxTreeNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxTreeNode) ProtoMessage() {}
+
+func (x *TxTreeNode) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxTreeNode.ProtoReflect.Descriptor instead.
+func (*TxTreeNode) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TxTreeNode) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *TxTreeNode) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+func (x *TxTreeNode) GetChildren() map[uint32]string {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type Intent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proof         string                 `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Intent) Reset() {
+	*x = Intent{}
+	mi := &file_introspector_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Intent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Intent) ProtoMessage() {}
+
+func (x *Intent) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Intent.ProtoReflect.Descriptor instead.
+func (*Intent) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Intent) GetProof() string {
+	if x != nil {
+		return x.Proof
+	}
+	return ""
+}
+
+func (x *Intent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SubmitOnchainTxRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// base64 psbt
+	Tx            string `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitOnchainTxRequest) Reset() {
+	*x = SubmitOnchainTxRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitOnchainTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitOnchainTxRequest) ProtoMessage() {}
+
+func (x *SubmitOnchainTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitOnchainTxRequest.ProtoReflect.Descriptor instead.
+func (*SubmitOnchainTxRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubmitOnchainTxRequest) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+type SubmitOnchainTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignedTx      string                 `protobuf:"bytes,1,opt,name=signed_tx,json=signedTx,proto3" json:"signed_tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitOnchainTxResponse) Reset() {
+	*x = SubmitOnchainTxResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitOnchainTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitOnchainTxResponse) ProtoMessage() {}
+
+func (x *SubmitOnchainTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitOnchainTxResponse.ProtoReflect.Descriptor instead.
+func (*SubmitOnchainTxResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubmitOnchainTxResponse) GetSignedTx() string {
+	if x != nil {
+		return x.SignedTx
+	}
+	return ""
+}
+
+type RegisterDelegateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unsigned intent proof spending the delegate VTXO, with its register message.
+	IntentTemplate *Intent `protobuf:"bytes,1,opt,name=intent_template,json=intentTemplate,proto3" json:"intent_template,omitempty"`
+	// unix timestamp of the VTXO expiry, 0 to refresh it right away.
+	VtxoExpireAt  int64 `protobuf:"varint,2,opt,name=vtxo_expire_at,json=vtxoExpireAt,proto3" json:"vtxo_expire_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterDelegateRequest) Reset() {
+	*x = RegisterDelegateRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDelegateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDelegateRequest) ProtoMessage() {}
+
+func (x *RegisterDelegateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDelegateRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDelegateRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RegisterDelegateRequest) GetIntentTemplate() *Intent {
+	if x != nil {
+		return x.IntentTemplate
+	}
+	return nil
+}
+
+func (x *RegisterDelegateRequest) GetVtxoExpireAt() int64 {
+	if x != nil {
+		return x.VtxoExpireAt
+	}
+	return 0
+}
+
+type RegisterDelegateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delegate      *Delegate              `protobuf:"bytes,1,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterDelegateResponse) Reset() {
+	*x = RegisterDelegateResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDelegateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDelegateResponse) ProtoMessage() {}
+
+func (x *RegisterDelegateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDelegateResponse.ProtoReflect.Descriptor instead.
+func (*RegisterDelegateResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RegisterDelegateResponse) GetDelegate() *Delegate {
+	if x != nil {
+		return x.Delegate
+	}
+	return nil
+}
+
+type GetDelegateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outpoint      string                 `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDelegateRequest) Reset() {
+	*x = GetDelegateRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDelegateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDelegateRequest) ProtoMessage() {}
+
+func (x *GetDelegateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDelegateRequest.ProtoReflect.Descriptor instead.
+func (*GetDelegateRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetDelegateRequest) GetOutpoint() string {
+	if x != nil {
+		return x.Outpoint
+	}
+	return ""
+}
+
+type GetDelegateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delegate      *Delegate              `protobuf:"bytes,1,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDelegateResponse) Reset() {
+	*x = GetDelegateResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDelegateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDelegateResponse) ProtoMessage() {}
+
+func (x *GetDelegateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDelegateResponse.ProtoReflect.Descriptor instead.
+func (*GetDelegateResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetDelegateResponse) GetDelegate() *Delegate {
+	if x != nil {
+		return x.Delegate
+	}
+	return nil
+}
+
+type Delegate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Outpoint string                 `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	Amount   int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status   DelegateStatus         `protobuf:"varint,3,opt,name=status,proto3,enum=introspector.v1.DelegateStatus" json:"status,omitempty"`
+	// unix timestamp after which the solver joins a batch.
+	RefreshAt int64  `protobuf:"varint,4,opt,name=refresh_at,json=refreshAt,proto3" json:"refresh_at,omitempty"`
+	Attempts  int32  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// set once settled.
+	CommitmentTxid string `protobuf:"bytes,7,opt,name=commitment_txid,json=commitmentTxid,proto3" json:"commitment_txid,omitempty"`
+	CreatedAt      int64  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Delegate) Reset() {
+	*x = Delegate{}
+	mi := &file_introspector_v1_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delegate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delegate) ProtoMessage() {}
+
+func (x *Delegate) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delegate.ProtoReflect.Descriptor instead.
+func (*Delegate) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Delegate) GetOutpoint() string {
+	if x != nil {
+		return x.Outpoint
+	}
+	return ""
+}
+
+func (x *Delegate) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Delegate) GetStatus() DelegateStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DelegateStatus_DELEGATE_STATUS_UNSPECIFIED
+}
+
+func (x *Delegate) GetRefreshAt() int64 {
+	if x != nil {
+		return x.RefreshAt
+	}
+	return 0
+}
+
+func (x *Delegate) GetAttempts() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Attempts
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SubmitTxRequest.ProtoReflect.Descriptor instead.
-func (*SubmitTxRequest) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{2}
+func (x *Delegate) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
 }
 
-func (x *SubmitTxRequest) GetArkTx() string {
+func (x *Delegate) GetCommitmentTxid() string {
 	if x != nil {
-		return x.ArkTx
+		return x.CommitmentTxid
 	}
 	return ""
 }
 
-func (x *SubmitTxRequest) GetCheckpointTxs() []string {
+func (x *Delegate) GetCreatedAt() int64 {
 	if x != nil {
-		return x.CheckpointTxs
+		return x.CreatedAt
 	}
-	return nil
+	return 0
 }
 
-type SubmitTxResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	SignedArkTx         string                 `protobuf:"bytes,1,opt,name=signed_ark_tx,json=signedArkTx,proto3" json:"signed_ark_tx,omitempty"`
-	SignedCheckpointTxs []string               `protobuf:"bytes,2,rep,name=signed_checkpoint_txs,json=signedCheckpointTxs,proto3" json:"signed_checkpoint_txs,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *Delegate) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
 }
 
-func (x *SubmitTxResponse) Reset() {
-	*x = SubmitTxResponse{}
-	mi := &file_introspector_v1_service_proto_msgTypes[3]
+type CreateTreeCosignerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTreeCosignerRequest) Reset() {
+	*x = CreateTreeCosignerRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitTxResponse) String() string {
+func (x *CreateTreeCosignerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitTxResponse) ProtoMessage() {}
+func (*CreateTreeCosignerRequest) ProtoMessage() {}
 
-func (x *SubmitTxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[3]
+func (x *CreateTreeCosignerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,47 +1739,36 @@ func (x *SubmitTxResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitTxResponse.ProtoReflect.Descriptor instead.
-func (*SubmitTxResponse) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *SubmitTxResponse) GetSignedArkTx() string {
-	if x != nil {
-		return x.SignedArkTx
-	}
-	return ""
-}
-
-func (x *SubmitTxResponse) GetSignedCheckpointTxs() []string {
-	if x != nil {
-		return x.SignedCheckpointTxs
-	}
-	return nil
+// Deprecated: Use CreateTreeCosignerRequest.ProtoReflect.Descriptor instead.
+func (*CreateTreeCosignerRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{27}
 }
 
-type SubmitIntentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Intent        *Intent                `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+type CreateTreeCosignerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hex-encoded compressed public key.
+	CosignerPubkey string `protobuf:"bytes,1,opt,name=cosigner_pubkey,json=cosignerPubkey,proto3" json:"cosigner_pubkey,omitempty"`
+	// unix timestamp after which the key is dropped, the batch must be signed before.
+	ExpireAt      int64 `protobuf:"varint,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitIntentRequest) Reset() {
-	*x = SubmitIntentRequest{}
-	mi := &file_introspector_v1_service_proto_msgTypes[4]
+func (x *CreateTreeCosignerResponse) Reset() {
+	*x = CreateTreeCosignerResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitIntentRequest) String() string {
+func (x *CreateTreeCosignerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitIntentRequest) ProtoMessage() {}
+func (*CreateTreeCosignerResponse) ProtoMessage() {}
 
-func (x *SubmitIntentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[4]
+func (x *CreateTreeCosignerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,40 +1779,50 @@ func (x *SubmitIntentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitIntentRequest.ProtoReflect.Descriptor instead.
-func (*SubmitIntentRequest) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateTreeCosignerResponse.ProtoReflect.Descriptor instead.
+func (*CreateTreeCosignerResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *SubmitIntentRequest) GetIntent() *Intent {
+func (x *CreateTreeCosignerResponse) GetCosignerPubkey() string {
 	if x != nil {
-		return x.Intent
+		return x.CosignerPubkey
 	}
-	return nil
+	return ""
 }
 
-type SubmitIntentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SignedProof   string                 `protobuf:"bytes,1,opt,name=signed_proof,json=signedProof,proto3" json:"signed_proof,omitempty"`
+func (x *CreateTreeCosignerResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type ScriptArgument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// hint for the wallets building the witness, eg. "signature", "pubkey",
+	// "bytes" or "int".
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitIntentResponse) Reset() {
-	*x = SubmitIntentResponse{}
-	mi := &file_introspector_v1_service_proto_msgTypes[5]
+func (x *ScriptArgument) Reset() {
+	*x = ScriptArgument{}
+	mi := &file_introspector_v1_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitIntentResponse) String() string {
+func (x *ScriptArgument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitIntentResponse) ProtoMessage() {}
+func (*ScriptArgument) ProtoMessage() {}
 
-func (x *SubmitIntentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[5]
+func (x *ScriptArgument) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,43 +1833,57 @@ func (x *SubmitIntentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitIntentResponse.ProtoReflect.Descriptor instead.
-func (*SubmitIntentResponse) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ScriptArgument.ProtoReflect.Descriptor instead.
+func (*ScriptArgument) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *SubmitIntentResponse) GetSignedProof() string {
+func (x *ScriptArgument) GetName() string {
 	if x != nil {
-		return x.SignedProof
+		return x.Name
 	}
 	return ""
 }
 
-type SubmitFinalizationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SignedIntent  *Intent                `protobuf:"bytes,1,opt,name=signed_intent,json=signedIntent,proto3" json:"signed_intent,omitempty"`
-	Forfeits      []string               `protobuf:"bytes,2,rep,name=forfeits,proto3" json:"forfeits,omitempty"`
-	ConnectorTree []*TxTreeNode          `protobuf:"bytes,3,rep,name=connector_tree,json=connectorTree,proto3" json:"connector_tree,omitempty"`
-	CommitmentTx  string                 `protobuf:"bytes,4,opt,name=commitment_tx,json=commitmentTx,proto3" json:"commitment_tx,omitempty"`
+func (x *ScriptArgument) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type RegisteredScript struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hex-encoded ArkadeScriptHash of the script.
+	ScriptHash string `protobuf:"bytes,1,opt,name=script_hash,json=scriptHash,proto3" json:"script_hash,omitempty"`
+	// hex-encoded hash of the script template, see the signing policy.
+	TemplateHash string `protobuf:"bytes,2,opt,name=template_hash,json=templateHash,proto3" json:"template_hash,omitempty"`
+	// hex-encoded arkade script.
+	Script  string `protobuf:"bytes,3,opt,name=script,proto3" json:"script,omitempty"`
+	Name    string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// witness arguments of the script, in stack order.
+	Abi           []*ScriptArgument `protobuf:"bytes,6,rep,name=abi,proto3" json:"abi,omitempty"`
+	CreatedAt     int64             `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitFinalizationRequest) Reset() {
-	*x = SubmitFinalizationRequest{}
-	mi := &file_introspector_v1_service_proto_msgTypes[6]
+func (x *RegisteredScript) Reset() {
+	*x = RegisteredScript{}
+	mi := &file_introspector_v1_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitFinalizationRequest) String() string {
+func (x *RegisteredScript) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitFinalizationRequest) ProtoMessage() {}
+func (*RegisteredScript) ProtoMessage() {}
 
-func (x *SubmitFinalizationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[6]
+func (x *RegisteredScript) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,115 +1894,86 @@ func (x *SubmitFinalizationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitFinalizationRequest.ProtoReflect.Descriptor instead.
-func (*SubmitFinalizationRequest) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RegisteredScript.ProtoReflect.Descriptor instead.
+func (*RegisteredScript) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SubmitFinalizationRequest) GetSignedIntent() *Intent {
+func (x *RegisteredScript) GetScriptHash() string {
 	if x != nil {
-		return x.SignedIntent
+		return x.ScriptHash
 	}
-	return nil
+	return ""
 }
 
-func (x *SubmitFinalizationRequest) GetForfeits() []string {
+func (x *RegisteredScript) GetTemplateHash() string {
 	if x != nil {
-		return x.Forfeits
+		return x.TemplateHash
 	}
-	return nil
+	return ""
 }
 
-func (x *SubmitFinalizationRequest) GetConnectorTree() []*TxTreeNode {
+func (x *RegisteredScript) GetScript() string {
 	if x != nil {
-		return x.ConnectorTree
+		return x.Script
 	}
-	return nil
+	return ""
 }
 
-func (x *SubmitFinalizationRequest) GetCommitmentTx() string {
+func (x *RegisteredScript) GetName() string {
 	if x != nil {
-		return x.CommitmentTx
+		return x.Name
 	}
 	return ""
 }
 
-type SubmitFinalizationResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	SignedForfeits     []string               `protobuf:"bytes,1,rep,name=signed_forfeits,json=signedForfeits,proto3" json:"signed_forfeits,omitempty"`
-	SignedCommitmentTx string                 `protobuf:"bytes,2,opt,name=signed_commitment_tx,json=signedCommitmentTx,proto3" json:"signed_commitment_tx,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
-}
-
-func (x *SubmitFinalizationResponse) Reset() {
-	*x = SubmitFinalizationResponse{}
-	mi := &file_introspector_v1_service_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SubmitFinalizationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SubmitFinalizationResponse) ProtoMessage() {}
-
-func (x *SubmitFinalizationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[7]
+func (x *RegisteredScript) GetVersion() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Version
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SubmitFinalizationResponse.ProtoReflect.Descriptor instead.
-func (*SubmitFinalizationResponse) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{7}
+	return ""
 }
 
-func (x *SubmitFinalizationResponse) GetSignedForfeits() []string {
+func (x *RegisteredScript) GetAbi() []*ScriptArgument {
 	if x != nil {
-		return x.SignedForfeits
+		return x.Abi
 	}
 	return nil
 }
 
-func (x *SubmitFinalizationResponse) GetSignedCommitmentTx() string {
+func (x *RegisteredScript) GetCreatedAt() int64 {
 	if x != nil {
-		return x.SignedCommitmentTx
+		return x.CreatedAt
 	}
-	return ""
+	return 0
 }
 
-type TxTreeNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Tx            string                 `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
-	Children      map[uint32]string      `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type RegisterScriptRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hex-encoded arkade script.
+	Script        string            `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Name          string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string            `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Abi           []*ScriptArgument `protobuf:"bytes,4,rep,name=abi,proto3" json:"abi,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxTreeNode) Reset() {
-	*x = TxTreeNode{}
-	mi := &file_introspector_v1_service_proto_msgTypes[8]
+func (x *RegisterScriptRequest) Reset() {
+	*x = RegisterScriptRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxTreeNode) String() string {
+func (x *RegisterScriptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxTreeNode) ProtoMessage() {}
+func (*RegisterScriptRequest) ProtoMessage() {}
 
-func (x *TxTreeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[8]
+func (x *RegisterScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,55 +1984,61 @@ func (x *TxTreeNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxTreeNode.ProtoReflect.Descriptor instead.
-func (*TxTreeNode) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RegisterScriptRequest.ProtoReflect.Descriptor instead.
+func (*RegisterScriptRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *TxTreeNode) GetTxid() string {
+func (x *RegisterScriptRequest) GetScript() string {
 	if x != nil {
-		return x.Txid
+		return x.Script
 	}
 	return ""
 }
 
-func (x *TxTreeNode) GetTx() string {
+func (x *RegisterScriptRequest) GetName() string {
 	if x != nil {
-		return x.Tx
+		return x.Name
 	}
 	return ""
 }
 
-func (x *TxTreeNode) GetChildren() map[uint32]string {
+func (x *RegisterScriptRequest) GetVersion() string {
 	if x != nil {
-		return x.Children
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterScriptRequest) GetAbi() []*ScriptArgument {
+	if x != nil {
+		return x.Abi
 	}
 	return nil
 }
 
-type Intent struct {
+type RegisterScriptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Proof         string                 `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Script        *RegisteredScript      `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Intent) Reset() {
-	*x = Intent{}
-	mi := &file_introspector_v1_service_proto_msgTypes[9]
+func (x *RegisterScriptResponse) Reset() {
+	*x = RegisterScriptResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Intent) String() string {
+func (x *RegisterScriptResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Intent) ProtoMessage() {}
+func (*RegisterScriptResponse) ProtoMessage() {}
 
-func (x *Intent) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[9]
+func (x *RegisterScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -518,48 +2049,41 @@ func (x *Intent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Intent.ProtoReflect.Descriptor instead.
-func (*Intent) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *Intent) GetProof() string {
-	if x != nil {
-		return x.Proof
-	}
-	return ""
+// Deprecated: Use RegisterScriptResponse.ProtoReflect.Descriptor instead.
+func (*RegisterScriptResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *Intent) GetMessage() string {
+func (x *RegisterScriptResponse) GetScript() *RegisteredScript {
 	if x != nil {
-		return x.Message
+		return x.Script
 	}
-	return ""
+	return nil
 }
 
-type SubmitOnchainTxRequest struct {
+type GetScriptRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// base64 psbt
-	Tx            string `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	// hex-encoded ArkadeScriptHash of the script.
+	ScriptHash    string `protobuf:"bytes,1,opt,name=script_hash,json=scriptHash,proto3" json:"script_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitOnchainTxRequest) Reset() {
-	*x = SubmitOnchainTxRequest{}
-	mi := &file_introspector_v1_service_proto_msgTypes[10]
+func (x *GetScriptRequest) Reset() {
+	*x = GetScriptRequest{}
+	mi := &file_introspector_v1_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitOnchainTxRequest) String() string {
+func (x *GetScriptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitOnchainTxRequest) ProtoMessage() {}
+func (*GetScriptRequest) ProtoMessage() {}
 
-func (x *SubmitOnchainTxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[10]
+func (x *GetScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,40 +2094,40 @@ func (x *SubmitOnchainTxRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitOnchainTxRequest.ProtoReflect.Descriptor instead.
-func (*SubmitOnchainTxRequest) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetScriptRequest.ProtoReflect.Descriptor instead.
+func (*GetScriptRequest) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *SubmitOnchainTxRequest) GetTx() string {
+func (x *GetScriptRequest) GetScriptHash() string {
 	if x != nil {
-		return x.Tx
+		return x.ScriptHash
 	}
 	return ""
 }
 
-type SubmitOnchainTxResponse struct {
+type GetScriptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SignedTx      string                 `protobuf:"bytes,1,opt,name=signed_tx,json=signedTx,proto3" json:"signed_tx,omitempty"`
+	Script        *RegisteredScript      `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitOnchainTxResponse) Reset() {
-	*x = SubmitOnchainTxResponse{}
-	mi := &file_introspector_v1_service_proto_msgTypes[11]
+func (x *GetScriptResponse) Reset() {
+	*x = GetScriptResponse{}
+	mi := &file_introspector_v1_service_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitOnchainTxResponse) String() string {
+func (x *GetScriptResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitOnchainTxResponse) ProtoMessage() {}
+func (*GetScriptResponse) ProtoMessage() {}
 
-func (x *SubmitOnchainTxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_introspector_v1_service_proto_msgTypes[11]
+func (x *GetScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_introspector_v1_service_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,16 +2138,16 @@ func (x *SubmitOnchainTxResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitOnchainTxResponse.ProtoReflect.Descriptor instead.
-func (*SubmitOnchainTxResponse) Descriptor() ([]byte, []int) {
-	return file_introspector_v1_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetScriptResponse.ProtoReflect.Descriptor instead.
+func (*GetScriptResponse) Descriptor() ([]byte, []int) {
+	return file_introspector_v1_service_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *SubmitOnchainTxResponse) GetSignedTx() string {
+func (x *GetScriptResponse) GetScript() *RegisteredScript {
 	if x != nil {
-		return x.SignedTx
+		return x.Script
 	}
-	return ""
+	return nil
 }
 
 var File_introspector_v1_service_proto protoreflect.FileDescriptor
@@ -631,25 +2155,67 @@ var File_introspector_v1_service_proto protoreflect.FileDescriptor
 const file_introspector_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"\x1dintrospector/v1/service.proto\x12\x0fintrospector.v1\x1a!meshapi/gateway/annotations.proto\"\x10\n" +
-	"\x0eGetInfoRequest\"P\n" +
+	"\x0eGetInfoRequest\"\xdf\x01\n" +
 	"\x0fGetInfoResponse\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12#\n" +
-	"\rsigner_pubkey\x18\x02 \x01(\tR\fsignerPubkey\"O\n" +
+	"\rsigner_pubkey\x18\x02 \x01(\tR\fsignerPubkey\x12P\n" +
+	"\x12deprecated_signers\x18\x03 \x03(\v2!.introspector.v1.DeprecatedSignerR\x11deprecatedSigners\x12;\n" +
+	"\vark_servers\x18\x04 \x03(\v2\x1a.introspector.v1.ArkServerR\n" +
+	"arkServers\"a\n" +
+	"\tArkServer\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12#\n" +
+	"\rsigner_pubkey\x18\x02 \x01(\tR\fsignerPubkey\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\"G\n" +
+	"\x10DeprecatedSigner\x12\x16\n" +
+	"\x06pubkey\x18\x01 \x01(\tR\x06pubkey\x12\x1b\n" +
+	"\tsunset_at\x18\x02 \x01(\x03R\bsunsetAt\"e\n" +
 	"\x0fSubmitTxRequest\x12\x15\n" +
 	"\x06ark_tx\x18\x01 \x01(\tR\x05arkTx\x12%\n" +
-	"\x0echeckpoint_txs\x18\x02 \x03(\tR\rcheckpointTxs\"j\n" +
+	"\x0echeckpoint_txs\x18\x02 \x03(\tR\rcheckpointTxs\x12\x14\n" +
+	"\x05async\x18\x03 \x01(\bR\x05async\"~\n" +
 	"\x10SubmitTxResponse\x12\"\n" +
 	"\rsigned_ark_tx\x18\x01 \x01(\tR\vsignedArkTx\x122\n" +
-	"\x15signed_checkpoint_txs\x18\x02 \x03(\tR\x13signedCheckpointTxs\"F\n" +
+	"\x15signed_checkpoint_txs\x18\x02 \x03(\tR\x13signedCheckpointTxs\x12\x12\n" +
+	"\x04txid\x18\x03 \x01(\tR\x04txid\"(\n" +
+	"\x12GetTxStatusRequest\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\"H\n" +
+	"\x13GetTxStatusResponse\x121\n" +
+	"\x06status\x18\x01 \x01(\v2\x19.introspector.v1.TxStatusR\x06status\"$\n" +
+	"\x0eWatchTxRequest\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\"D\n" +
+	"\x0fWatchTxResponse\x121\n" +
+	"\x06status\x18\x01 \x01(\v2\x19.introspector.v1.TxStatusR\x06status\"\xef\x01\n" +
+	"\bTxStatus\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12.\n" +
+	"\x05stage\x18\x02 \x01(\x0e2\x18.introspector.v1.TxStageR\x05stage\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\"\n" +
+	"\rsigned_ark_tx\x18\x05 \x01(\tR\vsignedArkTx\x122\n" +
+	"\x15signed_checkpoint_txs\x18\x06 \x03(\tR\x13signedCheckpointTxs\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"^\n" +
 	"\x13SubmitIntentRequest\x12/\n" +
-	"\x06intent\x18\x01 \x01(\v2\x17.introspector.v1.IntentR\x06intent\"9\n" +
+	"\x06intent\x18\x01 \x01(\v2\x17.introspector.v1.IntentR\x06intent\x12\x16\n" +
+	"\x06strict\x18\x02 \x01(\bR\x06strict\"q\n" +
 	"\x14SubmitIntentResponse\x12!\n" +
-	"\fsigned_proof\x18\x01 \x01(\tR\vsignedProof\"\xde\x01\n" +
+	"\fsigned_proof\x18\x01 \x01(\tR\vsignedProof\x126\n" +
+	"\aentries\x18\x02 \x03(\v2\x1c.introspector.v1.IntentEntryR\aentries\"s\n" +
+	"\vIntentEntry\x12\x10\n" +
+	"\x03vin\x18\x01 \x01(\rR\x03vin\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\".introspector.v1.IntentEntryStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"N\n" +
+	"\x1bSubmitIntentDeletionRequest\x12/\n" +
+	"\x06intent\x18\x01 \x01(\v2\x17.introspector.v1.IntentR\x06intent\"y\n" +
+	"\x1cSubmitIntentDeletionResponse\x12!\n" +
+	"\fsigned_proof\x18\x01 \x01(\tR\vsignedProof\x126\n" +
+	"\aentries\x18\x02 \x03(\v2\x1c.introspector.v1.IntentEntryR\aentries\"\x98\x02\n" +
 	"\x19SubmitFinalizationRequest\x12<\n" +
 	"\rsigned_intent\x18\x01 \x01(\v2\x17.introspector.v1.IntentR\fsignedIntent\x12\x1a\n" +
 	"\bforfeits\x18\x02 \x03(\tR\bforfeits\x12B\n" +
 	"\x0econnector_tree\x18\x03 \x03(\v2\x1b.introspector.v1.TxTreeNodeR\rconnectorTree\x12#\n" +
-	"\rcommitment_tx\x18\x04 \x01(\tR\fcommitmentTx\"w\n" +
+	"\rcommitment_tx\x18\x04 \x01(\tR\fcommitmentTx\x128\n" +
+	"\tvtxo_tree\x18\x05 \x03(\v2\x1b.introspector.v1.TxTreeNodeR\bvtxoTree\"w\n" +
 	"\x1aSubmitFinalizationResponse\x12'\n" +
 	"\x0fsigned_forfeits\x18\x01 \x03(\tR\x0esignedForfeits\x120\n" +
 	"\x14signed_commitment_tx\x18\x02 \x01(\tR\x12signedCommitmentTx\"\xb4\x01\n" +
@@ -667,15 +2233,95 @@ const file_introspector_v1_service_proto_rawDesc = "" +
 	"\x16SubmitOnchainTxRequest\x12\x0e\n" +
 	"\x02tx\x18\x01 \x01(\tR\x02tx\"6\n" +
 	"\x17SubmitOnchainTxResponse\x12\x1b\n" +
-	"\tsigned_tx\x18\x01 \x01(\tR\bsignedTx2\xcc\x04\n" +
+	"\tsigned_tx\x18\x01 \x01(\tR\bsignedTx\"\x81\x01\n" +
+	"\x17RegisterDelegateRequest\x12@\n" +
+	"\x0fintent_template\x18\x01 \x01(\v2\x17.introspector.v1.IntentR\x0eintentTemplate\x12$\n" +
+	"\x0evtxo_expire_at\x18\x02 \x01(\x03R\fvtxoExpireAt\"Q\n" +
+	"\x18RegisterDelegateResponse\x125\n" +
+	"\bdelegate\x18\x01 \x01(\v2\x19.introspector.v1.DelegateR\bdelegate\"0\n" +
+	"\x12GetDelegateRequest\x12\x1a\n" +
+	"\boutpoint\x18\x01 \x01(\tR\boutpoint\"L\n" +
+	"\x13GetDelegateResponse\x125\n" +
+	"\bdelegate\x18\x01 \x01(\v2\x19.introspector.v1.DelegateR\bdelegate\"\xb8\x02\n" +
+	"\bDelegate\x12\x1a\n" +
+	"\boutpoint\x18\x01 \x01(\tR\boutpoint\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x127\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1f.introspector.v1.DelegateStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"refresh_at\x18\x04 \x01(\x03R\trefreshAt\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12'\n" +
+	"\x0fcommitment_txid\x18\a \x01(\tR\x0ecommitmentTxid\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\"\x1b\n" +
+	"\x19CreateTreeCosignerRequest\"b\n" +
+	"\x1aCreateTreeCosignerResponse\x12'\n" +
+	"\x0fcosigner_pubkey\x18\x01 \x01(\tR\x0ecosignerPubkey\x12\x1b\n" +
+	"\texpire_at\x18\x02 \x01(\x03R\bexpireAt\"8\n" +
+	"\x0eScriptArgument\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\xf0\x01\n" +
+	"\x10RegisteredScript\x12\x1f\n" +
+	"\vscript_hash\x18\x01 \x01(\tR\n" +
+	"scriptHash\x12#\n" +
+	"\rtemplate_hash\x18\x02 \x01(\tR\ftemplateHash\x12\x16\n" +
+	"\x06script\x18\x03 \x01(\tR\x06script\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x121\n" +
+	"\x03abi\x18\x06 \x03(\v2\x1f.introspector.v1.ScriptArgumentR\x03abi\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"\x90\x01\n" +
+	"\x15RegisterScriptRequest\x12\x16\n" +
+	"\x06script\x18\x01 \x01(\tR\x06script\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x121\n" +
+	"\x03abi\x18\x04 \x03(\v2\x1f.introspector.v1.ScriptArgumentR\x03abi\"S\n" +
+	"\x16RegisterScriptResponse\x129\n" +
+	"\x06script\x18\x01 \x01(\v2!.introspector.v1.RegisteredScriptR\x06script\"3\n" +
+	"\x10GetScriptRequest\x12\x1f\n" +
+	"\vscript_hash\x18\x01 \x01(\tR\n" +
+	"scriptHash\"N\n" +
+	"\x11GetScriptResponse\x129\n" +
+	"\x06script\x18\x01 \x01(\v2!.introspector.v1.RegisteredScriptR\x06script*\xa9\x01\n" +
+	"\aTxStage\x12\x18\n" +
+	"\x14TX_STAGE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fTX_STAGE_QUEUED\x10\x01\x12\x15\n" +
+	"\x11TX_STAGE_EXECUTED\x10\x02\x12\x13\n" +
+	"\x0fTX_STAGE_SIGNED\x10\x03\x12\x16\n" +
+	"\x12TX_STAGE_SUBMITTED\x10\x04\x12\x16\n" +
+	"\x12TX_STAGE_FINALIZED\x10\x05\x12\x13\n" +
+	"\x0fTX_STAGE_FAILED\x10\x06*\x9b\x01\n" +
+	"\x11IntentEntryStatus\x12#\n" +
+	"\x1fINTENT_ENTRY_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aINTENT_ENTRY_STATUS_SIGNED\x10\x01\x12\x1f\n" +
+	"\x1bINTENT_ENTRY_STATUS_SKIPPED\x10\x02\x12 \n" +
+	"\x1cINTENT_ENTRY_STATUS_REJECTED\x10\x03*\xa4\x01\n" +
+	"\x0eDelegateStatus\x12\x1f\n" +
+	"\x1bDELEGATE_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17DELEGATE_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17DELEGATE_STATUS_RUNNING\x10\x02\x12\x1b\n" +
+	"\x17DELEGATE_STATUS_SETTLED\x10\x03\x12\x1a\n" +
+	"\x16DELEGATE_STATUS_FAILED\x10\x042\x80\f\n" +
 	"\x13IntrospectorService\x12[\n" +
 	"\aGetInfo\x12\x1f.introspector.v1.GetInfoRequest\x1a .introspector.v1.GetInfoResponse\"\r\xb2J\n" +
 	"\x12\b/v1/info\x12_\n" +
 	"\bSubmitTx\x12 .introspector.v1.SubmitTxRequest\x1a!.introspector.v1.SubmitTxResponse\"\x0e\xb2J\vB\x01*\"\x06/v1/tx\x12o\n" +
+	"\vGetTxStatus\x12#.introspector.v1.GetTxStatusRequest\x1a$.introspector.v1.GetTxStatusResponse\"\x15\xb2J\x12B\x01*\"\r/v1/tx/status\x12N\n" +
+	"\aWatchTx\x12\x1f.introspector.v1.WatchTxRequest\x1a .introspector.v1.WatchTxResponse0\x01\x12o\n" +
 	"\fSubmitIntent\x12$.introspector.v1.SubmitIntentRequest\x1a%.introspector.v1.SubmitIntentResponse\"\x12\xb2J\x0fB\x01*\"\n" +
-	"/v1/intent\x12\x87\x01\n" +
+	"/v1/intent\x12\x8e\x01\n" +
+	"\x14SubmitIntentDeletion\x12,.introspector.v1.SubmitIntentDeletionRequest\x1a-.introspector.v1.SubmitIntentDeletionResponse\"\x19\xb2J\x16B\x01*\"\x11/v1/intent/delete\x12\x87\x01\n" +
 	"\x12SubmitFinalization\x12*.introspector.v1.SubmitFinalizationRequest\x1a+.introspector.v1.SubmitFinalizationResponse\"\x18\xb2J\x15B\x01*\"\x10/v1/finalization\x12|\n" +
-	"\x0fSubmitOnchainTx\x12'.introspector.v1.SubmitOnchainTxRequest\x1a(.introspector.v1.SubmitOnchainTxResponse\"\x16\xb2J\x13B\x01*\"\x0e/v1/onchain-txB\xc2\x01\n" +
+	"\x0fSubmitOnchainTx\x12'.introspector.v1.SubmitOnchainTxRequest\x1a(.introspector.v1.SubmitOnchainTxResponse\"\x16\xb2J\x13B\x01*\"\x0e/v1/onchain-tx\x12}\n" +
+	"\x10RegisterDelegate\x12(.introspector.v1.RegisterDelegateRequest\x1a).introspector.v1.RegisterDelegateResponse\"\x14\xb2J\x11B\x01*\"\f/v1/delegate\x12u\n" +
+	"\vGetDelegate\x12#.introspector.v1.GetDelegateRequest\x1a$.introspector.v1.GetDelegateResponse\"\x1b\xb2J\x18B\x01*\"\x13/v1/delegate/status\x12\x83\x01\n" +
+	"\x12CreateTreeCosigner\x12*.introspector.v1.CreateTreeCosignerRequest\x1a+.introspector.v1.CreateTreeCosignerResponse\"\x14\xb2J\x11B\x01*\"\f/v1/cosigner\x12u\n" +
+	"\x0eRegisterScript\x12&.introspector.v1.RegisterScriptRequest\x1a'.introspector.v1.RegisterScriptResponse\"\x12\xb2J\x0fB\x01*\"\n" +
+	"/v1/script\x12m\n" +
+	"\tGetScript\x12!.introspector.v1.GetScriptRequest\x1a\".introspector.v1.GetScriptResponse\"\x19\xb2J\x16B\x01*\"\x11/v1/script/lookupB\xc2\x01\n" +
 	"\x13com.introspector.v1B\fServiceProtoP\x01Z@github.com/ArkLabsHQ/introspector/introspector/v1;introspectorv1\xa2\x02\x03IXX\xaa\x02\x0fIntrospector.V1\xca\x02\x0fIntrospector\\V1\xe2\x02\x1bIntrospector\\V1\\GPBMetadata\xea\x02\x10Introspector::V1b\x06proto3"
 
 var (
@@ -690,42 +2336,103 @@ func file_introspector_v1_service_proto_rawDescGZIP() []byte {
 	return file_introspector_v1_service_proto_rawDescData
 }
 
-var file_introspector_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_introspector_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_introspector_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_introspector_v1_service_proto_goTypes = []any{
-	(*GetInfoRequest)(nil),             // 0: introspector.v1.GetInfoRequest
-	(*GetInfoResponse)(nil),            // 1: introspector.v1.GetInfoResponse
-	(*SubmitTxRequest)(nil),            // 2: introspector.v1.SubmitTxRequest
-	(*SubmitTxResponse)(nil),           // 3: introspector.v1.SubmitTxResponse
-	(*SubmitIntentRequest)(nil),        // 4: introspector.v1.SubmitIntentRequest
-	(*SubmitIntentResponse)(nil),       // 5: introspector.v1.SubmitIntentResponse
-	(*SubmitFinalizationRequest)(nil),  // 6: introspector.v1.SubmitFinalizationRequest
-	(*SubmitFinalizationResponse)(nil), // 7: introspector.v1.SubmitFinalizationResponse
-	(*TxTreeNode)(nil),                 // 8: introspector.v1.TxTreeNode
-	(*Intent)(nil),                     // 9: introspector.v1.Intent
-	(*SubmitOnchainTxRequest)(nil),     // 10: introspector.v1.SubmitOnchainTxRequest
-	(*SubmitOnchainTxResponse)(nil),    // 11: introspector.v1.SubmitOnchainTxResponse
-	nil,                                // 12: introspector.v1.TxTreeNode.ChildrenEntry
+	(TxStage)(0),                         // 0: introspector.v1.TxStage
+	(IntentEntryStatus)(0),               // 1: introspector.v1.IntentEntryStatus
+	(DelegateStatus)(0),                  // 2: introspector.v1.DelegateStatus
+	(*GetInfoRequest)(nil),               // 3: introspector.v1.GetInfoRequest
+	(*GetInfoResponse)(nil),              // 4: introspector.v1.GetInfoResponse
+	(*ArkServer)(nil),                    // 5: introspector.v1.ArkServer
+	(*DeprecatedSigner)(nil),             // 6: introspector.v1.DeprecatedSigner
+	(*SubmitTxRequest)(nil),              // 7: introspector.v1.SubmitTxRequest
+	(*SubmitTxResponse)(nil),             // 8: introspector.v1.SubmitTxResponse
+	(*GetTxStatusRequest)(nil),           // 9: introspector.v1.GetTxStatusRequest
+	(*GetTxStatusResponse)(nil),          // 10: introspector.v1.GetTxStatusResponse
+	(*WatchTxRequest)(nil),               // 11: introspector.v1.WatchTxRequest
+	(*WatchTxResponse)(nil),              // 12: introspector.v1.WatchTxResponse
+	(*TxStatus)(nil),                     // 13: introspector.v1.TxStatus
+	(*SubmitIntentRequest)(nil),          // 14: introspector.v1.SubmitIntentRequest
+	(*SubmitIntentResponse)(nil),         // 15: introspector.v1.SubmitIntentResponse
+	(*IntentEntry)(nil),                  // 16: introspector.v1.IntentEntry
+	(*SubmitIntentDeletionRequest)(nil),  // 17: introspector.v1.SubmitIntentDeletionRequest
+	(*SubmitIntentDeletionResponse)(nil), // 18: introspector.v1.SubmitIntentDeletionResponse
+	(*SubmitFinalizationRequest)(nil),    // 19: introspector.v1.SubmitFinalizationRequest
+	(*SubmitFinalizationResponse)(nil),   // 20: introspector.v1.SubmitFinalizationResponse
+	(*TxTreeNode)(nil),                   // 21: introspector.v1.TxTreeNode
+	(*Intent)(nil),                       // 22: introspector.v1.Intent
+	(*SubmitOnchainTxRequest)(nil),       // 23: introspector.v1.SubmitOnchainTxRequest
+	(*SubmitOnchainTxResponse)(nil),      // 24: introspector.v1.SubmitOnchainTxResponse
+	(*RegisterDelegateRequest)(nil),      // 25: introspector.v1.RegisterDelegateRequest
+	(*RegisterDelegateResponse)(nil),     // 26: introspector.v1.RegisterDelegateResponse
+	(*GetDelegateRequest)(nil),           // 27: introspector.v1.GetDelegateRequest
+	(*GetDelegateResponse)(nil),          // 28: introspector.v1.GetDelegateResponse
+	(*Delegate)(nil),                     // 29: introspector.v1.Delegate
+	(*CreateTreeCosignerRequest)(nil),    // 30: introspector.v1.CreateTreeCosignerRequest
+	(*CreateTreeCosignerResponse)(nil),   // 31: introspector.v1.CreateTreeCosignerResponse
+	(*ScriptArgument)(nil),               // 32: introspector.v1.ScriptArgument
+	(*RegisteredScript)(nil),             // 33: introspector.v1.RegisteredScript
+	(*RegisterScriptRequest)(nil),        // 34: introspector.v1.RegisterScriptRequest
+	(*RegisterScriptResponse)(nil),       // 35: introspector.v1.RegisterScriptResponse
+	(*GetScriptRequest)(nil),             // 36: introspector.v1.GetScriptRequest
+	(*GetScriptResponse)(nil),            // 37: introspector.v1.GetScriptResponse
+	nil,                                  // 38: introspector.v1.TxTreeNode.ChildrenEntry
 }
 var file_introspector_v1_service_proto_depIdxs = []int32{
-	9,  // 0: introspector.v1.SubmitIntentRequest.intent:type_name -> introspector.v1.Intent
-	9,  // 1: introspector.v1.SubmitFinalizationRequest.signed_intent:type_name -> introspector.v1.Intent
-	8,  // 2: introspector.v1.SubmitFinalizationRequest.connector_tree:type_name -> introspector.v1.TxTreeNode
-	12, // 3: introspector.v1.TxTreeNode.children:type_name -> introspector.v1.TxTreeNode.ChildrenEntry
-	0,  // 4: introspector.v1.IntrospectorService.GetInfo:input_type -> introspector.v1.GetInfoRequest
-	2,  // 5: introspector.v1.IntrospectorService.SubmitTx:input_type -> introspector.v1.SubmitTxRequest
-	4,  // 6: introspector.v1.IntrospectorService.SubmitIntent:input_type -> introspector.v1.SubmitIntentRequest
-	6,  // 7: introspector.v1.IntrospectorService.SubmitFinalization:input_type -> introspector.v1.SubmitFinalizationRequest
-	10, // 8: introspector.v1.IntrospectorService.SubmitOnchainTx:input_type -> introspector.v1.SubmitOnchainTxRequest
-	1,  // 9: introspector.v1.IntrospectorService.GetInfo:output_type -> introspector.v1.GetInfoResponse
-	3,  // 10: introspector.v1.IntrospectorService.SubmitTx:output_type -> introspector.v1.SubmitTxResponse
-	5,  // 11: introspector.v1.IntrospectorService.SubmitIntent:output_type -> introspector.v1.SubmitIntentResponse
-	7,  // 12: introspector.v1.IntrospectorService.SubmitFinalization:output_type -> introspector.v1.SubmitFinalizationResponse
-	11, // 13: introspector.v1.IntrospectorService.SubmitOnchainTx:output_type -> introspector.v1.SubmitOnchainTxResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	6,  // 0: introspector.v1.GetInfoResponse.deprecated_signers:type_name -> introspector.v1.DeprecatedSigner
+	5,  // 1: introspector.v1.GetInfoResponse.ark_servers:type_name -> introspector.v1.ArkServer
+	13, // 2: introspector.v1.GetTxStatusResponse.status:type_name -> introspector.v1.TxStatus
+	13, // 3: introspector.v1.WatchTxResponse.status:type_name -> introspector.v1.TxStatus
+	0,  // 4: introspector.v1.TxStatus.stage:type_name -> introspector.v1.TxStage
+	22, // 5: introspector.v1.SubmitIntentRequest.intent:type_name -> introspector.v1.Intent
+	16, // 6: introspector.v1.SubmitIntentResponse.entries:type_name -> introspector.v1.IntentEntry
+	1,  // 7: introspector.v1.IntentEntry.status:type_name -> introspector.v1.IntentEntryStatus
+	22, // 8: introspector.v1.SubmitIntentDeletionRequest.intent:type_name -> introspector.v1.Intent
+	16, // 9: introspector.v1.SubmitIntentDeletionResponse.entries:type_name -> introspector.v1.IntentEntry
+	22, // 10: introspector.v1.SubmitFinalizationRequest.signed_intent:type_name -> introspector.v1.Intent
+	21, // 11: introspector.v1.SubmitFinalizationRequest.connector_tree:type_name -> introspector.v1.TxTreeNode
+	21, // 12: introspector.v1.SubmitFinalizationRequest.vtxo_tree:type_name -> introspector.v1.TxTreeNode
+	38, // 13: introspector.v1.TxTreeNode.children:type_name -> introspector.v1.TxTreeNode.ChildrenEntry
+	22, // 14: introspector.v1.RegisterDelegateRequest.intent_template:type_name -> introspector.v1.Intent
+	29, // 15: introspector.v1.RegisterDelegateResponse.delegate:type_name -> introspector.v1.Delegate
+	29, // 16: introspector.v1.GetDelegateResponse.delegate:type_name -> introspector.v1.Delegate
+	2,  // 17: introspector.v1.Delegate.status:type_name -> introspector.v1.DelegateStatus
+	32, // 18: introspector.v1.RegisteredScript.abi:type_name -> introspector.v1.ScriptArgument
+	32, // 19: introspector.v1.RegisterScriptRequest.abi:type_name -> introspector.v1.ScriptArgument
+	33, // 20: introspector.v1.RegisterScriptResponse.script:type_name -> introspector.v1.RegisteredScript
+	33, // 21: introspector.v1.GetScriptResponse.script:type_name -> introspector.v1.RegisteredScript
+	3,  // 22: introspector.v1.IntrospectorService.GetInfo:input_type -> introspector.v1.GetInfoRequest
+	7,  // 23: introspector.v1.IntrospectorService.SubmitTx:input_type -> introspector.v1.SubmitTxRequest
+	9,  // 24: introspector.v1.IntrospectorService.GetTxStatus:input_type -> introspector.v1.GetTxStatusRequest
+	11, // 25: introspector.v1.IntrospectorService.WatchTx:input_type -> introspector.v1.WatchTxRequest
+	14, // 26: introspector.v1.IntrospectorService.SubmitIntent:input_type -> introspector.v1.SubmitIntentRequest
+	17, // 27: introspector.v1.IntrospectorService.SubmitIntentDeletion:input_type -> introspector.v1.SubmitIntentDeletionRequest
+	19, // 28: introspector.v1.IntrospectorService.SubmitFinalization:input_type -> introspector.v1.SubmitFinalizationRequest
+	23, // 29: introspector.v1.IntrospectorService.SubmitOnchainTx:input_type -> introspector.v1.SubmitOnchainTxRequest
+	25, // 30: introspector.v1.IntrospectorService.RegisterDelegate:input_type -> introspector.v1.RegisterDelegateRequest
+	27, // 31: introspector.v1.IntrospectorService.GetDelegate:input_type -> introspector.v1.GetDelegateRequest
+	30, // 32: introspector.v1.IntrospectorService.CreateTreeCosigner:input_type -> introspector.v1.CreateTreeCosignerRequest
+	34, // 33: introspector.v1.IntrospectorService.RegisterScript:input_type -> introspector.v1.RegisterScriptRequest
+	36, // 34: introspector.v1.IntrospectorService.GetScript:input_type -> introspector.v1.GetScriptRequest
+	4,  // 35: introspector.v1.IntrospectorService.GetInfo:output_type -> introspector.v1.GetInfoResponse
+	8,  // 36: introspector.v1.IntrospectorService.SubmitTx:output_type -> introspector.v1.SubmitTxResponse
+	10, // 37: introspector.v1.IntrospectorService.GetTxStatus:output_type -> introspector.v1.GetTxStatusResponse
+	12, // 38: introspector.v1.IntrospectorService.WatchTx:output_type -> introspector.v1.WatchTxResponse
+	15, // 39: introspector.v1.IntrospectorService.SubmitIntent:output_type -> introspector.v1.SubmitIntentResponse
+	18, // 40: introspector.v1.IntrospectorService.SubmitIntentDeletion:output_type -> introspector.v1.SubmitIntentDeletionResponse
+	20, // 41: introspector.v1.IntrospectorService.SubmitFinalization:output_type -> introspector.v1.SubmitFinalizationResponse
+	24, // 42: introspector.v1.IntrospectorService.SubmitOnchainTx:output_type -> introspector.v1.SubmitOnchainTxResponse
+	26, // 43: introspector.v1.IntrospectorService.RegisterDelegate:output_type -> introspector.v1.RegisterDelegateResponse
+	28, // 44: introspector.v1.IntrospectorService.GetDelegate:output_type -> introspector.v1.GetDelegateResponse
+	31, // 45: introspector.v1.IntrospectorService.CreateTreeCosigner:output_type -> introspector.v1.CreateTreeCosignerResponse
+	35, // 46: introspector.v1.IntrospectorService.RegisterScript:output_type -> introspector.v1.RegisterScriptResponse
+	37, // 47: introspector.v1.IntrospectorService.GetScript:output_type -> introspector.v1.GetScriptResponse
+	35, // [35:48] is the sub-list for method output_type
+	22, // [22:35] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_introspector_v1_service_proto_init() }
@@ -738,13 +2445,14 @@ func file_introspector_v1_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_introspector_v1_service_proto_rawDesc), len(file_introspector_v1_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      3,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_introspector_v1_service_proto_goTypes,
 		DependencyIndexes: file_introspector_v1_service_proto_depIdxs,
+		EnumInfos:         file_introspector_v1_service_proto_enumTypes,
 		MessageInfos:      file_introspector_v1_service_proto_msgTypes,
 	}.Build()
 	File_introspector_v1_service_proto = out.File
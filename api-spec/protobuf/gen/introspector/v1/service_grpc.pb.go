@@ -19,11 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	IntrospectorService_GetInfo_FullMethodName            = "/introspector.v1.IntrospectorService/GetInfo"
-	IntrospectorService_SubmitTx_FullMethodName           = "/introspector.v1.IntrospectorService/SubmitTx"
-	IntrospectorService_SubmitIntent_FullMethodName       = "/introspector.v1.IntrospectorService/SubmitIntent"
-	IntrospectorService_SubmitFinalization_FullMethodName = "/introspector.v1.IntrospectorService/SubmitFinalization"
-	IntrospectorService_SubmitOnchainTx_FullMethodName    = "/introspector.v1.IntrospectorService/SubmitOnchainTx"
+	IntrospectorService_GetInfo_FullMethodName              = "/introspector.v1.IntrospectorService/GetInfo"
+	IntrospectorService_SubmitTx_FullMethodName             = "/introspector.v1.IntrospectorService/SubmitTx"
+	IntrospectorService_GetTxStatus_FullMethodName          = "/introspector.v1.IntrospectorService/GetTxStatus"
+	IntrospectorService_WatchTx_FullMethodName              = "/introspector.v1.IntrospectorService/WatchTx"
+	IntrospectorService_SubmitIntent_FullMethodName         = "/introspector.v1.IntrospectorService/SubmitIntent"
+	IntrospectorService_SubmitIntentDeletion_FullMethodName = "/introspector.v1.IntrospectorService/SubmitIntentDeletion"
+	IntrospectorService_SubmitFinalization_FullMethodName   = "/introspector.v1.IntrospectorService/SubmitFinalization"
+	IntrospectorService_SubmitOnchainTx_FullMethodName      = "/introspector.v1.IntrospectorService/SubmitOnchainTx"
+	IntrospectorService_RegisterDelegate_FullMethodName     = "/introspector.v1.IntrospectorService/RegisterDelegate"
+	IntrospectorService_GetDelegate_FullMethodName          = "/introspector.v1.IntrospectorService/GetDelegate"
+	IntrospectorService_CreateTreeCosigner_FullMethodName   = "/introspector.v1.IntrospectorService/CreateTreeCosigner"
+	IntrospectorService_RegisterScript_FullMethodName       = "/introspector.v1.IntrospectorService/RegisterScript"
+	IntrospectorService_GetScript_FullMethodName            = "/introspector.v1.IntrospectorService/GetScript"
 )
 
 // IntrospectorServiceClient is the client API for IntrospectorService service.
@@ -36,13 +44,31 @@ type IntrospectorServiceClient interface {
 	// SubmitTx signs an Ark transaction and its associated checkpoint
 	// transactions by executing Arkade scripts on each input.
 	// the script is executed only on the ark transaction, not on checkpoints.
+	// If async is set, it returns the txid right away and the tx is processed
+	// in background, its progress is reported by GetTxStatus and WatchTx.
 	SubmitTx(ctx context.Context, in *SubmitTxRequest, opts ...grpc.CallOption) (*SubmitTxResponse, error)
+	// GetTxStatus returns the progress of a submitted Ark transaction.
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+	// WatchTx streams the status of a submitted Ark transaction every time it
+	// changes, the stream ends once the status is done.
+	WatchTx(ctx context.Context, in *WatchTxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchTxResponse], error)
 	// SubmitIntent signs an intent proof after validating the register message
 	// and executing Arkade Script on the intent proof transaction.
+	// The outcome of each introspector packet entry is returned, in strict mode
+	// the request fails if any entry is not signed.
 	SubmitIntent(ctx context.Context, in *SubmitIntentRequest, opts ...grpc.CallOption) (*SubmitIntentResponse, error)
+	// SubmitIntentDeletion signs a proof deleting a registered intent. The
+	// message must be an encoded delete message the proof commits to. Inputs
+	// already signed in an intent proof are signed again, the others only if
+	// their Arkade Script succeeds on the delete proof.
+	SubmitIntentDeletion(ctx context.Context, in *SubmitIntentDeletionRequest, opts ...grpc.CallOption) (*SubmitIntentDeletionResponse, error)
 	// SubmitFinalization conditionally signs forfeit and/or boarding inputs.
 	// It only signs if the signer's signature is found in the intent proof.
-	// connector tree is used to verify the forfeits are part of a real batch session.
+	// connector tree is used to verify the forfeits are part of a real batch session:
+	// the tree must be rooted in the commitment tx and the forfeits must spend one of
+	// its connectors along with the full vtxo amount, paid to the arkd forfeit address.
+	// vtxo tree is used to verify the commitment tx creates the vtxos of the intent
+	// before signing its boarding inputs, along with the intent onchain outputs.
 	// SubmitFinalization works in sequence with SubmitIntent.
 	// 1. SubmitIntent before intent registration.
 	// 2. SubmitFinalization during batch finalization.
@@ -50,6 +76,26 @@ type IntrospectorServiceClient interface {
 	// SubmitOnchainTx signs a Bitcoin transaction by executing Arkade scripts
 	// on each input whose tapscript contains the introspector's tweaked key.
 	SubmitOnchainTx(ctx context.Context, in *SubmitOnchainTxRequest, opts ...grpc.CallOption) (*SubmitOnchainTxResponse, error)
+	// RegisterDelegate hands the refresh of a VTXO to the built-in solver.
+	// The intent template spends the VTXO through a closure signed only by
+	// arkd and the introspector, the solver registers it with its own cosigner
+	// key before the VTXO expires and signs the forfeit in the batch.
+	// Registering the same VTXO again replaces its template.
+	RegisterDelegate(ctx context.Context, in *RegisterDelegateRequest, opts ...grpc.CallOption) (*RegisterDelegateResponse, error)
+	// GetDelegate returns the refresh progress of a delegate VTXO.
+	GetDelegate(ctx context.Context, in *GetDelegateRequest, opts ...grpc.CallOption) (*GetDelegateResponse, error)
+	// CreateTreeCosigner returns an ephemeral key to be listed in the cosigners
+	// of a register message. Once the intent is signed with SubmitIntent, the
+	// introspector cosigns the VTXO tree of the first batch including it, if
+	// the leaves cosigned by the key only create the VTXOs of the intent.
+	CreateTreeCosigner(ctx context.Context, in *CreateTreeCosignerRequest, opts ...grpc.CallOption) (*CreateTreeCosignerResponse, error)
+	// RegisterScript stores an arkade script in the registry, so that the
+	// wallets receiving VTXOs locked by it can fetch its bytes with GetScript,
+	// and the introspector packet entries can reference it by hash instead of
+	// inlining it. Registering a script again returns the first registration.
+	RegisterScript(ctx context.Context, in *RegisterScriptRequest, opts ...grpc.CallOption) (*RegisterScriptResponse, error)
+	// GetScript returns a registered script by its ArkadeScriptHash.
+	GetScript(ctx context.Context, in *GetScriptRequest, opts ...grpc.CallOption) (*GetScriptResponse, error)
 }
 
 type introspectorServiceClient struct {
@@ -80,6 +126,35 @@ func (c *introspectorServiceClient) SubmitTx(ctx context.Context, in *SubmitTxRe
 	return out, nil
 }
 
+func (c *introspectorServiceClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_GetTxStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectorServiceClient) WatchTx(ctx context.Context, in *WatchTxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchTxResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IntrospectorService_ServiceDesc.Streams[0], IntrospectorService_WatchTx_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTxRequest, WatchTxResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IntrospectorService_WatchTxClient = grpc.ServerStreamingClient[WatchTxResponse]
+
 func (c *introspectorServiceClient) SubmitIntent(ctx context.Context, in *SubmitIntentRequest, opts ...grpc.CallOption) (*SubmitIntentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubmitIntentResponse)
@@ -90,6 +165,16 @@ func (c *introspectorServiceClient) SubmitIntent(ctx context.Context, in *Submit
 	return out, nil
 }
 
+func (c *introspectorServiceClient) SubmitIntentDeletion(ctx context.Context, in *SubmitIntentDeletionRequest, opts ...grpc.CallOption) (*SubmitIntentDeletionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitIntentDeletionResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_SubmitIntentDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *introspectorServiceClient) SubmitFinalization(ctx context.Context, in *SubmitFinalizationRequest, opts ...grpc.CallOption) (*SubmitFinalizationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubmitFinalizationResponse)
@@ -110,6 +195,56 @@ func (c *introspectorServiceClient) SubmitOnchainTx(ctx context.Context, in *Sub
 	return out, nil
 }
 
+func (c *introspectorServiceClient) RegisterDelegate(ctx context.Context, in *RegisterDelegateRequest, opts ...grpc.CallOption) (*RegisterDelegateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterDelegateResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_RegisterDelegate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectorServiceClient) GetDelegate(ctx context.Context, in *GetDelegateRequest, opts ...grpc.CallOption) (*GetDelegateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDelegateResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_GetDelegate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectorServiceClient) CreateTreeCosigner(ctx context.Context, in *CreateTreeCosignerRequest, opts ...grpc.CallOption) (*CreateTreeCosignerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTreeCosignerResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_CreateTreeCosigner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectorServiceClient) RegisterScript(ctx context.Context, in *RegisterScriptRequest, opts ...grpc.CallOption) (*RegisterScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterScriptResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_RegisterScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectorServiceClient) GetScript(ctx context.Context, in *GetScriptRequest, opts ...grpc.CallOption) (*GetScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScriptResponse)
+	err := c.cc.Invoke(ctx, IntrospectorService_GetScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IntrospectorServiceServer is the server API for IntrospectorService service.
 // All implementations should embed UnimplementedIntrospectorServiceServer
 // for forward compatibility.
@@ -120,13 +255,31 @@ type IntrospectorServiceServer interface {
 	// SubmitTx signs an Ark transaction and its associated checkpoint
 	// transactions by executing Arkade scripts on each input.
 	// the script is executed only on the ark transaction, not on checkpoints.
+	// If async is set, it returns the txid right away and the tx is processed
+	// in background, its progress is reported by GetTxStatus and WatchTx.
 	SubmitTx(context.Context, *SubmitTxRequest) (*SubmitTxResponse, error)
+	// GetTxStatus returns the progress of a submitted Ark transaction.
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	// WatchTx streams the status of a submitted Ark transaction every time it
+	// changes, the stream ends once the status is done.
+	WatchTx(*WatchTxRequest, grpc.ServerStreamingServer[WatchTxResponse]) error
 	// SubmitIntent signs an intent proof after validating the register message
 	// and executing Arkade Script on the intent proof transaction.
+	// The outcome of each introspector packet entry is returned, in strict mode
+	// the request fails if any entry is not signed.
 	SubmitIntent(context.Context, *SubmitIntentRequest) (*SubmitIntentResponse, error)
+	// SubmitIntentDeletion signs a proof deleting a registered intent. The
+	// message must be an encoded delete message the proof commits to. Inputs
+	// already signed in an intent proof are signed again, the others only if
+	// their Arkade Script succeeds on the delete proof.
+	SubmitIntentDeletion(context.Context, *SubmitIntentDeletionRequest) (*SubmitIntentDeletionResponse, error)
 	// SubmitFinalization conditionally signs forfeit and/or boarding inputs.
 	// It only signs if the signer's signature is found in the intent proof.
-	// connector tree is used to verify the forfeits are part of a real batch session.
+	// connector tree is used to verify the forfeits are part of a real batch session:
+	// the tree must be rooted in the commitment tx and the forfeits must spend one of
+	// its connectors along with the full vtxo amount, paid to the arkd forfeit address.
+	// vtxo tree is used to verify the commitment tx creates the vtxos of the intent
+	// before signing its boarding inputs, along with the intent onchain outputs.
 	// SubmitFinalization works in sequence with SubmitIntent.
 	// 1. SubmitIntent before intent registration.
 	// 2. SubmitFinalization during batch finalization.
@@ -134,6 +287,26 @@ type IntrospectorServiceServer interface {
 	// SubmitOnchainTx signs a Bitcoin transaction by executing Arkade scripts
 	// on each input whose tapscript contains the introspector's tweaked key.
 	SubmitOnchainTx(context.Context, *SubmitOnchainTxRequest) (*SubmitOnchainTxResponse, error)
+	// RegisterDelegate hands the refresh of a VTXO to the built-in solver.
+	// The intent template spends the VTXO through a closure signed only by
+	// arkd and the introspector, the solver registers it with its own cosigner
+	// key before the VTXO expires and signs the forfeit in the batch.
+	// Registering the same VTXO again replaces its template.
+	RegisterDelegate(context.Context, *RegisterDelegateRequest) (*RegisterDelegateResponse, error)
+	// GetDelegate returns the refresh progress of a delegate VTXO.
+	GetDelegate(context.Context, *GetDelegateRequest) (*GetDelegateResponse, error)
+	// CreateTreeCosigner returns an ephemeral key to be listed in the cosigners
+	// of a register message. Once the intent is signed with SubmitIntent, the
+	// introspector cosigns the VTXO tree of the first batch including it, if
+	// the leaves cosigned by the key only create the VTXOs of the intent.
+	CreateTreeCosigner(context.Context, *CreateTreeCosignerRequest) (*CreateTreeCosignerResponse, error)
+	// RegisterScript stores an arkade script in the registry, so that the
+	// wallets receiving VTXOs locked by it can fetch its bytes with GetScript,
+	// and the introspector packet entries can reference it by hash instead of
+	// inlining it. Registering a script again returns the first registration.
+	RegisterScript(context.Context, *RegisterScriptRequest) (*RegisterScriptResponse, error)
+	// GetScript returns a registered script by its ArkadeScriptHash.
+	GetScript(context.Context, *GetScriptRequest) (*GetScriptResponse, error)
 }
 
 // UnimplementedIntrospectorServiceServer should be embedded to have
@@ -149,15 +322,39 @@ func (UnimplementedIntrospectorServiceServer) GetInfo(context.Context, *GetInfoR
 func (UnimplementedIntrospectorServiceServer) SubmitTx(context.Context, *SubmitTxRequest) (*SubmitTxResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitTx not implemented")
 }
+func (UnimplementedIntrospectorServiceServer) GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (UnimplementedIntrospectorServiceServer) WatchTx(*WatchTxRequest, grpc.ServerStreamingServer[WatchTxResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchTx not implemented")
+}
 func (UnimplementedIntrospectorServiceServer) SubmitIntent(context.Context, *SubmitIntentRequest) (*SubmitIntentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitIntent not implemented")
 }
+func (UnimplementedIntrospectorServiceServer) SubmitIntentDeletion(context.Context, *SubmitIntentDeletionRequest) (*SubmitIntentDeletionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitIntentDeletion not implemented")
+}
 func (UnimplementedIntrospectorServiceServer) SubmitFinalization(context.Context, *SubmitFinalizationRequest) (*SubmitFinalizationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitFinalization not implemented")
 }
 func (UnimplementedIntrospectorServiceServer) SubmitOnchainTx(context.Context, *SubmitOnchainTxRequest) (*SubmitOnchainTxResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitOnchainTx not implemented")
 }
+func (UnimplementedIntrospectorServiceServer) RegisterDelegate(context.Context, *RegisterDelegateRequest) (*RegisterDelegateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterDelegate not implemented")
+}
+func (UnimplementedIntrospectorServiceServer) GetDelegate(context.Context, *GetDelegateRequest) (*GetDelegateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDelegate not implemented")
+}
+func (UnimplementedIntrospectorServiceServer) CreateTreeCosigner(context.Context, *CreateTreeCosignerRequest) (*CreateTreeCosignerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTreeCosigner not implemented")
+}
+func (UnimplementedIntrospectorServiceServer) RegisterScript(context.Context, *RegisterScriptRequest) (*RegisterScriptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterScript not implemented")
+}
+func (UnimplementedIntrospectorServiceServer) GetScript(context.Context, *GetScriptRequest) (*GetScriptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetScript not implemented")
+}
 func (UnimplementedIntrospectorServiceServer) testEmbeddedByValue() {}
 
 // UnsafeIntrospectorServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -214,6 +411,35 @@ func _IntrospectorService_SubmitTx_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IntrospectorService_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_GetTxStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IntrospectorService_WatchTx_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTxRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IntrospectorServiceServer).WatchTx(m, &grpc.GenericServerStream[WatchTxRequest, WatchTxResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IntrospectorService_WatchTxServer = grpc.ServerStreamingServer[WatchTxResponse]
+
 func _IntrospectorService_SubmitIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitIntentRequest)
 	if err := dec(in); err != nil {
@@ -232,6 +458,24 @@ func _IntrospectorService_SubmitIntent_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IntrospectorService_SubmitIntentDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitIntentDeletionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).SubmitIntentDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_SubmitIntentDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).SubmitIntentDeletion(ctx, req.(*SubmitIntentDeletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IntrospectorService_SubmitFinalization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitFinalizationRequest)
 	if err := dec(in); err != nil {
@@ -268,6 +512,96 @@ func _IntrospectorService_SubmitOnchainTx_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IntrospectorService_RegisterDelegate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDelegateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).RegisterDelegate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_RegisterDelegate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).RegisterDelegate(ctx, req.(*RegisterDelegateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IntrospectorService_GetDelegate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDelegateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).GetDelegate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_GetDelegate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).GetDelegate(ctx, req.(*GetDelegateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IntrospectorService_CreateTreeCosigner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTreeCosignerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).CreateTreeCosigner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_CreateTreeCosigner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).CreateTreeCosigner(ctx, req.(*CreateTreeCosignerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IntrospectorService_RegisterScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).RegisterScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_RegisterScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).RegisterScript(ctx, req.(*RegisterScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IntrospectorService_GetScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectorServiceServer).GetScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectorService_GetScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectorServiceServer).GetScript(ctx, req.(*GetScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IntrospectorService_ServiceDesc is the grpc.ServiceDesc for IntrospectorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -283,10 +617,18 @@ var IntrospectorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitTx",
 			Handler:    _IntrospectorService_SubmitTx_Handler,
 		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _IntrospectorService_GetTxStatus_Handler,
+		},
 		{
 			MethodName: "SubmitIntent",
 			Handler:    _IntrospectorService_SubmitIntent_Handler,
 		},
+		{
+			MethodName: "SubmitIntentDeletion",
+			Handler:    _IntrospectorService_SubmitIntentDeletion_Handler,
+		},
 		{
 			MethodName: "SubmitFinalization",
 			Handler:    _IntrospectorService_SubmitFinalization_Handler,
@@ -295,7 +637,33 @@ var IntrospectorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitOnchainTx",
 			Handler:    _IntrospectorService_SubmitOnchainTx_Handler,
 		},
+		{
+			MethodName: "RegisterDelegate",
+			Handler:    _IntrospectorService_RegisterDelegate_Handler,
+		},
+		{
+			MethodName: "GetDelegate",
+			Handler:    _IntrospectorService_GetDelegate_Handler,
+		},
+		{
+			MethodName: "CreateTreeCosigner",
+			Handler:    _IntrospectorService_CreateTreeCosigner_Handler,
+		},
+		{
+			MethodName: "RegisterScript",
+			Handler:    _IntrospectorService_RegisterScript_Handler,
+		},
+		{
+			MethodName: "GetScript",
+			Handler:    _IntrospectorService_GetScript_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTx",
+			Handler:       _IntrospectorService_WatchTx_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "introspector/v1/service.proto",
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/ArkLabsHQ/introspector/internal/config"
 	grpcservice "github.com/ArkLabsHQ/introspector/internal/interface/grpc"
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const (
@@ -15,7 +17,28 @@ const (
 )
 
 func main() {
-	cfg, err := config.LoadConfig()
+	cmd := &cobra.Command{
+		Use:   "introspector",
+		Short: "Signing service for the Arkade protocol, executing Arkade Script",
+		Long: "Signing service for the Arkade protocol, executing Arkade Script.\n\n" +
+			"Every flag can also be set with its INTROSPECTOR_* env var, eg. " +
+			"INTROSPECTOR_MAX_MSG_SIZE for --max-msg-size, or in the config file. " +
+			"The flags override the env vars, which override the config file.",
+		Version: Version,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			run(cmd.Flags())
+		},
+	}
+	config.RegisterFlags(cmd.Flags())
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(flags *pflag.FlagSet) {
+	cfg, err := config.LoadConfig(flags)
 	if err != nil {
 		log.Fatalf("invalid config: %s", err)
 	}
@@ -27,6 +50,7 @@ func main() {
 		"tls_extra_ips":     cfg.TLSExtraIPs,
 		"tls_extra_domains": cfg.TLSExtraDomains,
 		"datadir":           cfg.Datadir,
+		"config_file":       cfg.ConfigFile,
 	}).Info("config loaded")
 
 	svc, err := grpcservice.NewService(Version, cfg)
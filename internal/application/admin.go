@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultSignaturesLimit is the number of signatures listed if not specified
+const DefaultSignaturesLimit = 100
+
+// ServiceStatus is the operational state of the service
+type ServiceStatus struct {
+	// unix time of the start of the service
+	StartedAt     int64
+	SigningPaused bool
+	Health        Health
+	ArkServers    []ArkServer
+}
+
+// KeyInfo describes the keystore and the keys it holds
+type KeyInfo struct {
+	Initialized bool
+	Unlocked    bool
+	// empty if the keystore is not initialized
+	Keys []SignerKey
+}
+
+// AdminService exposes the operator endpoints to inspect and unblock the
+// background processing of the service, and to control the signing at runtime.
+type AdminService interface {
+	GetStatus(context.Context) (*ServiceStatus, error)
+	GetKeyInfo(context.Context) (*KeyInfo, error)
+	// PauseSigning makes the signing requests fail with ErrSigningPaused
+	// until ResumeSigning, the delegate solver doesn't start new sessions
+	PauseSigning(context.Context) error
+	ResumeSigning(context.Context) error
+	// ListSignatures returns the most recent signatures of the journal,
+	// DefaultSignaturesLimit of them if limit is not positive
+	ListSignatures(ctx context.Context, limit int) ([]Signature, error)
+	ListFinalizations(context.Context) ([]Finalization, error)
+	RetryFinalization(ctx context.Context, txid string) error
+	// ListIntents returns the intents we signed that are not expired yet
+	ListIntents(context.Context) ([]IntentRecord, error)
+	GetPolicy(context.Context) (*Policy, error)
+	// UpdatePolicy fails with ErrInvalidPolicy if the policy is malformed
+	UpdatePolicy(context.Context, Policy) error
+	// ReloadPolicy re-reads the policy file, it fails with ErrNoPolicyFile if
+	// the introspector was started without one
+	ReloadPolicy(context.Context) (*Policy, error)
+}
+
+type adminService struct {
+	startedAt     time.Time
+	keystore      Keystore
+	arkServers    *ArkServers
+	journal       SigningJournal
+	finalizations FinalizationQueue
+	intents       IntentStore
+	guard         *SigningGuard
+	health        HealthService
+}
+
+func NewAdminService(
+	keystore Keystore, arkServers *ArkServers, journal SigningJournal,
+	finalizations FinalizationQueue, intents IntentStore, guard *SigningGuard,
+	health HealthService,
+) AdminService {
+	return &adminService{
+		startedAt:     time.Now(),
+		keystore:      keystore,
+		arkServers:    arkServers,
+		journal:       journal,
+		finalizations: finalizations,
+		intents:       intents,
+		guard:         guard,
+		health:        health,
+	}
+}
+
+func (a *adminService) GetStatus(ctx context.Context) (*ServiceStatus, error) {
+	health, err := a.health.Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceStatus{
+		StartedAt:     a.startedAt.Unix(),
+		SigningPaused: a.guard.IsPaused(),
+		Health:        *health,
+		ArkServers:    a.arkServers.list(),
+	}, nil
+}
+
+func (a *adminService) GetKeyInfo(_ context.Context) (*KeyInfo, error) {
+	info := &KeyInfo{Initialized: a.keystore.IsInitialized()}
+	if !info.Initialized {
+		return info, nil
+	}
+
+	keys, err := a.keystore.SignerKeys()
+	if err != nil {
+		return nil, err
+	}
+	info.Unlocked = !a.keystore.IsLocked()
+	info.Keys = keys
+	return info, nil
+}
+
+func (a *adminService) PauseSigning(_ context.Context) error {
+	a.guard.Pause()
+	return nil
+}
+
+func (a *adminService) ResumeSigning(_ context.Context) error {
+	a.guard.Resume()
+	return nil
+}
+
+func (a *adminService) ListSignatures(ctx context.Context, limit int) ([]Signature, error) {
+	if limit <= 0 {
+		limit = DefaultSignaturesLimit
+	}
+	return a.journal.ListRecent(ctx, limit)
+}
+
+func (a *adminService) ListFinalizations(ctx context.Context) ([]Finalization, error) {
+	return a.finalizations.List(ctx)
+}
+
+func (a *adminService) RetryFinalization(ctx context.Context, txid string) error {
+	return a.finalizations.Retry(ctx, txid)
+}
+
+func (a *adminService) ListIntents(ctx context.Context) ([]IntentRecord, error) {
+	records, err := a.intents.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the expired ones may not be purged yet
+	now := time.Now()
+	active := make([]IntentRecord, 0, len(records))
+	for _, record := range records {
+		if !record.IsExpired(now) {
+			active = append(active, record)
+		}
+	}
+	return active, nil
+}
+
+func (a *adminService) GetPolicy(_ context.Context) (*Policy, error) {
+	policy := a.guard.Policy()
+	return &policy, nil
+}
+
+func (a *adminService) UpdatePolicy(_ context.Context, policy Policy) error {
+	return a.guard.SetPolicy(policy)
+}
+
+func (a *adminService) ReloadPolicy(_ context.Context) (*Policy, error) {
+	return a.guard.ReloadPolicy()
+}
@@ -0,0 +1,504 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arkade-os/go-sdk/client"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ErrUnknownArkServer = errors.New("unknown ark server")
+	// ErrArkServerUnavailable is returned while the info of an arkd was never
+	// fetched, the request can be retried once it's reachable
+	ErrArkServerUnavailable = errors.New("ark server unavailable")
+)
+
+// timeout of the first info fetches, the watchers keep trying in background
+const arkServerStartupTimeout = 10 * time.Second
+
+type ArkdWatcherConfig struct {
+	// RefreshInterval is the delay between two fetches of the arkd info
+	RefreshInterval time.Duration
+	// the retries of a failed fetch start at 1s and double up to MaxRetryDelay
+	MaxRetryDelay time.Duration
+}
+
+var DefaultArkdWatcherConfig = ArkdWatcherConfig{
+	RefreshInterval: 1 * time.Minute,
+	MaxRetryDelay:   30 * time.Second,
+}
+
+// ArkServer is an arkd instance served by the introspector
+type ArkServer struct {
+	URL string
+	// hex-encoded compressed signer pubkey of arkd, empty if never fetched
+	SignerPublicKey string
+	// unix time of the last successful info fetch, 0 if never fetched
+	UpdatedAt int64
+	// error of the last info fetch, empty if it succeeded
+	LastError string
+}
+
+// ArkdKeyStore persists the signer pubkeys seen for each arkd, a rotated key
+// may still be in the closures of unspent vtxos after a restart
+type ArkdKeyStore interface {
+	// Get returns the hex-encoded compressed pubkeys seen for the url
+	Get(ctx context.Context, url string) ([]string, error)
+	// Set replaces the pubkeys seen for the url
+	Set(ctx context.Context, url string, pubkeys []string) error
+	Close()
+}
+
+// arkServer is a snapshot of the info of an arkd, replaced on every refresh
+type arkServer struct {
+	url    string
+	client client.TransportClient
+	// current signer pubkey of arkd
+	pubKey *btcec.PublicKey
+	// hex-encoded compressed pubKey, persisted to route the background jobs
+	id string
+	// the current, deprecated and previously seen signer pubkeys
+	pubKeys []*btcec.PublicKey
+	// arkd unroll closure of the checkpoint outputs
+	checkpointTapscript []byte
+	// output script of the arkd forfeit address
+	forfeitPkScript []byte
+	updatedAt       time.Time
+}
+
+// owns reports whether the pubkey is one of the signer keys of arkd
+func (s *arkServer) owns(pubkey string) bool {
+	for _, key := range s.pubKeys {
+		if hex.EncodeToString(key.SerializeCompressed()) == pubkey {
+			return true
+		}
+	}
+	return false
+}
+
+// arkdWatcher refreshes the info of an arkd until the servers are closed
+type arkdWatcher struct {
+	url    string
+	client client.TransportClient
+
+	lock    sync.RWMutex
+	server  *arkServer
+	lastErr error
+	// signer pubkeys persisted by the previous runs, or by the last refresh
+	knownKeys []*btcec.PublicKey
+}
+
+func (w *arkdWatcher) snapshot() (*arkServer, error) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.server, w.lastErr
+}
+
+// refresh replaces the snapshot with the fetched info, unless the signer
+// pubkey is owned by another arkd
+func (w *arkdWatcher) refresh(ctx context.Context, servers *ArkServers) error {
+	w.lock.RLock()
+	previous := w.server
+	knownKeys := w.knownKeys
+	w.lock.RUnlock()
+
+	server, err := fetchArkServer(ctx, w.url, w.client, knownKeys)
+	if err == nil && len(server.pubKeys) != len(knownKeys) {
+		err = servers.persistKeys(ctx, w.url, server.pubKeys)
+	}
+	if err == nil {
+		for _, other := range servers.watchers {
+			if other == w {
+				continue
+			}
+			if snapshot, _ := other.snapshot(); snapshot != nil && snapshot.owns(server.id) {
+				err = fmt.Errorf("arkd signer pubkey %s already served by %s", server.id, other.url)
+				break
+			}
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lastErr = err
+	if err != nil {
+		return err
+	}
+	if previous != nil && previous.id != server.id {
+		log.WithFields(log.Fields{
+			"url": w.url, "old_pubkey": previous.id, "new_pubkey": server.id,
+		}).Warn("arkd signer key rotated")
+	}
+	w.server = server
+	w.knownKeys = server.pubKeys
+	return nil
+}
+
+// pubKeys returns the signer pubkeys of the snapshot, the persisted ones if
+// the arkd wasn't reached yet
+func (w *arkdWatcher) pubKeys() []*btcec.PublicKey {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if w.server != nil {
+		return w.server.pubKeys
+	}
+	return w.knownKeys
+}
+
+// ArkServers routes the requests to the arkd instances served by the
+// introspector, by the arkd signer pubkey found in the tapscript closures.
+// The first server is the default one, used for the records persisted
+// before the arkd key was recorded. The info of each arkd is refreshed in
+// background, the servers not reached yet make the requests routed to
+// them fail with ErrArkServerUnavailable.
+type ArkServers struct {
+	watchers []*arkdWatcher
+	keys     ArkdKeyStore
+	config   ArkdWatcherConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewArkServers creates a client for each url and starts watching its
+// server info, it doesn't fail if an arkd is unreachable. The key store is
+// closed along with the servers, even if the creation fails.
+func NewArkServers(
+	ctx context.Context, urls []string, newClient func(url string) (client.TransportClient, error),
+	keys ArkdKeyStore, config ArkdWatcherConfig,
+) (*ArkServers, error) {
+	watchCtx, cancel := context.WithCancel(context.Background())
+	servers := &ArkServers{
+		watchers: make([]*arkdWatcher, 0, len(urls)),
+		keys:     keys,
+		config:   config,
+		ctx:      watchCtx,
+		cancel:   cancel,
+	}
+	if len(urls) == 0 {
+		servers.close()
+		return nil, fmt.Errorf("missing arkd url")
+	}
+	for _, url := range urls {
+		knownKeys, err := servers.loadKeys(ctx, url)
+		if err != nil {
+			servers.close()
+			return nil, err
+		}
+		arkdClient, err := newClient(url)
+		if err != nil {
+			servers.close()
+			return nil, fmt.Errorf("failed to create arkd client for %s: %w", url, err)
+		}
+		servers.watchers = append(servers.watchers, &arkdWatcher{
+			url: url, client: arkdClient, knownKeys: knownKeys,
+		})
+	}
+
+	// a first attempt is made so that a reachable arkd is served right away,
+	// in order so that the first url wins if two share the same signer
+	startupCtx, cancelStartup := context.WithTimeout(ctx, arkServerStartupTimeout)
+	defer cancelStartup()
+	for _, watcher := range servers.watchers {
+		if err := watcher.refresh(startupCtx, servers); err != nil {
+			log.WithError(err).WithField("url", watcher.url).Warn(
+				"arkd not served until its info is fetched",
+			)
+		}
+	}
+
+	for _, watcher := range servers.watchers {
+		servers.wg.Add(1)
+		go servers.watch(watcher)
+	}
+	return servers, nil
+}
+
+// watch refreshes the info of the arkd periodically, retrying the failed
+// fetches with backoff
+func (a *ArkServers) watch(watcher *arkdWatcher) {
+	defer a.wg.Done()
+
+	const initialRetryDelay = time.Second
+	delay := a.config.RefreshInterval
+	if _, err := watcher.snapshot(); err != nil {
+		delay = initialRetryDelay
+	}
+	retryDelay := initialRetryDelay
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		if err := watcher.refresh(a.ctx, a); err != nil {
+			if a.ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).WithField("url", watcher.url).Warn("failed to refresh arkd info")
+			delay = applyJitter(retryDelay, 0.2)
+			retryDelay = min(a.config.MaxRetryDelay, 2*retryDelay)
+			continue
+		}
+		delay = a.config.RefreshInterval
+		retryDelay = initialRetryDelay
+	}
+}
+
+func (a *ArkServers) loadKeys(ctx context.Context, url string) ([]*btcec.PublicKey, error) {
+	if a.keys == nil {
+		return nil, nil
+	}
+	encoded, err := a.keys.Get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the signer pubkeys of %s: %w", url, err)
+	}
+	pubKeys := make([]*btcec.PublicKey, 0, len(encoded))
+	for _, pubkey := range encoded {
+		pubKey, err := parsePubKey(pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid persisted signer pubkey of %s: %w", url, err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+func (a *ArkServers) persistKeys(ctx context.Context, url string, pubKeys []*btcec.PublicKey) error {
+	if a.keys == nil {
+		return nil
+	}
+	encoded := make([]string, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		encoded = append(encoded, hex.EncodeToString(pubKey.SerializeCompressed()))
+	}
+	if err := a.keys.Set(ctx, url, encoded); err != nil {
+		return fmt.Errorf("failed to persist arkd signer pubkeys: %w", err)
+	}
+	return nil
+}
+
+// fetchArkServer keeps the signer pubkeys known so far, a rotated key may
+// still be in the closures of unspent vtxos
+func fetchArkServer(
+	ctx context.Context, url string, arkdClient client.TransportClient, knownKeys []*btcec.PublicKey,
+) (*arkServer, error) {
+	arkdInfo, err := arkdClient.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch arkd info: %w", err)
+	}
+	if arkdInfo == nil {
+		return nil, fmt.Errorf("arkd info is required")
+	}
+	if arkdInfo.SignerPubKey == "" {
+		return nil, fmt.Errorf("arkd info does not include signer pubkey")
+	}
+
+	arkdPubKey, err := parsePubKey(arkdInfo.SignerPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arkd signer pubkey: %w", err)
+	}
+
+	if arkdInfo.CheckpointTapscript == "" {
+		return nil, fmt.Errorf("arkd info does not include checkpoint tapscript")
+	}
+	checkpointTapscript, err := hex.DecodeString(arkdInfo.CheckpointTapscript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode arkd checkpoint tapscript: %w", err)
+	}
+
+	if arkdInfo.ForfeitAddress == "" {
+		return nil, fmt.Errorf("arkd info does not include forfeit address")
+	}
+	forfeitAddr, err := btcutil.DecodeAddress(arkdInfo.ForfeitAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode arkd forfeit address: %w", err)
+	}
+	forfeitPkScript, err := txscript.PayToAddrScript(forfeitAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute arkd forfeit script: %w", err)
+	}
+
+	pubKeys := []*btcec.PublicKey{arkdPubKey}
+	for _, deprecated := range arkdInfo.DeprecatedSignerPubKeys {
+		pubKey, err := parsePubKey(deprecated.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arkd deprecated signer pubkey: %w", err)
+		}
+		if !containsPubKey(pubKeys, pubKey) {
+			pubKeys = append(pubKeys, pubKey)
+		}
+	}
+	for _, pubKey := range knownKeys {
+		if !containsPubKey(pubKeys, pubKey) {
+			pubKeys = append(pubKeys, pubKey)
+		}
+	}
+
+	return &arkServer{
+		url:                 url,
+		client:              arkdClient,
+		pubKey:              arkdPubKey,
+		id:                  hex.EncodeToString(arkdPubKey.SerializeCompressed()),
+		pubKeys:             pubKeys,
+		checkpointTapscript: checkpointTapscript,
+		forfeitPkScript:     forfeitPkScript,
+		updatedAt:           time.Now(),
+	}, nil
+}
+
+func parsePubKey(pubkey string) (*btcec.PublicKey, error) {
+	decoded, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return btcec.ParsePubKey(decoded)
+}
+
+// get returns the server owning the given signer pubkey, the default one if
+// empty
+func (a *ArkServers) get(pubkey string) (*arkServer, error) {
+	if pubkey == "" {
+		return a.available(a.watchers[0])
+	}
+
+	unavailable := false
+	for _, watcher := range a.watchers {
+		server, _ := watcher.snapshot()
+		if server == nil {
+			unavailable = true
+			continue
+		}
+		if server.owns(pubkey) {
+			return server, nil
+		}
+	}
+	// the key may be owned by an arkd not reached yet
+	if unavailable {
+		return nil, fmt.Errorf("%w: no reachable arkd owns %s", ErrArkServerUnavailable, pubkey)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownArkServer, pubkey)
+}
+
+// route returns the server whose signer pubkeys include one of the closure ones
+func (a *ArkServers) route(pubkeys []*btcec.PublicKey) (*arkServer, error) {
+	unavailable := false
+	for _, watcher := range a.watchers {
+		server, _ := watcher.snapshot()
+		if server == nil {
+			unavailable = true
+			continue
+		}
+		for _, pubKey := range server.pubKeys {
+			if containsPubKey(pubkeys, pubKey) {
+				return server, nil
+			}
+		}
+	}
+	if unavailable {
+		return nil, fmt.Errorf(
+			"%w: closure does not contain the signer pubkey of a reachable arkd",
+			ErrArkServerUnavailable,
+		)
+	}
+	return nil, fmt.Errorf("%w: closure does not contain any served arkd signer pubkey", ErrUnknownArkServer)
+}
+
+// checkUnbound fails if the closure contains a signer pubkey of any of the
+// servers, the unreachable ones being checked against their persisted keys,
+// or with ErrArkServerUnavailable if an arkd was never reached since none of
+// its keys is known
+func (a *ArkServers) checkUnbound(pubkeys []*btcec.PublicKey) error {
+	unavailable := false
+	for _, watcher := range a.watchers {
+		keys := watcher.pubKeys()
+		for _, pubKey := range keys {
+			if containsPubKey(pubkeys, pubKey) {
+				return fmt.Errorf("closure contains the signer pubkey of %s", watcher.url)
+			}
+		}
+		if server, _ := watcher.snapshot(); server == nil && len(keys) == 0 {
+			unavailable = true
+		}
+	}
+	if unavailable {
+		return fmt.Errorf(
+			"%w: the closure may contain the signer pubkey of an unreachable arkd",
+			ErrArkServerUnavailable,
+		)
+	}
+	return nil
+}
+
+func (a *ArkServers) available(watcher *arkdWatcher) (*arkServer, error) {
+	server, err := watcher.snapshot()
+	if server == nil {
+		if err == nil {
+			err = fmt.Errorf("info not fetched yet")
+		}
+		return nil, fmt.Errorf("%w: %s: %s", ErrArkServerUnavailable, watcher.url, err)
+	}
+	return server, nil
+}
+
+func (a *ArkServers) list() []ArkServer {
+	list := make([]ArkServer, 0, len(a.watchers))
+	for _, watcher := range a.watchers {
+		server, err := watcher.snapshot()
+		info := ArkServer{URL: watcher.url}
+		if server != nil {
+			info.SignerPublicKey = server.id
+			info.UpdatedAt = server.updatedAt.Unix()
+		}
+		if err != nil {
+			info.LastError = err.Error()
+		}
+		list = append(list, info)
+	}
+	return list
+}
+
+func (a *ArkServers) close() {
+	a.cancel()
+	a.wg.Wait()
+	for _, watcher := range a.watchers {
+		watcher.client.Close()
+	}
+	if a.keys != nil {
+		a.keys.Close()
+	}
+}
+
+// serverRouter checks that all the inputs of a tx are bound to the same arkd
+type serverRouter struct {
+	servers *ArkServers
+	server  *arkServer
+}
+
+func (r *serverRouter) route(pubkeys []*btcec.PublicKey) (*arkServer, error) {
+	server, err := r.servers.route(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	if r.server != nil && r.server.url != server.url {
+		return nil, fmt.Errorf(
+			"inputs are bound to different arkd instances %s and %s", r.server.url, server.url,
+		)
+	}
+	if r.server == nil {
+		r.server = server
+	}
+	return server, nil
+}
@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	arkscript "github.com/arkade-os/arkd/pkg/ark-lib/script"
+	sdkclient "github.com/arkade-os/go-sdk/client"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArkServers(t *testing.T) {
+	newKey := func(t *testing.T) *btcec.PrivateKey {
+		t.Helper()
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		return key
+	}
+	encodeKey := func(key *btcec.PrivateKey) string {
+		return hex.EncodeToString(key.PubKey().SerializeCompressed())
+	}
+	newInfo := func(t *testing.T, signerKey *btcec.PrivateKey, deprecated ...*btcec.PrivateKey) *sdkclient.Info {
+		t.Helper()
+		forfeitAddr, err := btcutil.NewAddressTaproot(
+			schnorr.SerializePubKey(newKey(t).PubKey()), &chaincfg.RegressionNetParams,
+		)
+		require.NoError(t, err)
+		checkpointTapscript, err := (&arkscript.CSVMultisigClosure{
+			MultisigClosure: arkscript.MultisigClosure{PubKeys: []*btcec.PublicKey{signerKey.PubKey()}},
+		}).Script()
+		require.NoError(t, err)
+		info := &sdkclient.Info{
+			SignerPubKey:        encodeKey(signerKey),
+			CheckpointTapscript: hex.EncodeToString(checkpointTapscript),
+			ForfeitAddress:      forfeitAddr.EncodeAddress(),
+		}
+		for _, key := range deprecated {
+			info.DeprecatedSignerPubKeys = append(
+				info.DeprecatedSignerPubKeys, sdkclient.DeprecatedSigner{PubKey: encodeKey(key)},
+			)
+		}
+		return info
+	}
+	// the watchers are stopped so that the test drives the refreshes
+	newServers := func(
+		t *testing.T, keys ArkdKeyStore, clients map[string]*mockArkdClient, urls ...string,
+	) *ArkServers {
+		t.Helper()
+		if keys == nil {
+			keys = newMockArkdKeyStore()
+		}
+		servers, err := NewArkServers(
+			t.Context(), urls, func(url string) (sdkclient.TransportClient, error) {
+				return clients[url], nil
+			}, keys, DefaultArkdWatcherConfig,
+		)
+		require.NoError(t, err)
+		servers.cancel()
+		servers.wg.Wait()
+		return servers
+	}
+
+	mainnetKey, testnetKey, userKey := newKey(t), newKey(t), newKey(t)
+	rotatedKey := newKey(t)
+	clients := map[string]*mockArkdClient{
+		"mainnet:7070": {info: newInfo(t, mainnetKey, rotatedKey)},
+		"testnet:7070": {info: newInfo(t, testnetKey)},
+	}
+	servers := newServers(t, nil, clients, "mainnet:7070", "testnet:7070")
+
+	t.Run("list", func(t *testing.T) {
+		list := servers.list()
+		require.Len(t, list, 2)
+		require.Equal(t, "mainnet:7070", list[0].URL)
+		require.Equal(t, encodeKey(mainnetKey), list[0].SignerPublicKey)
+		require.NotZero(t, list[0].UpdatedAt)
+		require.Empty(t, list[0].LastError)
+		require.Equal(t, "testnet:7070", list[1].URL)
+		require.Equal(t, encodeKey(testnetKey), list[1].SignerPublicKey)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		server, err := servers.get("")
+		require.NoError(t, err)
+		require.Equal(t, "mainnet:7070", server.url)
+
+		server, err = servers.get(encodeKey(testnetKey))
+		require.NoError(t, err)
+		require.Equal(t, "testnet:7070", server.url)
+
+		// deprecated signer
+		server, err = servers.get(encodeKey(rotatedKey))
+		require.NoError(t, err)
+		require.Equal(t, "mainnet:7070", server.url)
+
+		_, err = servers.get(encodeKey(userKey))
+		require.ErrorIs(t, err, ErrUnknownArkServer)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		server, err := servers.route([]*btcec.PublicKey{userKey.PubKey(), testnetKey.PubKey()})
+		require.NoError(t, err)
+		require.Equal(t, "testnet:7070", server.url)
+		require.ErrorContains(
+			t, servers.checkUnbound([]*btcec.PublicKey{mainnetKey.PubKey()}), "mainnet:7070",
+		)
+		require.ErrorContains(
+			t, servers.checkUnbound([]*btcec.PublicKey{rotatedKey.PubKey()}), "mainnet:7070",
+		)
+
+		_, err = servers.route([]*btcec.PublicKey{userKey.PubKey()})
+		require.ErrorIs(t, err, ErrUnknownArkServer)
+		require.NoError(t, servers.checkUnbound([]*btcec.PublicKey{userKey.PubKey()}))
+	})
+
+	t.Run("inputs bound to different servers", func(t *testing.T) {
+		router := &serverRouter{servers: servers}
+		_, err := router.route([]*btcec.PublicKey{userKey.PubKey(), mainnetKey.PubKey()})
+		require.NoError(t, err)
+		_, err = router.route([]*btcec.PublicKey{rotatedKey.PubKey()})
+		require.NoError(t, err)
+		_, err = router.route([]*btcec.PublicKey{testnetKey.PubKey()})
+		require.ErrorContains(t, err, "different arkd instances")
+	})
+
+	t.Run("same signer twice", func(t *testing.T) {
+		clients := map[string]*mockArkdClient{
+			"arkd-1:7070": {info: newInfo(t, mainnetKey)},
+			"arkd-2:7070": {info: newInfo(t, mainnetKey)},
+		}
+		servers := newServers(t, nil, clients, "arkd-1:7070", "arkd-2:7070")
+		list := servers.list()
+		require.Equal(t, encodeKey(mainnetKey), list[0].SignerPublicKey)
+		require.Empty(t, list[1].SignerPublicKey)
+		require.Contains(t, list[1].LastError, "already served by arkd-1:7070")
+
+		servers.close()
+		require.True(t, clients["arkd-1:7070"].closed)
+		require.True(t, clients["arkd-2:7070"].closed)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		oldKey, newSignerKey := newKey(t), newKey(t)
+		client := &mockArkdClient{info: newInfo(t, oldKey)}
+		servers := newServers(t, nil, map[string]*mockArkdClient{"arkd:7070": client}, "arkd:7070")
+
+		// the old key is not listed as deprecated anymore, it's still known
+		client.info = newInfo(t, newSignerKey)
+		require.NoError(t, servers.watchers[0].refresh(t.Context(), servers))
+
+		server, err := servers.get("")
+		require.NoError(t, err)
+		require.Equal(t, encodeKey(newSignerKey), server.id)
+		require.Error(t, servers.checkUnbound([]*btcec.PublicKey{oldKey.PubKey()}))
+		server, err = servers.get(encodeKey(oldKey))
+		require.NoError(t, err)
+		require.Equal(t, encodeKey(newSignerKey), server.id)
+	})
+
+	t.Run("degraded mode", func(t *testing.T) {
+		unreachable := &mockArkdClient{infoErr: fmt.Errorf("connection refused")}
+		clients := map[string]*mockArkdClient{
+			"mainnet:7070": {info: newInfo(t, mainnetKey)},
+			"down:7070":    unreachable,
+		}
+		servers := newServers(t, nil, clients, "mainnet:7070", "down:7070")
+
+		// the reachable arkd is served
+		_, err := servers.route([]*btcec.PublicKey{mainnetKey.PubKey()})
+		require.NoError(t, err)
+
+		// the key may belong to the unreachable one
+		_, err = servers.route([]*btcec.PublicKey{userKey.PubKey()})
+		require.ErrorIs(t, err, ErrArkServerUnavailable)
+		_, err = servers.get(encodeKey(testnetKey))
+		require.ErrorIs(t, err, ErrArkServerUnavailable)
+		require.Contains(t, servers.list()[1].LastError, "connection refused")
+
+		// the onchain check fails closed while an arkd key is unknown
+		err = servers.checkUnbound([]*btcec.PublicKey{userKey.PubKey()})
+		require.ErrorIs(t, err, ErrArkServerUnavailable)
+
+		unreachable.infoErr = nil
+		unreachable.info = newInfo(t, testnetKey)
+		require.NoError(t, servers.watchers[1].refresh(t.Context(), servers))
+		server, err := servers.get(encodeKey(testnetKey))
+		require.NoError(t, err)
+		require.Equal(t, "down:7070", server.url)
+		_, err = servers.route([]*btcec.PublicKey{userKey.PubKey()})
+		require.ErrorIs(t, err, ErrUnknownArkServer)
+		require.NoError(t, servers.checkUnbound([]*btcec.PublicKey{userKey.PubKey()}))
+	})
+
+	t.Run("default server unreachable", func(t *testing.T) {
+		clients := map[string]*mockArkdClient{
+			"down:7070": {infoErr: fmt.Errorf("connection refused")},
+		}
+		servers := newServers(t, nil, clients, "down:7070")
+		_, err := servers.get("")
+		require.ErrorIs(t, err, ErrArkServerUnavailable)
+		err = servers.checkUnbound([]*btcec.PublicKey{mainnetKey.PubKey()})
+		require.ErrorIs(t, err, ErrArkServerUnavailable)
+	})
+
+	t.Run("persisted keys", func(t *testing.T) {
+		keys := newMockArkdKeyStore()
+		oldKey, newSignerKey := newKey(t), newKey(t)
+		client := &mockArkdClient{info: newInfo(t, oldKey)}
+		servers := newServers(t, keys, map[string]*mockArkdClient{"arkd:7070": client}, "arkd:7070")
+		client.info = newInfo(t, newSignerKey)
+		require.NoError(t, servers.watchers[0].refresh(t.Context(), servers))
+		require.Equal(t, []string{encodeKey(newSignerKey), encodeKey(oldKey)}, keys.keys["arkd:7070"])
+
+		// restarted while arkd is unreachable, the known keys are still refused
+		// and the other closures accepted
+		client = &mockArkdClient{infoErr: fmt.Errorf("connection refused")}
+		servers = newServers(t, keys, map[string]*mockArkdClient{"arkd:7070": client}, "arkd:7070")
+		err := servers.checkUnbound([]*btcec.PublicKey{oldKey.PubKey()})
+		require.ErrorContains(t, err, "signer pubkey of arkd:7070")
+		require.NotErrorIs(t, err, ErrArkServerUnavailable)
+		require.NoError(t, servers.checkUnbound([]*btcec.PublicKey{userKey.PubKey()}))
+
+		// the rotated key is still routed once arkd is reached
+		client.infoErr = nil
+		client.info = newInfo(t, newSignerKey)
+		require.NoError(t, servers.watchers[0].refresh(t.Context(), servers))
+		server, err := servers.route([]*btcec.PublicKey{oldKey.PubKey()})
+		require.NoError(t, err)
+		require.Equal(t, encodeKey(newSignerKey), server.id)
+	})
+}
+
+type mockArkdKeyStore struct {
+	keys map[string][]string
+}
+
+func newMockArkdKeyStore() *mockArkdKeyStore {
+	return &mockArkdKeyStore{keys: make(map[string][]string)}
+}
+
+func (m *mockArkdKeyStore) Get(_ context.Context, url string) ([]string, error) {
+	return m.keys[url], nil
+}
+
+func (m *mockArkdKeyStore) Set(_ context.Context, url string, pubkeys []string) error {
+	m.keys[url] = pubkeys
+	return nil
+}
+
+func (m *mockArkdKeyStore) Close() {}
+
+// newTestArkServers serves the given snapshots without watching them
+func newTestArkServers(snapshots ...*arkServer) *ArkServers {
+	ctx, cancel := context.WithCancel(context.Background())
+	servers := &ArkServers{config: DefaultArkdWatcherConfig, ctx: ctx, cancel: cancel}
+	for i, snapshot := range snapshots {
+		if snapshot.url == "" {
+			snapshot.url = fmt.Sprintf("arkd-%d:7070", i)
+		}
+		if snapshot.updatedAt.IsZero() {
+			snapshot.updatedAt = time.Now()
+		}
+		servers.watchers = append(servers.watchers, &arkdWatcher{
+			url: snapshot.url, client: snapshot.client, server: snapshot,
+		})
+	}
+	return servers
+}
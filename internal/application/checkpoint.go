@@ -0,0 +1,114 @@
+package application
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/offchain"
+	"github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+)
+
+var ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// validateCheckpoint checks that the checkpoint spent by the given ark tx
+// input is the one arkd builds for the vtxo: a single input spending the vtxo
+// with the same closure as the ark tx input, and an output of the same value
+// locked by the arkd checkpoint tapscript + that closure, plus the anchor.
+func validateCheckpoint(
+	checkpoint *psbt.Packet, arkPtx *psbt.Packet, inputIndex int, checkpointTapscript []byte,
+) error {
+	if err := checkCheckpoint(checkpoint, arkPtx, inputIndex, checkpointTapscript); err != nil {
+		return fmt.Errorf("%w for input %d: %s", ErrInvalidCheckpoint, inputIndex, err)
+	}
+	return nil
+}
+
+func checkCheckpoint(
+	checkpoint *psbt.Packet, arkPtx *psbt.Packet, inputIndex int, checkpointTapscript []byte,
+) error {
+	if len(checkpoint.UnsignedTx.TxIn) != 1 || len(checkpoint.Inputs) != 1 {
+		return fmt.Errorf("expected 1 input, got %d", len(checkpoint.UnsignedTx.TxIn))
+	}
+
+	arkInput := arkPtx.Inputs[inputIndex]
+	if len(arkInput.TaprootLeafScript) == 0 {
+		return fmt.Errorf("ark tx input is missing taproot leaf script")
+	}
+	input := checkpoint.Inputs[0]
+	if len(input.TaprootLeafScript) == 0 {
+		return fmt.Errorf("missing taproot leaf script")
+	}
+	if input.WitnessUtxo == nil {
+		return fmt.Errorf("missing witness utxo")
+	}
+
+	// the arkade script was validated against the leaf of the ark tx input
+	leaf := input.TaprootLeafScript[0]
+	if !bytes.Equal(leaf.Script, arkInput.TaprootLeafScript[0].Script) {
+		return fmt.Errorf("leaf script differs from the ark tx input one")
+	}
+
+	controlBlock, err := txscript.ParseControlBlock(leaf.ControlBlock)
+	if err != nil {
+		return fmt.Errorf("invalid control block: %w", err)
+	}
+	vtxoTapKey := txscript.ComputeTaprootOutputKey(
+		controlBlock.InternalKey, controlBlock.RootHash(leaf.Script),
+	)
+	vtxoPkScript, err := script.P2TRScript(vtxoTapKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(vtxoPkScript, input.WitnessUtxo.PkScript) {
+		return fmt.Errorf("leaf script is not part of the spent vtxo script")
+	}
+
+	// the revealed tapscripts don't affect the txid, they're only required by the builder
+	revealedTapscripts := []string{hex.EncodeToString(leaf.Script)}
+	taptrees, err := txutils.GetArkPsbtFields(checkpoint, 0, txutils.VtxoTaprootTreeField)
+	if err == nil && len(taptrees) > 0 && len(taptrees[0]) > 0 {
+		revealedTapscripts = taptrees[0]
+	}
+
+	vtxo := offchain.VtxoInput{
+		Outpoint: &checkpoint.UnsignedTx.TxIn[0].PreviousOutPoint,
+		Amount:   input.WitnessUtxo.Value,
+		Tapscript: &waddrmgr.Tapscript{
+			ControlBlock:   controlBlock,
+			RevealedScript: leaf.Script,
+		},
+		RevealedTapscripts: revealedTapscripts,
+	}
+	output := &wire.TxOut{Value: vtxo.Amount, PkScript: vtxoPkScript}
+	expectedArkTx, expectedCheckpoints, err := offchain.BuildTxs(
+		[]offchain.VtxoInput{vtxo}, []*wire.TxOut{output}, checkpointTapscript,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build expected checkpoint: %w", err)
+	}
+
+	expected := expectedCheckpoints[0].UnsignedTx
+	if expected.TxHash() != checkpoint.UnsignedTx.TxHash() {
+		return fmt.Errorf("tx doesn't match the expected checkpoint %s", expected.TxID())
+	}
+
+	// the ark tx input must spend the checkpoint output with the same prevout
+	if arkPtx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint.Index != 0 {
+		return fmt.Errorf("ark tx input doesn't spend the checkpoint output")
+	}
+	checkpointOutput := expectedArkTx.Inputs[0].WitnessUtxo
+	if arkInput.WitnessUtxo == nil ||
+		arkInput.WitnessUtxo.Value != checkpointOutput.Value ||
+		!bytes.Equal(arkInput.WitnessUtxo.PkScript, checkpointOutput.PkScript) {
+		return fmt.Errorf("ark tx input witness utxo doesn't match the checkpoint output")
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"testing"
+
+	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	"github.com/arkade-os/arkd/pkg/ark-lib/offchain"
+	arkscript "github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCheckpoint(t *testing.T) {
+	newKey := func(t *testing.T) *btcec.PublicKey {
+		t.Helper()
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		return key.PubKey()
+	}
+	arkdKey := newKey(t)
+	ownerKey := newKey(t)
+
+	checkpointClosure := arkscript.CSVMultisigClosure{
+		MultisigClosure: arkscript.MultisigClosure{PubKeys: []*btcec.PublicKey{arkdKey}},
+		Locktime:        arklib.RelativeLocktime{Type: arklib.LocktimeTypeBlock, Value: 10},
+	}
+	checkpointTapscript, err := checkpointClosure.Script()
+	require.NoError(t, err)
+
+	collaborativeClosure := &arkscript.MultisigClosure{PubKeys: []*btcec.PublicKey{ownerKey, arkdKey}}
+	exitClosure := &arkscript.CSVMultisigClosure{
+		MultisigClosure: arkscript.MultisigClosure{PubKeys: []*btcec.PublicKey{ownerKey}},
+		Locktime:        arklib.RelativeLocktime{Type: arklib.LocktimeTypeBlock, Value: 144},
+	}
+	vtxoScript := arkscript.TapscriptsVtxoScript{
+		Closures: []arkscript.Closure{collaborativeClosure, exitClosure},
+	}
+
+	// build a valid ark tx spending a single vtxo through its checkpoint
+	newTxs := func(t *testing.T) (*psbt.Packet, *psbt.Packet) {
+		t.Helper()
+		tapKey, tapTree, err := vtxoScript.TapTree()
+		require.NoError(t, err)
+		leafScript, err := collaborativeClosure.Script()
+		require.NoError(t, err)
+		proof, err := tapTree.GetTaprootMerkleProof(txscript.NewBaseTapLeaf(leafScript).TapHash())
+		require.NoError(t, err)
+		controlBlock, err := txscript.ParseControlBlock(proof.ControlBlock)
+		require.NoError(t, err)
+		tapscripts, err := vtxoScript.Encode()
+		require.NoError(t, err)
+		pkScript, err := arkscript.P2TRScript(tapKey)
+		require.NoError(t, err)
+
+		arkTx, checkpoints, err := offchain.BuildTxs(
+			[]offchain.VtxoInput{{
+				Outpoint: &wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0},
+				Amount:   10_000,
+				Tapscript: &waddrmgr.Tapscript{
+					ControlBlock:   controlBlock,
+					RevealedScript: proof.Script,
+				},
+				RevealedTapscripts: tapscripts,
+			}},
+			[]*wire.TxOut{{Value: 10_000, PkScript: pkScript}},
+			checkpointTapscript,
+		)
+		require.NoError(t, err)
+		return arkTx, checkpoints[0]
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		arkTx, checkpoint := newTxs(t)
+		require.NoError(t, validateCheckpoint(checkpoint, arkTx, 0, checkpointTapscript))
+	})
+
+	t.Run("valid without taproot tree field", func(t *testing.T) {
+		arkTx, checkpoint := newTxs(t)
+		checkpoint.Inputs[0].Unknowns = nil
+		require.NoError(t, validateCheckpoint(checkpoint, arkTx, 0, checkpointTapscript))
+	})
+
+	testCases := []struct {
+		name   string
+		tamper func(t *testing.T, arkTx, checkpoint *psbt.Packet)
+	}{
+		{
+			name: "value not preserved",
+			tamper: func(_ *testing.T, _, checkpoint *psbt.Packet) {
+				checkpoint.UnsignedTx.TxOut[0].Value -= 1_000
+			},
+		},
+		{
+			name: "missing anchor",
+			tamper: func(_ *testing.T, _, checkpoint *psbt.Packet) {
+				checkpoint.UnsignedTx.TxOut = checkpoint.UnsignedTx.TxOut[:1]
+				checkpoint.Outputs = checkpoint.Outputs[:1]
+			},
+		},
+		{
+			name: "output not locked by checkpoint tapscript",
+			tamper: func(_ *testing.T, _, checkpoint *psbt.Packet) {
+				checkpoint.UnsignedTx.TxOut[0].PkScript = txutils.ANCHOR_PKSCRIPT
+			},
+		},
+		{
+			name: "extra input",
+			tamper: func(_ *testing.T, _, checkpoint *psbt.Packet) {
+				checkpoint.UnsignedTx.AddTxIn(&wire.TxIn{
+					PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{2}, Index: 0},
+				})
+				checkpoint.Inputs = append(checkpoint.Inputs, psbt.PInput{})
+			},
+		},
+		{
+			name: "leaf differs from ark tx input",
+			tamper: func(t *testing.T, arkTx, _ *psbt.Packet) {
+				exitScript, err := exitClosure.Script()
+				require.NoError(t, err)
+				arkTx.Inputs[0].TaprootLeafScript[0].Script = exitScript
+			},
+		},
+		{
+			name: "leaf not part of the vtxo",
+			tamper: func(t *testing.T, _, checkpoint *psbt.Packet) {
+				otherScript := arkscript.TapscriptsVtxoScript{
+					Closures: []arkscript.Closure{exitClosure},
+				}
+				tapKey, _, err := otherScript.TapTree()
+				require.NoError(t, err)
+				pkScript, err := arkscript.P2TRScript(tapKey)
+				require.NoError(t, err)
+				checkpoint.Inputs[0].WitnessUtxo.PkScript = pkScript
+			},
+		},
+		{
+			name: "ark tx witness utxo mismatch",
+			tamper: func(_ *testing.T, arkTx, _ *psbt.Packet) {
+				arkTx.Inputs[0].WitnessUtxo.Value += 1
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			arkTx, checkpoint := newTxs(t)
+			tc.tamper(t, arkTx, checkpoint)
+			// keep the ark tx pointing to the tampered checkpoint
+			arkTx.UnsignedTx.TxIn[0].PreviousOutPoint.Hash = checkpoint.UnsignedTx.TxHash()
+
+			err := validateCheckpoint(checkpoint, arkTx, 0, checkpointTapscript)
+			require.ErrorIs(t, err, ErrInvalidCheckpoint)
+		})
+	}
+}
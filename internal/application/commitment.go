@@ -0,0 +1,111 @@
+package application
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var ErrInvalidVtxoTree = errors.New("invalid vtxo tree")
+
+// validateVtxoTree checks that the vtxo tree is well formed, spends entirely
+// an output of the commitment tx and creates every vtxo of the intent
+func validateVtxoTree(vtxoTree *tree.TxTree, commitmentTx *psbt.Packet, record *IntentRecord) error {
+	if err := checkVtxoTree(vtxoTree, commitmentTx, record); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidVtxoTree, err)
+	}
+	return nil
+}
+
+func checkVtxoTree(vtxoTree *tree.TxTree, commitmentTx *psbt.Packet, record *IntentRecord) error {
+	vtxos := make([]IntentOutput, 0, len(record.Outputs))
+	for _, output := range record.Outputs {
+		if !output.Onchain {
+			vtxos = append(vtxos, output)
+		}
+	}
+	// an intent only made of onchain outputs doesn't need a tree
+	if len(vtxos) == 0 {
+		return nil
+	}
+	if vtxoTree == nil {
+		return fmt.Errorf("missing tree")
+	}
+
+	if _, err := checkTreeRoot(vtxoTree, commitmentTx); err != nil {
+		return err
+	}
+
+	leafOutputs := make([]*wire.TxOut, 0)
+	for _, leaf := range vtxoTree.Leaves() {
+		for _, output := range leaf.UnsignedTx.TxOut {
+			if !bytes.Equal(output.PkScript, txutils.ANCHOR_PKSCRIPT) {
+				leafOutputs = append(leafOutputs, output)
+			}
+		}
+	}
+
+	used := make(map[int]bool)
+	for _, vtxo := range vtxos {
+		pkScript, err := hex.DecodeString(vtxo.PkScript)
+		if err != nil {
+			return fmt.Errorf("invalid recorded output script: %w", err)
+		}
+
+		found := false
+		for index, output := range leafOutputs {
+			if used[index] || output.Value != vtxo.Amount || !bytes.Equal(output.PkScript, pkScript) {
+				continue
+			}
+			used[index] = true
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("vtxo %s of %d sats not found in tree leaves", vtxo.PkScript, vtxo.Amount)
+		}
+	}
+
+	return nil
+}
+
+// checkTreeRoot checks the parent/child chain of the tree and that its root
+// spends a non-anchor output of the commitment tx for its full amount, which
+// is returned
+func checkTreeRoot(txTree *tree.TxTree, commitmentTx *psbt.Packet) (*wire.TxOut, error) {
+	if err := txTree.Validate(); err != nil {
+		return nil, err
+	}
+
+	rootInput := txTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint
+	if rootInput.Hash != commitmentTx.UnsignedTx.TxHash() {
+		return nil, fmt.Errorf("root is not spending the commitment tx")
+	}
+	if int(rootInput.Index) >= len(commitmentTx.UnsignedTx.TxOut) {
+		return nil, fmt.Errorf("root input index out of range")
+	}
+
+	batchOutput := commitmentTx.UnsignedTx.TxOut[rootInput.Index]
+	if bytes.Equal(batchOutput.PkScript, txutils.ANCHOR_PKSCRIPT) {
+		return nil, fmt.Errorf("root is spending the commitment tx anchor")
+	}
+
+	rootAmount := int64(0)
+	for _, output := range txTree.Root.UnsignedTx.TxOut {
+		rootAmount += output.Value
+	}
+	if rootAmount != batchOutput.Value {
+		return nil, fmt.Errorf(
+			"root amount %d doesn't match the commitment tx output %d",
+			rootAmount, batchOutput.Value,
+		)
+	}
+
+	return batchOutput, nil
+}
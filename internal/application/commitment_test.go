@@ -0,0 +1,171 @@
+package application
+
+import (
+	"encoding/hex"
+	"testing"
+
+	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	arkscript "github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVtxoTree(t *testing.T) {
+	newPkScript := func(t *testing.T) []byte {
+		t.Helper()
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		pkScript, err := arkscript.P2TRScript(key.PubKey())
+		require.NoError(t, err)
+		return pkScript
+	}
+	cosignerKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	sweepRoot := make([]byte, 32)
+	expiry := arklib.RelativeLocktime{Type: arklib.LocktimeTypeBlock, Value: 144}
+
+	vtxoScript := newPkScript(t)
+	onchainScript := newPkScript(t)
+	record := &IntentRecord{
+		Outputs: []IntentOutput{
+			{Amount: 5_000, PkScript: hex.EncodeToString(vtxoScript)},
+			{Amount: 3_000, PkScript: hex.EncodeToString(onchainScript), Onchain: true},
+		},
+	}
+
+	receivers := []tree.Leaf{
+		{
+			Outputs: []tree.LeafOutput{{
+				Amount: 5_000, Script: hex.EncodeToString(vtxoScript),
+			}},
+			CosignersPublicKeys: []string{
+				hex.EncodeToString(cosignerKey.PubKey().SerializeCompressed()),
+			},
+		},
+		{
+			Outputs: []tree.LeafOutput{{
+				Amount: 7_000, Script: hex.EncodeToString(newPkScript(t)),
+			}},
+			CosignersPublicKeys: []string{
+				hex.EncodeToString(cosignerKey.PubKey().SerializeCompressed()),
+			},
+		},
+	}
+
+	// commitment tx with a batch output, the onchain output and the anchor
+	newCommitmentTx := func(t *testing.T, receivers []tree.Leaf) (*psbt.Packet, *tree.TxTree) {
+		t.Helper()
+		batchPkScript, batchAmount, err := tree.BuildBatchOutput(receivers, sweepRoot)
+		require.NoError(t, err)
+
+		ptx, err := psbt.New(
+			[]*wire.OutPoint{{Hash: chainhash.Hash{1}, Index: 0}},
+			[]*wire.TxOut{
+				{Value: batchAmount, PkScript: batchPkScript},
+				{Value: 3_000, PkScript: onchainScript},
+				txutils.AnchorOutput(),
+			},
+			3, 0, []uint32{wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+
+		vtxoTree, err := tree.BuildVtxoTree(
+			&wire.OutPoint{Hash: ptx.UnsignedTx.TxHash(), Index: 0}, receivers, sweepRoot, expiry,
+		)
+		require.NoError(t, err)
+		return ptx, vtxoTree
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		commitmentTx, vtxoTree := newCommitmentTx(t, receivers)
+		require.NoError(t, validateVtxoTree(vtxoTree, commitmentTx, record))
+	})
+
+	t.Run("onchain outputs only", func(t *testing.T) {
+		commitmentTx, _ := newCommitmentTx(t, receivers)
+		onchainOnly := &IntentRecord{Outputs: record.Outputs[1:]}
+		require.NoError(t, validateVtxoTree(nil, commitmentTx, onchainOnly))
+	})
+
+	testCases := []struct {
+		name      string
+		receivers []tree.Leaf
+		tamper    func(commitmentTx *psbt.Packet, vtxoTree *tree.TxTree) *tree.TxTree
+	}{
+		{
+			name: "missing tree",
+			tamper: func(_ *psbt.Packet, _ *tree.TxTree) *tree.TxTree {
+				return nil
+			},
+		},
+		{
+			name: "not rooted in commitment tx",
+			tamper: func(_ *psbt.Packet, vtxoTree *tree.TxTree) *tree.TxTree {
+				vtxoTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint.Hash = chainhash.Hash{2}
+				return vtxoTree
+			},
+		},
+		{
+			name: "spending the onchain output",
+			tamper: func(_ *psbt.Packet, vtxoTree *tree.TxTree) *tree.TxTree {
+				vtxoTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint.Index = 1
+				return vtxoTree
+			},
+		},
+		{
+			name: "vtxo not in the tree",
+			receivers: []tree.Leaf{{
+				Outputs: []tree.LeafOutput{{
+					Amount: 5_000, Script: hex.EncodeToString(newPkScript(t)),
+				}},
+				CosignersPublicKeys: receivers[0].CosignersPublicKeys,
+			}},
+		},
+		{
+			name: "vtxo amount mismatch",
+			receivers: []tree.Leaf{{
+				Outputs: []tree.LeafOutput{{
+					Amount: 4_000, Script: hex.EncodeToString(vtxoScript),
+				}},
+				CosignersPublicKeys: receivers[0].CosignersPublicKeys,
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			treeReceivers := receivers
+			if tc.receivers != nil {
+				treeReceivers = tc.receivers
+			}
+			commitmentTx, vtxoTree := newCommitmentTx(t, treeReceivers)
+			if tc.tamper != nil {
+				vtxoTree = tc.tamper(commitmentTx, vtxoTree)
+			}
+			err := validateVtxoTree(vtxoTree, commitmentTx, record)
+			require.ErrorIs(t, err, ErrInvalidVtxoTree)
+		})
+	}
+
+	// the forfeits are refused before the connectors are even looked at
+	t.Run("forfeits without the intent vtxos", func(t *testing.T) {
+		otherReceivers := []tree.Leaf{receivers[1]}
+		commitmentTx, vtxoTree := newCommitmentTx(t, otherReceivers)
+		forfeits := []*psbt.Packet{{}}
+
+		err := validateBatch(BatchFinalization{
+			Forfeits: forfeits, VtxoTree: vtxoTree, CommitmentTx: commitmentTx,
+		}, record)
+		require.ErrorIs(t, err, ErrInvalidVtxoTree)
+
+		err = validateBatch(BatchFinalization{
+			Forfeits: forfeits, CommitmentTx: commitmentTx,
+		}, record)
+		require.ErrorIs(t, err, ErrInvalidVtxoTree)
+	})
+}
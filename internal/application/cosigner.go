@@ -0,0 +1,367 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/arkade-os/go-sdk/client"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrTreeCosignerDisabled is returned by CreateTreeCosigner if the
+	// introspector doesn't cosign vtxo trees
+	ErrTreeCosignerDisabled = errors.New("tree cosigner disabled")
+	ErrInvalidCosignedTree  = errors.New("invalid cosigned vtxo tree")
+)
+
+type TreeCosignerConfig struct {
+	Enabled bool
+	// SessionTimeout bounds the lifetime of a cosigner key, from its creation
+	// to the signature of the tree
+	SessionTimeout time.Duration
+}
+
+var DefaultTreeCosignerConfig = TreeCosignerConfig{
+	Enabled:        false,
+	SessionTimeout: 10 * time.Minute,
+}
+
+// TreeCosigner is an ephemeral key to be listed in the cosigners of a
+// register message, the introspector cosigns the vtxo tree of the batch
+// once the intent is signed with SubmitIntent
+type TreeCosigner struct {
+	PublicKey string
+	ExpireAt  int64
+}
+
+type treeCosigner struct {
+	session  tree.SignerSession
+	expireAt time.Time
+	// proof txid of the intent listing the key, empty until SubmitIntent
+	proofTxid string
+}
+
+// treeCosigners holds the keys handed out by CreateTreeCosigner, they are
+// never persisted: a restart only makes the batch fail
+type treeCosigners struct {
+	lock sync.Mutex
+	keys map[string]*treeCosigner
+	wg   sync.WaitGroup
+}
+
+func newTreeCosigners() *treeCosigners {
+	return &treeCosigners{keys: make(map[string]*treeCosigner)}
+}
+
+func (c *treeCosigners) add(cosigner *treeCosigner) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	for pubkey, key := range c.keys {
+		if key.proofTxid == "" && now.After(key.expireAt) {
+			delete(c.keys, pubkey)
+		}
+	}
+	c.keys[cosigner.session.GetPublicKey()] = cosigner
+}
+
+// bind returns the unbound keys listed by the message, now bound to the intent
+func (c *treeCosigners) bind(proofTxid string, message intent.RegisterMessage) []*treeCosigner {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bound := make([]*treeCosigner, 0, len(message.CosignersPublicKeys))
+	for _, pubkey := range message.CosignersPublicKeys {
+		key, ok := c.keys[pubkey]
+		if !ok || key.proofTxid != "" || time.Now().After(key.expireAt) {
+			continue
+		}
+		key.proofTxid = proofTxid
+		bound = append(bound, key)
+	}
+	return bound
+}
+
+func (c *treeCosigners) remove(pubkey string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.keys, pubkey)
+}
+
+func (s *service) CreateTreeCosigner(_ context.Context) (*TreeCosigner, error) {
+	if !s.cosigner.Enabled {
+		return nil, ErrTreeCosignerDisabled
+	}
+
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cosigner key: %w", err)
+	}
+	cosigner := &treeCosigner{
+		session:  tree.NewTreeSignerSession(key),
+		expireAt: time.Now().Add(s.cosigner.SessionTimeout),
+	}
+	s.cosigners.add(cosigner)
+
+	return &TreeCosigner{
+		PublicKey: cosigner.session.GetPublicKey(),
+		ExpireAt:  cosigner.expireAt.Unix(),
+	}, nil
+}
+
+// startTreeCosigners follows the batch events for the cosigner keys listed by
+// a signed intent
+func (s *service) startTreeCosigners(proofTxid string, message intent.RegisterMessage) {
+	if !s.cosigner.Enabled {
+		return
+	}
+	for _, cosigner := range s.cosigners.bind(proofTxid, message) {
+		s.cosigners.wg.Add(1)
+		go func(cosigner *treeCosigner) {
+			defer s.cosigners.wg.Done()
+			pubkey := cosigner.session.GetPublicKey()
+			defer s.cosigners.remove(pubkey)
+
+			logger := log.WithFields(log.Fields{"txid": proofTxid, "cosigner": pubkey})
+			if err := s.runTreeCosigner(cosigner); err != nil {
+				logger.WithError(err).Warn("vtxo tree not cosigned")
+				return
+			}
+			logger.Info("vtxo tree cosigned")
+		}(cosigner)
+	}
+}
+
+// runTreeCosigner signs the vtxo tree of the first batch listing the key,
+// the key is dropped afterwards
+func (s *service) runTreeCosigner(cosigner *treeCosigner) error {
+	ctx, cancel := context.WithDeadline(s.ctx, cosigner.expireAt)
+	defer cancel()
+
+	// the batch is run by the arkd the intent is bound to
+	record, err := s.intents.Get(ctx, cosigner.proofTxid)
+	if err != nil {
+		return fmt.Errorf("failed to read intent: %w", err)
+	}
+	server, err := s.arkServers.get(record.ArkdPubKey)
+	if err != nil {
+		return err
+	}
+
+	pubkey := cosigner.session.GetPublicKey()
+	eventsCh, closeStream, err := server.client.GetEventStream(ctx, []string{pubkey})
+	if err != nil {
+		return fmt.Errorf("failed to get event stream: %w", err)
+	}
+	defer closeStream()
+
+	var (
+		batchId     string
+		batchExpiry arklib.RelativeLocktime
+		flatTree    tree.FlatTxTree
+		nonceSent   bool
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notify, ok := <-eventsCh:
+			if !ok {
+				return fmt.Errorf("event stream closed")
+			}
+			if notify.Err != nil {
+				return notify.Err
+			}
+
+			switch event := notify.Event.(type) {
+			case client.BatchStartedEvent:
+				batchId = event.Id
+				batchExpiry = batchExpiryLocktime(uint32(event.BatchExpiry))
+				flatTree = nil
+				nonceSent = false
+			case client.BatchFailedEvent:
+				// the intent may be included in the next batch
+				if event.Id == batchId {
+					batchId = ""
+				}
+			case client.TreeTxEvent:
+				if event.Id == batchId && event.BatchIndex == 0 {
+					flatTree = append(flatTree, event.Node)
+				}
+			case client.TreeSigningStartedEvent:
+				if event.Id != batchId || !slices.Contains(event.CosignersPubkeys, pubkey) {
+					continue
+				}
+				vtxoTree, err := tree.NewTxTree(flatTree)
+				if err != nil {
+					return fmt.Errorf("failed to build vtxo tree: %w", err)
+				}
+				if err := s.startTreeSigning(
+					ctx, server, cosigner.session, event, vtxoTree, batchExpiry, record,
+				); err != nil {
+					return err
+				}
+				nonceSent = true
+			case client.TreeNoncesAggregatedEvent:
+				if event.Id != batchId || !nonceSent {
+					continue
+				}
+				return s.submitTreeSignatures(ctx, server, cosigner.session, event)
+			}
+		}
+	}
+}
+
+// startTreeSigning checks the tree only creates the vtxos of the intent in
+// the leaves cosigned by the session, then submits the session nonces
+func (s *service) startTreeSigning(
+	ctx context.Context, server *arkServer, session tree.SignerSession, event client.TreeSigningStartedEvent,
+	vtxoTree *tree.TxTree, batchExpiry arklib.RelativeLocktime, record *IntentRecord,
+) error {
+	if vtxoTree == nil || vtxoTree.Root == nil {
+		return fmt.Errorf("%w: missing tree", ErrInvalidCosignedTree)
+	}
+
+	commitmentTx, err := psbt.NewFromRawBytes(strings.NewReader(event.UnsignedCommitmentTx), true)
+	if err != nil {
+		return fmt.Errorf("failed to decode commitment tx: %w", err)
+	}
+	if err := validateCosignedTree(vtxoTree, commitmentTx, session.GetPublicKey(), record); err != nil {
+		return err
+	}
+
+	arkdInfo, err := server.client.GetInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch arkd info: %w", err)
+	}
+	forfeitPubKeyBytes, err := hex.DecodeString(arkdInfo.ForfeitPubKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode arkd forfeit pubkey: %w", err)
+	}
+	forfeitPubKey, err := btcec.ParsePubKey(forfeitPubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse arkd forfeit pubkey: %w", err)
+	}
+
+	sweepClosure := script.CSVMultisigClosure{
+		MultisigClosure: script.MultisigClosure{PubKeys: []*btcec.PublicKey{forfeitPubKey}},
+		Locktime:        batchExpiry,
+	}
+	sweepScript, err := sweepClosure.Script()
+	if err != nil {
+		return err
+	}
+	sweepTapTree := txscript.AssembleTaprootScriptTree(txscript.NewBaseTapLeaf(sweepScript))
+	sweepRoot := sweepTapTree.RootNode.TapHash()
+
+	// the tree root spends the batch output, validated above
+	batchIndex := vtxoTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint.Index
+	batchAmount := commitmentTx.UnsignedTx.TxOut[batchIndex].Value
+
+	if err := session.Init(sweepRoot.CloneBytes(), batchAmount, vtxoTree); err != nil {
+		return fmt.Errorf("failed to init signer session: %w", err)
+	}
+	nonces, err := session.GetNonces()
+	if err != nil {
+		return fmt.Errorf("failed to generate nonces: %w", err)
+	}
+	if err := server.client.SubmitTreeNonces(ctx, event.Id, session.GetPublicKey(), nonces); err != nil {
+		return fmt.Errorf("failed to submit tree nonces: %w", err)
+	}
+	return nil
+}
+
+func (s *service) submitTreeSignatures(
+	ctx context.Context, server *arkServer, session tree.SignerSession, event client.TreeNoncesAggregatedEvent,
+) error {
+	session.SetAggregatedNonces(event.Nonces)
+
+	sigs, err := session.Sign()
+	if err != nil {
+		return fmt.Errorf("failed to sign vtxo tree: %w", err)
+	}
+	if err := server.client.SubmitTreeSignatures(ctx, event.Id, session.GetPublicKey(), sigs); err != nil {
+		return fmt.Errorf("failed to submit tree signatures: %w", err)
+	}
+	return nil
+}
+
+// validateCosignedTree checks the tree is rooted in the commitment tx and
+// creates the vtxos of the intent, and that the leaves cosigned by the key
+// don't create anything else
+func validateCosignedTree(
+	vtxoTree *tree.TxTree, commitmentTx *psbt.Packet, cosignerPubKey string, record *IntentRecord,
+) error {
+	if err := checkCosignedTree(vtxoTree, commitmentTx, cosignerPubKey, record); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCosignedTree, err)
+	}
+	return nil
+}
+
+func checkCosignedTree(
+	vtxoTree *tree.TxTree, commitmentTx *psbt.Packet, cosignerPubKey string, record *IntentRecord,
+) error {
+	if _, err := checkTreeRoot(vtxoTree, commitmentTx); err != nil {
+		return err
+	}
+	if err := checkVtxoTree(vtxoTree, commitmentTx, record); err != nil {
+		return err
+	}
+
+	decodedKey, err := hex.DecodeString(cosignerPubKey)
+	if err != nil {
+		return fmt.Errorf("invalid cosigner pubkey: %w", err)
+	}
+	cosignerKey, err := btcec.ParsePubKey(decodedKey)
+	if err != nil {
+		return fmt.Errorf("invalid cosigner pubkey: %w", err)
+	}
+
+	vtxos := make(map[string]int)
+	for _, output := range record.Outputs {
+		if !output.Onchain {
+			vtxos[fmt.Sprintf("%s:%d", output.PkScript, output.Amount)]++
+		}
+	}
+
+	for _, leaf := range vtxoTree.Leaves() {
+		cosigners, err := txutils.ParseCosignerKeysFromArkPsbt(leaf, 0)
+		if err != nil {
+			return fmt.Errorf("failed to parse leaf cosigners: %w", err)
+		}
+		if !containsPubKey(cosigners, cosignerKey) {
+			continue
+		}
+		for _, output := range leaf.UnsignedTx.TxOut {
+			if bytes.Equal(output.PkScript, txutils.ANCHOR_PKSCRIPT) {
+				continue
+			}
+			key := fmt.Sprintf("%s:%d", hex.EncodeToString(output.PkScript), output.Value)
+			if vtxos[key] == 0 {
+				return fmt.Errorf(
+					"cosigned leaf %s creates output %x of %d sats not in the intent",
+					leaf.UnsignedTx.TxID(), output.PkScript, output.Value,
+				)
+			}
+			vtxos[key]--
+		}
+	}
+	return nil
+}
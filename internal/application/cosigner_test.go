@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	arkscript "github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreeCosigner(t *testing.T) {
+	newKey := func(t *testing.T) *btcec.PrivateKey {
+		t.Helper()
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		return key
+	}
+	newPkScript := func(t *testing.T) []byte {
+		t.Helper()
+		pkScript, err := arkscript.P2TRScript(newKey(t).PubKey())
+		require.NoError(t, err)
+		return pkScript
+	}
+	encodeKey := func(key *btcec.PrivateKey) string {
+		return hex.EncodeToString(key.PubKey().SerializeCompressed())
+	}
+
+	introspectorKey := newKey(t)
+	otherKey := newKey(t)
+	sweepRoot := make([]byte, 32)
+	expiry := arklib.RelativeLocktime{Type: arklib.LocktimeTypeBlock, Value: 144}
+
+	vtxoScript := newPkScript(t)
+	record := &IntentRecord{
+		Outputs: []IntentOutput{{Amount: 5_000, PkScript: hex.EncodeToString(vtxoScript)}},
+	}
+
+	newCommitmentTx := func(t *testing.T, receivers []tree.Leaf) (*psbt.Packet, *tree.TxTree) {
+		t.Helper()
+		batchPkScript, batchAmount, err := tree.BuildBatchOutput(receivers, sweepRoot)
+		require.NoError(t, err)
+
+		ptx, err := psbt.New(
+			[]*wire.OutPoint{{Hash: chainhash.Hash{1}, Index: 0}},
+			[]*wire.TxOut{{Value: batchAmount, PkScript: batchPkScript}, txutils.AnchorOutput()},
+			3, 0, []uint32{wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+
+		vtxoTree, err := tree.BuildVtxoTree(
+			&wire.OutPoint{Hash: ptx.UnsignedTx.TxHash(), Index: 0}, receivers, sweepRoot, expiry,
+		)
+		require.NoError(t, err)
+		return ptx, vtxoTree
+	}
+
+	intentLeaf := tree.Leaf{
+		Outputs:             []tree.LeafOutput{{Amount: 5_000, Script: hex.EncodeToString(vtxoScript)}},
+		CosignersPublicKeys: []string{encodeKey(introspectorKey), encodeKey(otherKey)},
+	}
+	otherLeaf := tree.Leaf{
+		Outputs:             []tree.LeafOutput{{Amount: 7_000, Script: hex.EncodeToString(newPkScript(t))}},
+		CosignersPublicKeys: []string{encodeKey(otherKey)},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		commitmentTx, vtxoTree := newCommitmentTx(t, []tree.Leaf{intentLeaf, otherLeaf})
+		require.NoError(t, validateCosignedTree(vtxoTree, commitmentTx, encodeKey(introspectorKey), record))
+	})
+
+	t.Run("cosigned leaf paying another output", func(t *testing.T) {
+		tampered := otherLeaf
+		tampered.CosignersPublicKeys = []string{encodeKey(introspectorKey)}
+		commitmentTx, vtxoTree := newCommitmentTx(t, []tree.Leaf{intentLeaf, tampered})
+		err := validateCosignedTree(vtxoTree, commitmentTx, encodeKey(introspectorKey), record)
+		require.ErrorIs(t, err, ErrInvalidCosignedTree)
+	})
+
+	t.Run("intent vtxo missing", func(t *testing.T) {
+		commitmentTx, vtxoTree := newCommitmentTx(t, []tree.Leaf{otherLeaf})
+		err := validateCosignedTree(vtxoTree, commitmentTx, encodeKey(introspectorKey), record)
+		require.ErrorIs(t, err, ErrInvalidCosignedTree)
+	})
+
+	t.Run("bind keys", func(t *testing.T) {
+		cosigners := newTreeCosigners()
+		active := &treeCosigner{
+			session: tree.NewTreeSignerSession(newKey(t)), expireAt: time.Now().Add(time.Minute),
+		}
+		expired := &treeCosigner{
+			session: tree.NewTreeSignerSession(newKey(t)), expireAt: time.Now().Add(-time.Minute),
+		}
+		cosigners.add(active)
+		cosigners.add(expired)
+
+		message := intent.RegisterMessage{CosignersPublicKeys: []string{
+			active.session.GetPublicKey(), expired.session.GetPublicKey(), encodeKey(otherKey),
+		}}
+		bound := cosigners.bind("proof1", message)
+		require.Equal(t, []*treeCosigner{active}, bound)
+		require.Equal(t, "proof1", active.proofTxid)
+
+		// a key signs for a single intent
+		require.Empty(t, cosigners.bind("proof2", message))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		svc := &service{}
+		_, err := svc.CreateTreeCosigner(context.Background())
+		require.ErrorIs(t, err, ErrTreeCosignerDisabled)
+	})
+}
@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	ErrDelegateNotFound = errors.New("delegate not found")
+	ErrInvalidDelegate  = errors.New("invalid delegate")
+	// ErrDelegateSolverDisabled is returned by the delegate RPCs if the
+	// built-in solver is not enabled
+	ErrDelegateSolverDisabled = errors.New("delegate solver disabled")
+)
+
+type DelegateStatus string
+
+const (
+	// pending delegates wait for their refresh time or their next attempt
+	DelegateStatusPending DelegateStatus = "pending"
+	// running delegates have their intent registered in a batch session
+	DelegateStatusRunning DelegateStatus = "running"
+	DelegateStatusSettled DelegateStatus = "settled"
+	// failed delegates ran out of attempts
+	DelegateStatusFailed DelegateStatus = "failed"
+)
+
+// Delegate is a VTXO the introspector refreshes in a batch on behalf of its
+// owner, by registering the intent template and signing the forfeit
+type Delegate struct {
+	// Outpoint of the delegate VTXO, input 1 of the intent template
+	Outpoint string
+	Amount   int64
+	// Proof is the unsigned intent proof template, base64 encoded
+	Proof string
+	// Message is the encoded register message of the template, the solver
+	// adds its cosigner key and an expiry to it on each attempt
+	Message string
+	// ArkdPubKey is the signer pubkey of the arkd in the forfeit closure, the
+	// solver joins its batches
+	ArkdPubKey string
+	// RefreshAt is the unix time after which the solver joins a batch
+	RefreshAt      int64
+	Status         DelegateStatus
+	Attempts       int
+	LastError      string
+	CommitmentTxid string
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+type DelegateStore interface {
+	Upsert(ctx context.Context, delegate Delegate) error
+	// Get returns ErrDelegateNotFound if the outpoint is unknown
+	Get(ctx context.Context, outpoint string) (*Delegate, error)
+	GetByStatus(ctx context.Context, statuses ...DelegateStatus) ([]Delegate, error)
+	Close()
+}
+
+type DelegateSolverConfig struct {
+	Enabled bool
+	// RefreshWindow is how long before the VTXO expiry the solver refreshes it
+	RefreshWindow time.Duration
+	// SessionTimeout bounds a batch session, the intent expires after it
+	SessionTimeout time.Duration
+	MaxAttempts    int
+	RetryDelay     time.Duration
+}
+
+var DefaultDelegateSolverConfig = DelegateSolverConfig{
+	Enabled:        false,
+	RefreshWindow:  24 * time.Hour,
+	SessionTimeout: 5 * time.Minute,
+	MaxAttempts:    10,
+	RetryDelay:     time.Minute,
+}
+
+// DelegateRegistration is the intent template of a delegate VTXO
+type DelegateRegistration struct {
+	Proof   intent.Proof
+	Message intent.RegisterMessage
+	// VtxoExpireAt is the expiry of the VTXO, 0 to refresh it right away
+	VtxoExpireAt int64
+}
+
+// RegisterDelegate validates the intent template and schedules the refresh
+// of the delegate VTXO by the built-in solver
+func (s *service) RegisterDelegate(ctx context.Context, registration DelegateRegistration) (*Delegate, error) {
+	if !s.solver.Enabled {
+		return nil, ErrDelegateSolverDisabled
+	}
+	signers, err := s.getKeyring()
+	if err != nil {
+		return nil, err
+	}
+	defer signers.zero()
+
+	ptx := &registration.Proof.Packet
+	server, err := validateDelegateTemplate(ptx, signers, s.arkServers)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDelegate, err)
+	}
+
+	proof, err := ptx.B64Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode intent template: %w", err)
+	}
+	message, err := registration.Message.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode register message: %w", err)
+	}
+
+	now := time.Now()
+	refreshAt := now.Unix()
+	if registration.VtxoExpireAt > 0 {
+		refreshAt = max(refreshAt, registration.VtxoExpireAt-int64(s.solver.RefreshWindow.Seconds()))
+	}
+
+	outpoint := ptx.UnsignedTx.TxIn[1].PreviousOutPoint.String()
+	existing, err := s.delegates.Get(ctx, outpoint)
+	if err != nil && !errors.Is(err, ErrDelegateNotFound) {
+		return nil, fmt.Errorf("failed to read delegate: %w", err)
+	}
+	if existing != nil && existing.Status == DelegateStatusRunning {
+		return nil, fmt.Errorf("%w: %s is in a batch session", ErrInvalidDelegate, outpoint)
+	}
+
+	delegate := Delegate{
+		Outpoint:   outpoint,
+		Amount:     ptx.Inputs[1].WitnessUtxo.Value,
+		Proof:      proof,
+		Message:    message,
+		ArkdPubKey: server.id,
+		RefreshAt:  refreshAt,
+		Status:     DelegateStatusPending,
+		CreatedAt:  now.Unix(),
+		UpdatedAt:  now.Unix(),
+	}
+	if err := s.delegates.Upsert(ctx, delegate); err != nil {
+		return nil, fmt.Errorf("failed to save delegate: %w", err)
+	}
+	return &delegate, nil
+}
+
+func (s *service) GetDelegate(ctx context.Context, outpoint string) (*Delegate, error) {
+	if !s.solver.Enabled {
+		return nil, ErrDelegateSolverDisabled
+	}
+	return s.delegates.Get(ctx, outpoint)
+}
+
+// validateDelegateTemplate checks the template spends a single VTXO through
+// a closure shared by arkd and the introspector, so that the solver can sign
+// both the intent proof and the forfeit without the owner. It returns the
+// arkd of the closure
+func validateDelegateTemplate(
+	ptx *psbt.Packet, signers keyring, servers *ArkServers,
+) (*arkServer, error) {
+	if len(ptx.UnsignedTx.TxIn) != 2 || len(ptx.Inputs) != 2 {
+		return nil, fmt.Errorf("intent template must spend a single vtxo")
+	}
+	input := ptx.Inputs[1]
+	if input.WitnessUtxo == nil {
+		return nil, fmt.Errorf("missing witness utxo")
+	}
+	if len(input.TaprootLeafScript) == 0 {
+		return nil, fmt.Errorf("missing taproot leaf script")
+	}
+
+	packet, err := arkade.FindIntrospectorPacket(ptx.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse introspector packet: %w", err)
+	}
+	var arkadeScript *arkade.ArkadeScript
+	for _, entry := range packet {
+		if entry.Vin != 1 {
+			continue
+		}
+		arkadeScript, _, err = signers.readArkadeScript(ptx, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read arkade script: %w", err)
+		}
+	}
+	if arkadeScript == nil {
+		return nil, fmt.Errorf("no introspector entry for the vtxo input")
+	}
+
+	closure, err := script.DecodeClosure(input.TaprootLeafScript[0].Script)
+	if err != nil {
+		return nil, fmt.Errorf("invalid closure: %w", err)
+	}
+	switch closure.(type) {
+	case *script.MultisigClosure, *script.CLTVMultisigClosure:
+	default:
+		return nil, fmt.Errorf("closure can't be used to forfeit the vtxo")
+	}
+
+	// any other key would require the owner to sign the forfeit
+	pubkeys := arkadeScript.ClosurePubKeys()
+	server, err := servers.route(pubkeys)
+	if err != nil {
+		return nil, fmt.Errorf("closure is not signed by arkd: %w", err)
+	}
+	allowed := append([]*btcec.PublicKey{arkadeScript.PubKey()}, server.pubKeys...)
+	for _, pubkey := range pubkeys {
+		if !containsPubKey(allowed, pubkey) {
+			return nil, fmt.Errorf("closure requires a key other than arkd and introspector ones")
+		}
+	}
+
+	return server, nil
+}
+
+// bindDelegateIntent rebuilds the intent proof of the template for the given
+// message, keeping the psbt fields of the template inputs
+func bindDelegateIntent(template *psbt.Packet, message string) (*psbt.Packet, error) {
+	return rebindProof(template, message, template.UnsignedTx.TxOut)
+}
+
+// buildDelegateDeletion builds the proof deleting the intent of the template,
+// it only keeps the introspector packet output of the template
+func buildDelegateDeletion(template *psbt.Packet, message string) (*psbt.Packet, error) {
+	outputs := make([]*wire.TxOut, 0, 1)
+	for _, output := range template.UnsignedTx.TxOut {
+		if txscript.GetScriptClass(output.PkScript) == txscript.NullDataTy {
+			outputs = append(outputs, output)
+		}
+	}
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("no introspector packet found in intent template")
+	}
+	return rebindProof(template, message, outputs)
+}
+
+func rebindProof(template *psbt.Packet, message string, outputs []*wire.TxOut) (*psbt.Packet, error) {
+	inputs := make([]intent.Input, 0, len(template.UnsignedTx.TxIn)-1)
+	for index, input := range template.UnsignedTx.TxIn {
+		// input 0 is the message input
+		if index == 0 {
+			continue
+		}
+		inputs = append(inputs, intent.Input{
+			OutPoint:    &input.PreviousOutPoint,
+			Sequence:    input.Sequence,
+			WitnessUtxo: template.Inputs[index].WitnessUtxo,
+		})
+	}
+
+	proof, err := intent.New(message, inputs, outputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build intent proof: %w", err)
+	}
+	for index := range proof.Inputs {
+		proof.Inputs[index].TaprootLeafScript = template.Inputs[index].TaprootLeafScript
+		proof.Inputs[index].Unknowns = template.Inputs[index].Unknowns
+	}
+	return &proof.Packet, nil
+}
@@ -0,0 +1,489 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	arksdk "github.com/arkade-os/go-sdk"
+	"github.com/arkade-os/go-sdk/client"
+	"github.com/arkade-os/go-sdk/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	log "github.com/sirupsen/logrus"
+)
+
+// how often the solver looks for delegates to refresh
+const delegatePollInterval = 10 * time.Second
+
+// runDelegateSolver joins a batch session for every delegate due for refresh
+// until the service is closed
+func (s *service) runDelegateSolver() {
+	defer s.solverWg.Done()
+
+	s.recoverDelegates()
+
+	ticker := time.NewTicker(delegatePollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.startDueDelegates()
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverDelegates reschedules the sessions interrupted by a previous run,
+// their intents expire on their own if they can't be deleted
+func (s *service) recoverDelegates() {
+	running, err := s.delegates.GetByStatus(s.ctx, DelegateStatusRunning)
+	if err != nil {
+		log.WithError(err).Warn("failed to read running delegates")
+		return
+	}
+	for _, delegate := range running {
+		delegate.Status = DelegateStatusPending
+		delegate.UpdatedAt = time.Now().Unix()
+		s.persistDelegate(delegate)
+	}
+	if len(running) > 0 {
+		log.Infof("rescheduled %d interrupted delegate(s)", len(running))
+	}
+}
+
+func (s *service) startDueDelegates() {
+	// the attempts are not spent while signing is paused
+	if s.guard.IsPaused() {
+		return
+	}
+
+	pending, err := s.delegates.GetByStatus(s.ctx, DelegateStatusPending)
+	if err != nil {
+		log.WithError(err).Warn("failed to read pending delegates")
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, delegate := range pending {
+		if delegate.RefreshAt > now {
+			continue
+		}
+		// the attempts are not spent while the arkd is unreachable
+		if _, err := s.arkServers.get(delegate.ArkdPubKey); errors.Is(err, ErrArkServerUnavailable) {
+			continue
+		}
+		if !s.lockDelegate(delegate.Outpoint) {
+			continue
+		}
+
+		s.solverWg.Add(1)
+		go func(delegate Delegate) {
+			defer s.solverWg.Done()
+			defer s.unlockDelegate(delegate.Outpoint)
+			s.refreshDelegate(delegate)
+		}(delegate)
+	}
+}
+
+func (s *service) lockDelegate(outpoint string) bool {
+	s.solvingLock.Lock()
+	defer s.solvingLock.Unlock()
+	if _, ok := s.solving[outpoint]; ok {
+		return false
+	}
+	s.solving[outpoint] = struct{}{}
+	return true
+}
+
+func (s *service) unlockDelegate(outpoint string) {
+	s.solvingLock.Lock()
+	defer s.solvingLock.Unlock()
+	delete(s.solving, outpoint)
+}
+
+// refreshDelegate runs one attempt and persists its outcome
+func (s *service) refreshDelegate(delegate Delegate) {
+	logger := log.WithField("outpoint", delegate.Outpoint)
+
+	delegate.Status = DelegateStatusRunning
+	delegate.Attempts++
+	delegate.UpdatedAt = time.Now().Unix()
+	s.persistDelegate(delegate)
+
+	commitmentTxid, err := s.solveDelegate(delegate)
+	delegate.UpdatedAt = time.Now().Unix()
+	if err == nil {
+		delegate.Status = DelegateStatusSettled
+		delegate.CommitmentTxid = commitmentTxid
+		delegate.LastError = ""
+		s.persistDelegate(delegate)
+		logger.WithField("commitment_txid", commitmentTxid).Info("delegate vtxo refreshed")
+		return
+	}
+
+	delegate.LastError = err.Error()
+	if s.ctx.Err() != nil {
+		// interrupted by shutdown, recoverDelegates reschedules it on restart
+		s.persistDelegate(delegate)
+		return
+	}
+	if delegate.Attempts >= s.solver.MaxAttempts {
+		delegate.Status = DelegateStatusFailed
+		logger.WithError(err).Error("giving up delegate refresh")
+	} else {
+		delegate.Status = DelegateStatusPending
+		delegate.RefreshAt = time.Now().Add(s.solver.RetryDelay).Unix()
+		logger.WithError(err).Warn("delegate refresh failed, retrying later")
+	}
+	s.persistDelegate(delegate)
+}
+
+// solveDelegate registers the intent of the delegate with a new cosigner key
+// and follows the batch session until the commitment tx is finalized
+func (s *service) solveDelegate(delegate Delegate) (string, error) {
+	server, err := s.arkServers.get(delegate.ArkdPubKey)
+	if err != nil {
+		return "", err
+	}
+	template, err := psbt.NewFromRawBytes(strings.NewReader(delegate.Proof), true)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode intent template: %w", err)
+	}
+	var message intent.RegisterMessage
+	if err := message.Decode(delegate.Message); err != nil {
+		return "", fmt.Errorf("failed to decode register message: %w", err)
+	}
+
+	cosignerKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate cosigner key: %w", err)
+	}
+	signerSession := tree.NewTreeSignerSession(cosignerKey)
+
+	now := time.Now()
+	message.CosignersPublicKeys = []string{signerSession.GetPublicKey()}
+	message.ValidAt = now.Unix()
+	message.ExpireAt = now.Add(s.solver.SessionTimeout).Unix()
+	encodedMessage, err := message.Encode()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode register message: %w", err)
+	}
+
+	proof, err := bindDelegateIntent(template, encodedMessage)
+	if err != nil {
+		return "", err
+	}
+	signed, err := s.SubmitIntent(s.ctx, Intent{Proof: intent.Proof{Packet: *proof}, Message: message}, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign intent: %w", err)
+	}
+	signedProof, err := signed.Proof.B64Encode()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode intent proof: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.solver.SessionTimeout)
+	defer cancel()
+
+	intentId, err := server.client.RegisterIntent(ctx, signedProof, encodedMessage)
+	if err != nil {
+		return "", fmt.Errorf("failed to register intent: %w", err)
+	}
+
+	outpoint := template.UnsignedTx.TxIn[1].PreviousOutPoint
+	topics := arksdk.GetEventStreamTopics(
+		[]types.Outpoint{{Txid: outpoint.Hash.String(), VOut: outpoint.Index}},
+		[]tree.SignerSession{signerSession},
+	)
+	eventsCh, closeStream, err := server.client.GetEventStream(ctx, topics)
+	if err != nil {
+		s.deleteDelegateIntent(server, template, delegate.Outpoint)
+		return "", fmt.Errorf("failed to get event stream: %w", err)
+	}
+	defer closeStream()
+
+	session := &delegateSession{
+		svc:           s,
+		server:        server,
+		intentId:      intentId,
+		intent:        Intent{Proof: intent.Proof{Packet: *signed.Proof}, Message: message},
+		signerSession: signerSession,
+	}
+
+	opts := make([]arksdk.BatchSessionOption, 0, 1)
+	if !hasOffchainOutputs(template, message) {
+		opts = append(opts, arksdk.WithSkipVtxoTreeSigning())
+	}
+	commitmentTxid, err := arksdk.JoinBatchSession(ctx, eventsCh, session, opts...)
+	if err != nil {
+		s.deleteDelegateIntent(server, template, delegate.Outpoint)
+		return "", err
+	}
+	return commitmentTxid, nil
+}
+
+// deleteDelegateIntent is best effort, the intent expires after the session
+// timeout anyway
+func (s *service) deleteDelegateIntent(server *arkServer, template *psbt.Packet, outpoint string) {
+	logger := log.WithField("outpoint", outpoint)
+
+	message := intent.DeleteMessage{
+		BaseMessage: intent.BaseMessage{Type: intent.IntentMessageTypeDelete},
+		ExpireAt:    time.Now().Add(time.Minute).Unix(),
+	}
+	encoded, err := message.Encode()
+	if err != nil {
+		logger.WithError(err).Warn("failed to encode delete message")
+		return
+	}
+
+	proof, err := buildDelegateDeletion(template, encoded)
+	if err != nil {
+		logger.WithError(err).Warn("failed to build intent deletion")
+		return
+	}
+
+	// the session context may be done, but deletion must not outlive the service
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(s.ctx), 30*time.Second)
+	defer cancel()
+
+	signed, err := s.SubmitIntentDeletion(ctx, IntentDeletion{
+		Proof: intent.Proof{Packet: *proof}, Message: message,
+	})
+	if err != nil {
+		logger.WithError(err).Warn("failed to sign intent deletion")
+		return
+	}
+	b64, err := signed.Proof.B64Encode()
+	if err != nil {
+		logger.WithError(err).Warn("failed to encode intent deletion")
+		return
+	}
+	if err := server.client.DeleteIntent(ctx, b64, encoded); err != nil {
+		logger.WithError(err).Debug("failed to delete intent")
+	}
+}
+
+func (s *service) persistDelegate(delegate Delegate) {
+	// persist even if the service is being closed
+	ctx := context.WithoutCancel(s.ctx)
+	if err := s.delegates.Upsert(ctx, delegate); err != nil {
+		log.WithError(err).WithField("outpoint", delegate.Outpoint).Warn("failed to persist delegate")
+	}
+}
+
+// hasOffchainOutputs returns true if the intent creates vtxos, which requires
+// the vtxo tree to be cosigned
+func hasOffchainOutputs(ptx *psbt.Packet, message intent.RegisterMessage) bool {
+	for index, output := range ptx.UnsignedTx.TxOut {
+		if txscript.GetScriptClass(output.PkScript) == txscript.NullDataTy {
+			continue
+		}
+		if !slices.Contains(message.OnchainOutputIndexes, index) {
+			return true
+		}
+	}
+	return false
+}
+
+// delegateSession handles the batch events of a single delegate intent
+type delegateSession struct {
+	svc           *service
+	server        *arkServer
+	intentId      string
+	intent        Intent
+	signerSession tree.SignerSession
+
+	batchId     string
+	batchExpiry arklib.RelativeLocktime
+}
+
+func (h *delegateSession) OnBatchStarted(
+	ctx context.Context, event client.BatchStartedEvent,
+) (bool, error) {
+	buf := sha256.Sum256([]byte(h.intentId))
+	hashedIntentId := hex.EncodeToString(buf[:])
+
+	if !slices.Contains(event.HashedIntentIds, hashedIntentId) {
+		return true, nil
+	}
+	if err := h.server.client.ConfirmRegistration(ctx, h.intentId); err != nil {
+		return false, err
+	}
+	h.batchId = event.Id
+	h.batchExpiry = batchExpiryLocktime(uint32(event.BatchExpiry))
+	return false, nil
+}
+
+func (h *delegateSession) OnBatchFinalized(context.Context, client.BatchFinalizedEvent) error {
+	return nil
+}
+
+func (h *delegateSession) OnBatchFailed(_ context.Context, event client.BatchFailedEvent) error {
+	if event.Id == h.batchId {
+		return fmt.Errorf("batch failed: %s", event.Reason)
+	}
+	return nil
+}
+
+func (h *delegateSession) OnTreeTxEvent(context.Context, client.TreeTxEvent) error {
+	return nil
+}
+
+func (h *delegateSession) OnTreeSignatureEvent(context.Context, client.TreeSignatureEvent) error {
+	return nil
+}
+
+func (h *delegateSession) OnTreeSigningStarted(
+	ctx context.Context, event client.TreeSigningStartedEvent, vtxoTree *tree.TxTree,
+) (bool, error) {
+	if !slices.Contains(event.CosignersPubkeys, h.signerSession.GetPublicKey()) {
+		return true, nil
+	}
+	record, err := h.svc.getSignedIntent(ctx, h.intent)
+	if err != nil {
+		return false, err
+	}
+	if err := h.svc.startTreeSigning(
+		ctx, h.server, h.signerSession, event, vtxoTree, h.batchExpiry, record,
+	); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+func (h *delegateSession) OnTreeNonces(context.Context, client.TreeNoncesEvent) (bool, error) {
+	return false, nil
+}
+
+func (h *delegateSession) OnTreeNoncesAggregated(
+	ctx context.Context, event client.TreeNoncesAggregatedEvent,
+) (bool, error) {
+	if err := h.svc.submitTreeSignatures(ctx, h.server, h.signerSession, event); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *delegateSession) OnBatchFinalization(
+	ctx context.Context, event client.BatchFinalizationEvent, vtxoTree, connectorTree *tree.TxTree,
+) error {
+	if connectorTree == nil {
+		return fmt.Errorf("missing connector tree")
+	}
+	commitmentTx, err := psbt.NewFromRawBytes(strings.NewReader(event.Tx), true)
+	if err != nil {
+		return fmt.Errorf("failed to decode commitment tx: %w", err)
+	}
+
+	forfeit, err := buildDelegateForfeit(
+		&h.intent.Proof.Packet, connectorTree.Leaves(), h.server.forfeitPkScript,
+	)
+	if err != nil {
+		return err
+	}
+
+	signed, err := h.svc.SubmitFinalization(ctx, BatchFinalization{
+		Intent:        h.intent,
+		Forfeits:      []*psbt.Packet{forfeit},
+		ConnectorTree: connectorTree,
+		VtxoTree:      vtxoTree,
+		CommitmentTx:  commitmentTx,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign forfeit: %w", err)
+	}
+	if len(signed.Forfeits) != 1 {
+		return fmt.Errorf("forfeit not signed")
+	}
+
+	b64, err := signed.Forfeits[0].B64Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode forfeit: %w", err)
+	}
+	return h.server.client.SubmitSignedForfeitTxs(ctx, []string{b64}, "")
+}
+
+func (h *delegateSession) OnStreamStarted(context.Context, client.StreamStartedEvent) error {
+	return nil
+}
+
+// buildDelegateForfeit builds the forfeit of the vtxo spent by input 1 of the
+// intent proof. arkd gives each participant a connector leaf per forfeited
+// vtxo, the delegate only forfeits one.
+func buildDelegateForfeit(
+	proof *psbt.Packet, connectorLeaves []*psbt.Packet, forfeitPkScript []byte,
+) (*psbt.Packet, error) {
+	if len(connectorLeaves) == 0 {
+		return nil, fmt.Errorf("%w: no connector leaf", ErrInvalidConnectorTree)
+	}
+	connectorTx := connectorLeaves[0]
+
+	var connector *wire.TxOut
+	var connectorOutpoint *wire.OutPoint
+	for index, output := range connectorTx.UnsignedTx.TxOut {
+		if bytes.Equal(output.PkScript, txutils.ANCHOR_PKSCRIPT) {
+			continue
+		}
+		connector = output
+		connectorOutpoint = &wire.OutPoint{Hash: connectorTx.UnsignedTx.TxHash(), Index: uint32(index)}
+		break
+	}
+	if connector == nil {
+		return nil, fmt.Errorf("%w: connector output not found", ErrInvalidConnectorTree)
+	}
+
+	vtxoInput := proof.Inputs[1]
+	leaf := vtxoInput.TaprootLeafScript[0]
+	closure, err := script.DecodeClosure(leaf.Script)
+	if err != nil {
+		return nil, fmt.Errorf("invalid forfeit closure: %w", err)
+	}
+	locktime := arklib.AbsoluteLocktime(0)
+	if cltv, ok := closure.(*script.CLTVMultisigClosure); ok {
+		locktime = cltv.Locktime
+	}
+	sequence := wire.MaxTxInSequenceNum
+	if locktime != 0 {
+		sequence = wire.MaxTxInSequenceNum - 1
+	}
+
+	forfeit, err := tree.BuildForfeitTx(
+		[]*wire.OutPoint{&proof.UnsignedTx.TxIn[1].PreviousOutPoint, connectorOutpoint},
+		[]uint32{sequence, wire.MaxTxInSequenceNum},
+		[]*wire.TxOut{vtxoInput.WitnessUtxo, connector},
+		forfeitPkScript,
+		uint32(locktime),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build forfeit: %w", err)
+	}
+	forfeit.Inputs[0].TaprootLeafScript = []*psbt.TaprootTapLeafScript{leaf}
+	return forfeit, nil
+}
+
+func batchExpiryLocktime(expiry uint32) arklib.RelativeLocktime {
+	if expiry >= 512 {
+		return arklib.RelativeLocktime{Type: arklib.LocktimeTypeSecond, Value: expiry}
+	}
+	return arklib.RelativeLocktime{Type: arklib.LocktimeTypeBlock, Value: expiry}
+}
+
+var _ arksdk.BatchEventsHandler = (*delegateSession)(nil)
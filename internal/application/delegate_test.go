@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	"github.com/arkade-os/arkd/pkg/ark-lib/extension"
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	arkscript "github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegate(t *testing.T) {
+	newKey := func(t *testing.T) *btcec.PrivateKey {
+		t.Helper()
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		return key
+	}
+	introspectorKey := newKey(t)
+	arkdKey := newKey(t)
+	ownerKey := newKey(t)
+	signers := keyring{signer{introspectorKey}}
+	servers := newTestArkServers(&arkServer{
+		pubKey: arkdKey.PubKey(), pubKeys: []*btcec.PublicKey{arkdKey.PubKey()},
+	})
+
+	arkadeScript := []byte{txscript.OP_TRUE}
+	tweakedKey := arkade.ComputeArkadeScriptPublicKey(
+		introspectorKey.PubKey(), arkade.ArkadeScriptHash(arkadeScript),
+	)
+	vtxoOutpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 2}
+	receiverPkScript, err := arkscript.P2TRScript(ownerKey.PubKey())
+	require.NoError(t, err)
+
+	message := intent.RegisterMessage{
+		BaseMessage: intent.BaseMessage{Type: intent.IntentMessageTypeRegister},
+	}
+	encodedMessage, err := message.Encode()
+	require.NoError(t, err)
+
+	// template spending a vtxo through the given forfeit closure
+	newTemplate := func(t *testing.T, pubkeys ...*btcec.PublicKey) *psbt.Packet {
+		t.Helper()
+		vtxoScript := arkscript.TapscriptsVtxoScript{
+			Closures: []arkscript.Closure{
+				&arkscript.MultisigClosure{PubKeys: pubkeys},
+				&arkscript.CSVMultisigClosure{
+					MultisigClosure: arkscript.MultisigClosure{PubKeys: []*btcec.PublicKey{ownerKey.PubKey()}},
+					Locktime:        arklib.RelativeLocktime{Type: arklib.LocktimeTypeBlock, Value: 144},
+				},
+			},
+		}
+		tapKey, tapTree, err := vtxoScript.TapTree()
+		require.NoError(t, err)
+		pkScript, err := arkscript.P2TRScript(tapKey)
+		require.NoError(t, err)
+		forfeitScript, err := vtxoScript.Closures[0].Script()
+		require.NoError(t, err)
+		leafProof, err := tapTree.GetTaprootMerkleProof(txscript.NewBaseTapLeaf(forfeitScript).TapHash())
+		require.NoError(t, err)
+
+		packet, err := arkade.NewPacket(arkade.IntrospectorEntry{Vin: 1, Script: arkadeScript})
+		require.NoError(t, err)
+		packetOut, err := extension.Extension{packet}.TxOut()
+		require.NoError(t, err)
+
+		proof, err := intent.New(encodedMessage, []intent.Input{{
+			OutPoint:    &vtxoOutpoint,
+			Sequence:    wire.MaxTxInSequenceNum,
+			WitnessUtxo: &wire.TxOut{Value: 10_000, PkScript: pkScript},
+		}}, []*wire.TxOut{{Value: 10_000, PkScript: receiverPkScript}, packetOut})
+		require.NoError(t, err)
+		proof.Inputs[1].TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+			ControlBlock: leafProof.ControlBlock,
+			Script:       leafProof.Script,
+			LeafVersion:  txscript.BaseLeafVersion,
+		}}
+		return &proof.Packet
+	}
+
+	t.Run("validate template", func(t *testing.T) {
+		template := newTemplate(t, arkdKey.PubKey(), tweakedKey)
+		server, err := validateDelegateTemplate(template, signers, servers)
+		require.NoError(t, err)
+		require.Equal(t, arkdKey.PubKey(), server.pubKey)
+
+		// the owner would have to sign the forfeit
+		template = newTemplate(t, arkdKey.PubKey(), tweakedKey, ownerKey.PubKey())
+		_, err = validateDelegateTemplate(template, signers, servers)
+		require.Error(t, err)
+
+		// arkd can't cosign the forfeit
+		template = newTemplate(t, tweakedKey)
+		_, err = validateDelegateTemplate(template, signers, servers)
+		require.Error(t, err)
+
+		// the vtxo is not bound to the introspector
+		template = newTemplate(t, arkdKey.PubKey(), ownerKey.PubKey())
+		_, err = validateDelegateTemplate(template, signers, servers)
+		require.Error(t, err)
+	})
+
+	t.Run("bind intent", func(t *testing.T) {
+		template := newTemplate(t, arkdKey.PubKey(), tweakedKey)
+
+		bound := message
+		bound.CosignersPublicKeys = []string{hex.EncodeToString(ownerKey.PubKey().SerializeCompressed())}
+		bound.ExpireAt = 1000
+		encoded, err := bound.Encode()
+		require.NoError(t, err)
+
+		proof, err := bindDelegateIntent(template, encoded)
+		require.NoError(t, err)
+		require.NotEqual(t, template.UnsignedTx.TxIn[0].PreviousOutPoint, proof.UnsignedTx.TxIn[0].PreviousOutPoint)
+		require.Equal(t, vtxoOutpoint, proof.UnsignedTx.TxIn[1].PreviousOutPoint)
+		require.Equal(t, template.UnsignedTx.TxOut, proof.UnsignedTx.TxOut)
+		require.Equal(t, template.Inputs[1].TaprootLeafScript, proof.Inputs[1].TaprootLeafScript)
+		_, err = validateDelegateTemplate(proof, signers, servers)
+		require.NoError(t, err)
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		template := newTemplate(t, arkdKey.PubKey(), tweakedKey)
+
+		deleteMessage := intent.DeleteMessage{
+			BaseMessage: intent.BaseMessage{Type: intent.IntentMessageTypeDelete},
+		}
+		encoded, err := deleteMessage.Encode()
+		require.NoError(t, err)
+
+		proof, err := buildDelegateDeletion(template, encoded)
+		require.NoError(t, err)
+		require.Len(t, proof.UnsignedTx.TxOut, 1)
+		require.NoError(t, validateDeleteMessage(proof, deleteMessage))
+
+		packet, err := arkade.FindIntrospectorPacket(proof.UnsignedTx)
+		require.NoError(t, err)
+		require.Len(t, packet, 1)
+	})
+
+	t.Run("offchain outputs", func(t *testing.T) {
+		template := newTemplate(t, arkdKey.PubKey(), tweakedKey)
+		require.True(t, hasOffchainOutputs(template, message))
+
+		onchain := message
+		onchain.OnchainOutputIndexes = []int{0}
+		require.False(t, hasOffchainOutputs(template, onchain))
+	})
+
+	t.Run("forfeit", func(t *testing.T) {
+		template := newTemplate(t, arkdKey.PubKey(), tweakedKey)
+		forfeitPkScript, err := arkscript.P2TRScript(arkdKey.PubKey())
+		require.NoError(t, err)
+		connectorPkScript, err := arkscript.P2TRScript(newKey(t).PubKey())
+		require.NoError(t, err)
+
+		connectorTree, err := tree.BuildConnectorTree(
+			&wire.OutPoint{Hash: chainhash.Hash{3}, Index: 1},
+			[]tree.Leaf{{
+				Outputs: []tree.LeafOutput{{Amount: 330, Script: hex.EncodeToString(connectorPkScript)}},
+				CosignersPublicKeys: []string{
+					hex.EncodeToString(arkdKey.PubKey().SerializeCompressed()),
+				},
+			}},
+		)
+		require.NoError(t, err)
+
+		forfeit, err := buildDelegateForfeit(template, connectorTree.Leaves(), forfeitPkScript)
+		require.NoError(t, err)
+		require.Equal(t, template.Inputs[1].TaprootLeafScript, forfeit.Inputs[0].TaprootLeafScript)
+		require.NoError(t, validateForfeit(
+			forfeit, 0, template.Inputs[1].WitnessUtxo, connectorTree, forfeitPkScript,
+		))
+
+		_, err = buildDelegateForfeit(template, nil, forfeitPkScript)
+		require.ErrorIs(t, err, ErrInvalidConnectorTree)
+	})
+
+	t.Run("solver disabled", func(t *testing.T) {
+		svc := &service{}
+		_, err := svc.RegisterDelegate(context.Background(), DelegateRegistration{})
+		require.ErrorIs(t, err, ErrDelegateSolverDisabled)
+		_, err = svc.GetDelegate(context.Background(), vtxoOutpoint.String())
+		require.ErrorIs(t, err, ErrDelegateSolverDisabled)
+	})
+}
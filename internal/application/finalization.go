@@ -6,9 +6,6 @@ import (
 	"fmt"
 
 	"github.com/ArkLabsHQ/introspector/pkg/arkade"
-	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
-	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/txscript"
@@ -17,10 +14,17 @@ import (
 
 // SubmitFinalization doesn't execute arkade scripts, it only signs the forfeits and the commitment tx
 // if and only if the intent proof contains the signer's signature (it means we executed the arkade script in the past)
-// before signing the forfeits, we also verify that is it part of the commitment tx
+// before signing anything, we also verify that the commitment tx creates the intent outputs, and
+// before signing the forfeits that they spend a connector of the tree rooted in the commitment tx
+// and pay the full vtxo amount to the arkd forfeit address
 func (s *service) SubmitFinalization(ctx context.Context, finalization BatchFinalization) (*SignedBatchFinalization, error) {
-	signerPublicKey := s.signer.secretKey.PubKey()
-	signedInputs, err := getSignedInputs(finalization.Intent.Proof.Packet, signerPublicKey)
+	signers, err := s.getKeyring()
+	if err != nil {
+		return nil, err
+	}
+	defer signers.zero()
+
+	signedInputs, err := getSignedInputs(finalization.Intent.Proof.Packet, signers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get signed inputs: %w", err)
 	}
@@ -29,33 +33,42 @@ func (s *service) SubmitFinalization(ctx context.Context, finalization BatchFina
 		return nil, fmt.Errorf("no signed inputs found in intent proof")
 	}
 
+	// the intent must be one we signed, and its onchain outputs must be paid
+	intentRecord, err := s.getSignedIntent(ctx, finalization.Intent)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBatch(finalization, intentRecord); err != nil {
+		return nil, err
+	}
+	// the forfeits pay the arkd the intent inputs are bound to
+	server, err := s.arkServers.get(intentRecord.ArkdPubKey)
+	if err != nil {
+		return nil, err
+	}
+
 	signedForfeits := make([]*psbt.Packet, 0, len(finalization.Forfeits))
+	signatures := make([]Signature, 0, len(signedInputs))
 
 	for _, forfeit := range finalization.Forfeits {
-		if len(forfeit.Inputs) != 2 {
-			return nil, fmt.Errorf(
-				"malformed forfeit %s: expected 2 inputs, got %d",
-				forfeit.UnsignedTx.TxID(), len(forfeit.Inputs),
-			)
-		}
-		if len(forfeit.UnsignedTx.TxIn) != 2 {
+		if len(forfeit.Inputs) != 2 || len(forfeit.UnsignedTx.TxIn) != 2 {
 			return nil, fmt.Errorf(
-				"malformed forfeit %s: expected 2 inputs, got %d",
-				forfeit.UnsignedTx.TxID(), len(forfeit.UnsignedTx.TxIn),
+				"%w %s: expected 2 inputs, got %d",
+				ErrInvalidForfeit, forfeit.UnsignedTx.TxID(), len(forfeit.UnsignedTx.TxIn),
 			)
 		}
 
 		for inputIndex, input := range forfeit.UnsignedTx.TxIn {
-			arkadeScript, ok := signedInputs[input.PreviousOutPoint]
+			signedInput, ok := signedInputs[input.PreviousOutPoint]
 			if !ok {
 				continue
 			}
 
-			// validate connector is part of the connector tree
-			connectorIndex := inputIndex ^ 1 // if inputIndex is 0, connectorIndex is 1, and vice versa
-			connector := forfeit.UnsignedTx.TxIn[connectorIndex].PreviousOutPoint
-			if !hasLeaf(finalization.ConnectorTree, connector) {
-				return nil, fmt.Errorf("connector %s is not part of the tree", connector)
+			if err := validateForfeit(
+				forfeit, inputIndex, signedInput.prevout,
+				finalization.ConnectorTree, server.forfeitPkScript,
+			); err != nil {
+				return nil, err
 			}
 
 			// sign the forfeit
@@ -63,9 +76,14 @@ func (s *service) SubmitFinalization(ctx context.Context, finalization BatchFina
 			if err != nil {
 				return nil, err
 			}
-			if err := s.signer.signInput(forfeit, inputIndex, arkadeScript.Hash(), prevoutFetcher); err != nil {
+			if err := signedInput.signer.signInput(
+				ctx, forfeit, inputIndex, signedInput.script.Hash(), prevoutFetcher,
+			); err != nil {
 				return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
 			}
+			signatures = append(signatures, newSignature(
+				SignatureKindForfeitTx, forfeit, inputIndex, signedInput.script.Hash(),
+			))
 			signedForfeits = append(signedForfeits, forfeit)
 			delete(signedInputs, input.PreviousOutPoint)
 		}
@@ -77,28 +95,42 @@ func (s *service) SubmitFinalization(ctx context.Context, finalization BatchFina
 
 	if len(signedInputs) == 0 {
 		// all signed inputs were matched to forfeits, no boarding inputs remain
+		if err := s.journalSignatures(ctx, signatures); err != nil {
+			return nil, err
+		}
 		return signedBatchFinalization, nil
 	}
 
-	prevoutFetcher, err := computePrevoutFetcher(finalization.CommitmentTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create prevout fetcher for commitment tx: %w", err)
+	boardingInputs := make([]int, 0, len(signedInputs))
+	for inputIndex, input := range finalization.CommitmentTx.UnsignedTx.TxIn {
+		if _, ok := signedInputs[input.PreviousOutPoint]; ok {
+			boardingInputs = append(boardingInputs, inputIndex)
+		}
 	}
 
-	signed := false
-
-	for inputIndex, input := range finalization.CommitmentTx.UnsignedTx.TxIn {
-		arkadeScript, ok := signedInputs[input.PreviousOutPoint]
-		if !ok {
-			continue
+	signed := len(boardingInputs) > 0
+	if signed {
+		prevoutFetcher, err := computePrevoutFetcher(finalization.CommitmentTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prevout fetcher for commitment tx: %w", err)
 		}
 
-		if err := s.signer.signInput(
-			finalization.CommitmentTx, inputIndex, arkadeScript.Hash(), prevoutFetcher,
-		); err != nil {
-			return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
+		for _, inputIndex := range boardingInputs {
+			outpoint := finalization.CommitmentTx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint
+			signedInput := signedInputs[outpoint]
+			if err := signedInput.signer.signInput(
+				ctx, finalization.CommitmentTx, inputIndex, signedInput.script.Hash(), prevoutFetcher,
+			); err != nil {
+				return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
+			}
+			signatures = append(signatures, newSignature(
+				SignatureKindCommitmentTx, finalization.CommitmentTx, inputIndex, signedInput.script.Hash(),
+			))
 		}
-		signed = true
+	}
+
+	if err := s.journalSignatures(ctx, signatures); err != nil {
+		return nil, err
 	}
 
 	if signed {
@@ -108,15 +140,22 @@ func (s *service) SubmitFinalization(ctx context.Context, finalization BatchFina
 	return signedBatchFinalization, nil
 }
 
+// signedInput is an intent proof input signed in the past, along with the key that signed it
+type signedInput struct {
+	script  *arkade.ArkadeScript
+	signer  signer
+	prevout *wire.TxOut
+}
+
 // getSignedInputs iterates over tapscript sigs to find arkade script inputs with valid signature
-func getSignedInputs(ptx psbt.Packet, signerPublicKey *btcec.PublicKey) (map[wire.OutPoint]*arkade.ArkadeScript, error) {
+func getSignedInputs(ptx psbt.Packet, signers keyring) (map[wire.OutPoint]signedInput, error) {
 	prevoutFetcher, err := computePrevoutFetcher(&ptx)
 	if err != nil {
 		return nil, err
 	}
 	sighashes := txscript.NewTxSigHashes(ptx.UnsignedTx, prevoutFetcher)
 
-	signedInputs := make(map[wire.OutPoint]*arkade.ArkadeScript)
+	signedInputs := make(map[wire.OutPoint]signedInput)
 
 	if len(ptx.Inputs) != len(ptx.UnsignedTx.TxIn) {
 		return nil, fmt.Errorf("malformed psbt")
@@ -151,7 +190,7 @@ func getSignedInputs(ptx psbt.Packet, signerPublicKey *btcec.PublicKey) (map[wir
 			continue // not signed: skip
 		}
 
-		script, err := arkade.ReadArkadeScript(&ptx, signerPublicKey, entry)
+		script, signer, err := signers.readArkadeScript(&ptx, entry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read arkade script: %w", err)
 		}
@@ -179,35 +218,26 @@ func getSignedInputs(ptx psbt.Packet, signerPublicKey *btcec.PublicKey) (map[wir
 				return nil, fmt.Errorf("invalid signature for input %d", inputIndex)
 			}
 
-			signedInputs[ptx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint] = script
+			signedInputs[ptx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint] = signedInput{
+				script, signer, input.WitnessUtxo,
+			}
 		}
 
 	}
 	return signedInputs, nil
 }
 
-func hasLeaf(tree *tree.TxTree, outpoint wire.OutPoint) bool {
-	if tree == nil {
-		return false
-	}
-
-	node := tree.Find(outpoint.Hash.String())
-	if node == nil {
-		return false
+// validateBatch checks that the commitment tx pays the onchain outputs of the intent and creates
+// its vtxos, the intent inputs being forfeited or boarded only in exchange for them
+func validateBatch(finalization BatchFinalization, record *IntentRecord) error {
+	if err := verifyOnchainOutputs(record, finalization.CommitmentTx); err != nil {
+		return err
 	}
-
-	if len(node.Children) != 0 {
-		// not a leaf
-		return false
+	if err := validateVtxoTree(finalization.VtxoTree, finalization.CommitmentTx, record); err != nil {
+		return err
 	}
-
-	if len(node.Root.UnsignedTx.TxOut) <= int(outpoint.Index) {
-		// index out of range
-		return false
+	if len(finalization.Forfeits) > 0 {
+		return validateConnectorTree(finalization.ConnectorTree, finalization.CommitmentTx)
 	}
-
-	output := node.Root.UnsignedTx.TxOut[outpoint.Index]
-
-	// false if the output is anchor
-	return !bytes.Equal(output.PkScript, txutils.ANCHOR_PKSCRIPT)
+	return nil
 }
@@ -0,0 +1,375 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ErrFinalizationNotFound   = errors.New("finalization not found")
+	ErrFinalizationInProgress = errors.New("finalization already in progress")
+	ErrFinalizationCompleted  = errors.New("tx already finalized")
+	ErrFinalizationStuck      = errors.New("finalization stuck")
+	// ErrFinalizationQueued is returned when the finalization didn't succeed
+	// before the request ended, it keeps being retried in background
+	ErrFinalizationQueued = errors.New("finalization queued for background retry")
+)
+
+type FinalizationStatus string
+
+const (
+	FinalizationStatusPending FinalizationStatus = "pending"
+	// stuck finalizations ran out of attempts and wait for an operator retry
+	FinalizationStatusStuck     FinalizationStatus = "stuck"
+	FinalizationStatusFinalized FinalizationStatus = "finalized"
+)
+
+// Finalization is a tx accepted by arkd for which we submit the signed checkpoints
+type Finalization struct {
+	Txid string
+	// signer pubkey of the arkd that accepted the tx, the default one if empty
+	ArkdPubKey  string
+	Checkpoints []string
+	Status      FinalizationStatus
+	Attempts    int
+	LastError   string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+type FinalizationStore interface {
+	Upsert(ctx context.Context, finalization Finalization) error
+	// Get returns ErrFinalizationNotFound if the txid is unknown
+	Get(ctx context.Context, txid string) (*Finalization, error)
+	GetByStatus(ctx context.Context, statuses ...FinalizationStatus) ([]Finalization, error)
+	Close()
+}
+
+type FinalizeRetryConfig struct {
+	// MinAttempts is the number of attempts made before giving up on request
+	// cancellation, so that a client hangup doesn't interrupt finalization
+	MinAttempts int
+	// MaxAttempts is the number of attempts of a single run before the
+	// finalization is marked as stuck, 0 means unlimited
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	// Jitter adds ±jitter randomness to the delays, 0.2 means ±20%
+	Jitter float64
+}
+
+var DefaultFinalizeRetryConfig = FinalizeRetryConfig{
+	MinAttempts:  10,
+	MaxAttempts:  50,
+	InitialDelay: 1 * time.Second,
+	MaxDelay:     10 * time.Second,
+	Multiplier:   2.0,
+	Jitter:       0.2,
+}
+
+// FinalizationQueue durably tracks the finalizations of the txs submitted to
+// arkd and retries them until they succeed, including across restarts.
+type FinalizationQueue interface {
+	// Finalize persists the finalization and retries it with backoff.
+	// If it doesn't succeed before ctx is done, it goes on in background and
+	// ErrFinalizationQueued is returned.
+	Finalize(ctx context.Context, txid, arkdPubKey string, checkpoints []string) error
+	// Get returns ErrFinalizationNotFound if the txid was never enqueued
+	Get(ctx context.Context, txid string) (*Finalization, error)
+	// List returns the pending and stuck finalizations, oldest first
+	List(ctx context.Context) ([]Finalization, error)
+	// Retry restarts the finalization of a stuck tx in background
+	Retry(ctx context.Context, txid string) error
+	Close()
+}
+
+type finalizationQueue struct {
+	arkServers  *ArkServers
+	store       FinalizationStore
+	journal     SigningJournal
+	retryConfig FinalizeRetryConfig
+	metrics     Metrics
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	lock       sync.Mutex
+	inProgress map[string]struct{}
+}
+
+// NewFinalizationQueue resumes in background the finalizations left pending
+// by a previous run. The signatures of the stuck txs are marked as failed in
+// the journal.
+func NewFinalizationQueue(
+	ctx context.Context, arkServers *ArkServers, store FinalizationStore,
+	journal SigningJournal, retryConfig FinalizeRetryConfig, metrics Metrics,
+) (FinalizationQueue, error) {
+	pending, err := store.GetByStatus(ctx, FinalizationStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending finalizations: %w", err)
+	}
+
+	queueCtx, cancel := context.WithCancel(context.Background())
+	q := &finalizationQueue{
+		arkServers:  arkServers,
+		store:       store,
+		journal:     journal,
+		retryConfig: retryConfig,
+		metrics:     metrics,
+		ctx:         queueCtx,
+		cancel:      cancel,
+		inProgress:  make(map[string]struct{}),
+	}
+
+	for _, finalization := range pending {
+		if !q.lockTx(finalization.Txid) {
+			continue
+		}
+		q.runInBackground(finalization)
+	}
+	if len(pending) > 0 {
+		log.Infof("resumed %d pending finalization(s)", len(pending))
+	}
+
+	return q, nil
+}
+
+func (q *finalizationQueue) Finalize(
+	ctx context.Context, txid, arkdPubKey string, checkpoints []string,
+) error {
+	if !q.lockTx(txid) {
+		return ErrFinalizationInProgress
+	}
+
+	now := time.Now().Unix()
+	finalization := Finalization{
+		Txid:        txid,
+		ArkdPubKey:  arkdPubKey,
+		Checkpoints: checkpoints,
+		Status:      FinalizationStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := q.store.Upsert(ctx, finalization); err != nil {
+		q.unlockTx(txid)
+		return fmt.Errorf("failed to persist finalization: %w", err)
+	}
+
+	if err := q.retryFinalize(ctx, &finalization, q.retryConfig.MinAttempts); err != nil {
+		if errors.Is(err, ErrFinalizationStuck) {
+			q.unlockTx(txid)
+			return err
+		}
+		q.runInBackground(finalization)
+		return fmt.Errorf("%w: %s", ErrFinalizationQueued, err)
+	}
+
+	q.unlockTx(txid)
+	return nil
+}
+
+func (q *finalizationQueue) Get(ctx context.Context, txid string) (*Finalization, error) {
+	return q.store.Get(ctx, txid)
+}
+
+func (q *finalizationQueue) List(ctx context.Context) ([]Finalization, error) {
+	finalizations, err := q.store.GetByStatus(
+		ctx, FinalizationStatusPending, FinalizationStatusStuck,
+	)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(finalizations, func(i, j int) bool {
+		return finalizations[i].CreatedAt < finalizations[j].CreatedAt
+	})
+	return finalizations, nil
+}
+
+func (q *finalizationQueue) Retry(ctx context.Context, txid string) error {
+	finalization, err := q.store.Get(ctx, txid)
+	if err != nil {
+		return err
+	}
+	if finalization.Status == FinalizationStatusFinalized {
+		return ErrFinalizationCompleted
+	}
+
+	if !q.lockTx(txid) {
+		return ErrFinalizationInProgress
+	}
+
+	finalization.Status = FinalizationStatusPending
+	finalization.UpdatedAt = time.Now().Unix()
+	if err := q.store.Upsert(ctx, *finalization); err != nil {
+		q.unlockTx(txid)
+		return fmt.Errorf("failed to persist finalization: %w", err)
+	}
+
+	q.runInBackground(*finalization)
+	return nil
+}
+
+func (q *finalizationQueue) Close() {
+	q.cancel()
+	q.wg.Wait()
+	q.store.Close()
+}
+
+// runInBackground retries the finalization until it succeeds, gets stuck or
+// the queue is closed. The txid must be locked by the caller.
+func (q *finalizationQueue) runInBackground(finalization Finalization) {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		defer q.unlockTx(finalization.Txid)
+
+		logger := log.WithField("txid", finalization.Txid)
+		// shutdown must not be delayed, don't enforce a minimum number of attempts
+		if err := q.retryFinalize(q.ctx, &finalization, 0); err != nil {
+			if errors.Is(err, ErrFinalizationStuck) {
+				logger.WithError(err).Error("giving up finalization, retry it with the admin api")
+				return
+			}
+			logger.WithError(err).Debug("background finalization interrupted")
+			return
+		}
+		logger.Info("tx finalized in background")
+	}()
+}
+
+// retryFinalize calls FinalizeTx with exponential backoff, persisting the
+// outcome of every attempt. Cancellation of ctx is ignored until minAttempts
+// attempts have been made.
+func (q *finalizationQueue) retryFinalize(
+	ctx context.Context, finalization *Finalization, minAttempts int,
+) error {
+	// copy config to local for this retry run
+	retryConfig := q.retryConfig
+	backoffDelay := retryConfig.InitialDelay
+	attempt := 0
+
+	for {
+		attempt++
+
+		err := q.finalizeTx(ctx, finalization)
+		q.metrics.ObserveFinalizeAttempt(err)
+		finalization.Attempts++
+		finalization.UpdatedAt = time.Now().Unix()
+		if errors.Is(err, ErrUnknownArkServer) {
+			// the arkd was removed from the config since the tx was accepted
+			finalization.LastError = err.Error()
+			q.markStuck(ctx, finalization)
+			return fmt.Errorf("%w: %s", ErrFinalizationStuck, err)
+		}
+		if err == nil {
+			finalization.Status = FinalizationStatusFinalized
+			finalization.LastError = ""
+			q.persist(ctx, *finalization)
+			return nil
+		}
+
+		log.WithField("txid", finalization.Txid).WithField("attempt", attempt).Errorf("finalizing tx failed: %s", err)
+		finalization.LastError = err.Error()
+
+		if retryConfig.MaxAttempts > 0 && attempt >= retryConfig.MaxAttempts {
+			q.markStuck(ctx, finalization)
+			return fmt.Errorf("%w after %d attempts: %s", ErrFinalizationStuck, attempt, err)
+		}
+		q.persist(ctx, *finalization)
+
+		delay := applyJitter(backoffDelay, retryConfig.Jitter)
+		backoffDelay = min(retryConfig.MaxDelay, time.Duration(float64(backoffDelay)*retryConfig.Multiplier))
+
+		// try a minimum number of times before respecting ctx.Done, only
+		// closing the queue interrupts them
+		waitCtx := ctx
+		if attempt < minAttempts {
+			waitCtx = q.ctx
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return fmt.Errorf("finalize retry cancelled after attempt %d: %w", attempt, waitCtx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// markStuck persists the finalization as stuck, and marks the signatures of
+// the tx and its checkpoints as failed in the journal until an operator retry
+func (q *finalizationQueue) markStuck(ctx context.Context, finalization *Finalization) {
+	finalization.Status = FinalizationStatusStuck
+	q.persist(ctx, *finalization)
+
+	txids := []string{finalization.Txid}
+	for _, checkpoint := range finalization.Checkpoints {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(checkpoint), true)
+		if err != nil {
+			log.WithError(err).WithField("txid", finalization.Txid).Warn("failed to decode checkpoint")
+			continue
+		}
+		txids = append(txids, ptx.UnsignedTx.TxID())
+	}
+	if err := q.journal.MarkFailed(context.WithoutCancel(ctx), txids...); err != nil {
+		log.WithError(err).WithField("txid", finalization.Txid).Warn("failed to mark tx as failed in signing journal")
+	}
+}
+
+// finalizeTx fails with ErrArkServerUnavailable while the arkd that accepted
+// the tx is not reachable, the attempt is retried as any other failure
+func (q *finalizationQueue) finalizeTx(ctx context.Context, finalization *Finalization) error {
+	server, err := q.arkServers.get(finalization.ArkdPubKey)
+	if err != nil {
+		return err
+	}
+	return server.client.FinalizeTx(ctx, finalization.Txid, finalization.Checkpoints)
+}
+
+// persist doesn't fail the retry loop, the next attempt will overwrite the entry
+func (q *finalizationQueue) persist(ctx context.Context, finalization Finalization) {
+	if err := q.store.Upsert(context.WithoutCancel(ctx), finalization); err != nil {
+		log.WithError(err).WithField("txid", finalization.Txid).Warn("failed to persist finalization")
+	}
+}
+
+func (q *finalizationQueue) lockTx(txid string) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if _, ok := q.inProgress[txid]; ok {
+		return false
+	}
+	q.inProgress[txid] = struct{}{}
+	return true
+}
+
+func (q *finalizationQueue) unlockTx(txid string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.inProgress, txid)
+}
+
+// applyJitter adds ±jitter randomness to a duration.
+// with jitter = 0.2, d get + or - 20%
+func applyJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	if jitter >= 1.0 {
+		jitter = 0.999
+	}
+
+	randomFactor := 2.0*rand.Float64() - 1.0 // [-1, +1] factor
+	jitterFactor := 1.0 + jitter*randomFactor
+	return time.Duration(float64(d) * jitterFactor)
+}
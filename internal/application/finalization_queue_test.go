@@ -0,0 +1,277 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFinalizeRetryConfig = FinalizeRetryConfig{
+	MinAttempts:  3,
+	MaxAttempts:  5,
+	InitialDelay: 10 * time.Millisecond,
+	MaxDelay:     10 * time.Millisecond,
+	Multiplier:   1,
+	Jitter:       0,
+}
+
+func TestRetryFinalize(t *testing.T) {
+	t.Run("success after retries", func(t *testing.T) {
+		client := &mockArkdClient{
+			finalizeErrs: []error{
+				fmt.Errorf("retry 1"),
+				fmt.Errorf("retry 2"),
+				nil,
+			},
+		}
+		store := newMockFinalizationStore()
+		q := newTestFinalizationQueue(t, client, store)
+
+		checkpoints := []string{"checkpoint-a", "checkpoint-b"}
+		err := q.Finalize(t.Context(), "txid-123", "", checkpoints)
+		require.NoError(t, err)
+		require.Equal(t, 3, client.finalizeCalls)
+		require.Equal(t, []string{"txid-123", "txid-123", "txid-123"}, client.finalizeTxids)
+		require.Equal(t, [][]string{
+			{"checkpoint-a", "checkpoint-b"},
+			{"checkpoint-a", "checkpoint-b"},
+			{"checkpoint-a", "checkpoint-b"},
+		}, client.finalizePayloads)
+
+		finalization, err := store.Get(t.Context(), "txid-123")
+		require.NoError(t, err)
+		require.Equal(t, FinalizationStatusFinalized, finalization.Status)
+		require.Equal(t, 3, finalization.Attempts)
+		require.Empty(t, finalization.LastError)
+	})
+
+	t.Run("exhausts minimum retries", func(t *testing.T) {
+		client := &mockArkdClient{
+			finalizeErrs: []error{
+				fmt.Errorf("retry 1"),
+				fmt.Errorf("retry 2"),
+				fmt.Errorf("retry 3"),
+				fmt.Errorf("retry 4"),
+			},
+		}
+		store := newMockFinalizationStore()
+		q := newTestFinalizationQueue(t, client, store)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		// simulates client hangup
+		cancel()
+		err := q.retryFinalize(
+			ctx,
+			&Finalization{Txid: "txid-123", Checkpoints: []string{"checkpoint-a"}},
+			testFinalizeRetryConfig.MinAttempts,
+		)
+		require.ErrorContains(t, err, "context canceled")
+		require.Equal(t, 3, client.finalizeCalls)
+		require.Equal(t, []string{"txid-123", "txid-123", "txid-123"}, client.finalizeTxids)
+		require.Equal(t, [][]string{
+			{"checkpoint-a"},
+			{"checkpoint-a"},
+			{"checkpoint-a"},
+		}, client.finalizePayloads)
+
+		finalization, err := store.Get(t.Context(), "txid-123")
+		require.NoError(t, err)
+		require.Equal(t, 3, finalization.Attempts)
+		require.Equal(t, "retry 3", finalization.LastError)
+	})
+
+	t.Run("stuck after max attempts", func(t *testing.T) {
+		client := &mockArkdClient{}
+		for i := range testFinalizeRetryConfig.MaxAttempts {
+			client.finalizeErrs = append(client.finalizeErrs, fmt.Errorf("retry %d", i))
+		}
+		store := newMockFinalizationStore()
+		q := newTestFinalizationQueue(t, client, store)
+		journal := q.journal.(*mockJournal)
+		require.NoError(t, journal.Add(t.Context(), Signature{Txid: "txid-123"}))
+
+		err := q.Finalize(t.Context(), "txid-123", "", []string{"checkpoint-a"})
+		require.ErrorIs(t, err, ErrFinalizationStuck)
+		require.Equal(t, testFinalizeRetryConfig.MaxAttempts, client.finalizeCalls)
+		requireFailed(t, journal, "txid-123")
+
+		list, err := q.List(t.Context())
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, FinalizationStatusStuck, list[0].Status)
+
+		// an operator retry succeeds now that arkd accepts the finalization
+		require.NoError(t, q.Retry(t.Context(), "txid-123"))
+		require.Eventually(t, func() bool {
+			finalization, err := store.Get(t.Context(), "txid-123")
+			return err == nil && finalization.Status == FinalizationStatusFinalized
+		}, time.Second, 10*time.Millisecond)
+
+		list, err = q.List(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, list)
+
+		err = q.Retry(t.Context(), "txid-123")
+		require.ErrorIs(t, err, ErrFinalizationCompleted)
+		err = q.Retry(t.Context(), "unknown")
+		require.ErrorIs(t, err, ErrFinalizationNotFound)
+	})
+
+	t.Run("stuck if arkd is not served", func(t *testing.T) {
+		client := &mockArkdClient{}
+		store := newMockFinalizationStore()
+		q := newTestFinalizationQueue(t, client, store)
+		journal := q.journal.(*mockJournal)
+		require.NoError(t, journal.Add(t.Context(), Signature{Txid: "txid-123"}))
+
+		err := q.Finalize(t.Context(), "txid-123", "unknown", []string{"checkpoint-a"})
+		require.ErrorIs(t, err, ErrFinalizationStuck)
+		require.ErrorContains(t, err, ErrUnknownArkServer.Error())
+		require.Zero(t, client.finalizeCalls)
+
+		finalization, err := store.Get(t.Context(), "txid-123")
+		require.NoError(t, err)
+		require.Equal(t, FinalizationStatusStuck, finalization.Status)
+		require.Equal(t, "unknown", finalization.ArkdPubKey)
+		require.Equal(t, 1, finalization.Attempts)
+		requireFailed(t, journal, "txid-123")
+	})
+
+	t.Run("pending while arkd is unavailable", func(t *testing.T) {
+		store := newMockFinalizationStore()
+		servers := newTestArkServers()
+		servers.watchers = append(servers.watchers, &arkdWatcher{
+			url: "arkd:7070", client: &mockArkdClient{}, lastErr: fmt.Errorf("connection refused"),
+		})
+		q, err := NewFinalizationQueue(
+			t.Context(), servers, store, newMockJournal(), testFinalizeRetryConfig, NoopMetrics,
+		)
+		require.NoError(t, err)
+		t.Cleanup(q.Close)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		err = q.(*finalizationQueue).retryFinalize(
+			ctx, &Finalization{Txid: "txid-123", Checkpoints: []string{"checkpoint-a"}}, 0,
+		)
+		require.ErrorContains(t, err, "context canceled")
+
+		finalization, err := store.Get(t.Context(), "txid-123")
+		require.NoError(t, err)
+		require.NotEqual(t, FinalizationStatusStuck, finalization.Status)
+		require.Contains(t, finalization.LastError, ErrArkServerUnavailable.Error())
+	})
+
+	t.Run("resume pending on startup", func(t *testing.T) {
+		store := newMockFinalizationStore()
+		require.NoError(t, store.Upsert(t.Context(), Finalization{
+			Txid:        "txid-123",
+			Checkpoints: []string{"checkpoint-a"},
+			Status:      FinalizationStatusPending,
+			Attempts:    7,
+		}))
+
+		client := &mockArkdClient{}
+		newTestFinalizationQueue(t, client, store)
+
+		require.Eventually(t, func() bool {
+			finalization, err := store.Get(t.Context(), "txid-123")
+			return err == nil && finalization.Status == FinalizationStatusFinalized
+		}, time.Second, 10*time.Millisecond)
+
+		finalization, err := store.Get(t.Context(), "txid-123")
+		require.NoError(t, err)
+		require.Equal(t, 8, finalization.Attempts)
+	})
+
+	t.Run("close interrupts the minimum retries", func(t *testing.T) {
+		client := &mockArkdClient{}
+		for i := range testFinalizeRetryConfig.MinAttempts {
+			client.finalizeErrs = append(client.finalizeErrs, fmt.Errorf("retry %d", i))
+		}
+		store := newMockFinalizationStore()
+		q := newTestFinalizationQueue(t, client, store)
+		q.cancel()
+
+		err := q.retryFinalize(
+			t.Context(), &Finalization{Txid: "txid-123"}, testFinalizeRetryConfig.MinAttempts,
+		)
+		require.ErrorContains(t, err, "context canceled")
+		require.Equal(t, 1, client.finalizeCalls)
+	})
+}
+
+func requireFailed(t *testing.T, journal *mockJournal, txid string) {
+	t.Helper()
+	signatures, err := journal.ListRecent(t.Context(), 10)
+	require.NoError(t, err)
+	found := false
+	for _, signature := range signatures {
+		if signature.Txid == txid {
+			require.True(t, signature.Failed)
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func newTestFinalizationQueue(
+	t *testing.T, client *mockArkdClient, store *mockFinalizationStore,
+) *finalizationQueue {
+	t.Helper()
+	servers := newTestArkServers(&arkServer{client: client})
+	q, err := NewFinalizationQueue(
+		t.Context(), servers, store, newMockJournal(), testFinalizeRetryConfig, NoopMetrics,
+	)
+	require.NoError(t, err)
+	t.Cleanup(q.Close)
+	return q.(*finalizationQueue)
+}
+
+type mockFinalizationStore struct {
+	lock          sync.Mutex
+	finalizations map[string]Finalization
+}
+
+func newMockFinalizationStore() *mockFinalizationStore {
+	return &mockFinalizationStore{finalizations: make(map[string]Finalization)}
+}
+
+func (m *mockFinalizationStore) Upsert(_ context.Context, finalization Finalization) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.finalizations[finalization.Txid] = finalization
+	return nil
+}
+
+func (m *mockFinalizationStore) Get(_ context.Context, txid string) (*Finalization, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	finalization, ok := m.finalizations[txid]
+	if !ok {
+		return nil, ErrFinalizationNotFound
+	}
+	return &finalization, nil
+}
+
+func (m *mockFinalizationStore) GetByStatus(
+	_ context.Context, statuses ...FinalizationStatus,
+) ([]Finalization, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var result []Finalization
+	for _, finalization := range m.finalizations {
+		for _, status := range statuses {
+			if finalization.Status == status {
+				result = append(result, finalization)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *mockFinalizationStore) Close() {}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	ErrInvalidConnectorTree = errors.New("invalid connector tree")
+	ErrInvalidForfeit       = errors.New("invalid forfeit")
+)
+
+// validateConnectorTree checks that the connector tree is well formed and
+// spends entirely an output of the commitment tx
+func validateConnectorTree(connectorTree *tree.TxTree, commitmentTx *psbt.Packet) error {
+	if err := checkConnectorTree(connectorTree, commitmentTx); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidConnectorTree, err)
+	}
+	return nil
+}
+
+func checkConnectorTree(connectorTree *tree.TxTree, commitmentTx *psbt.Packet) error {
+	if connectorTree == nil {
+		return fmt.Errorf("missing tree")
+	}
+	_, err := checkTreeRoot(connectorTree, commitmentTx)
+	return err
+}
+
+// validateForfeit checks that the forfeit spends the vtxo for its full amount
+// along with a connector of the tree, and pays everything to arkd
+func validateForfeit(
+	forfeit *psbt.Packet, vtxoIndex int, vtxoPrevout *wire.TxOut,
+	connectorTree *tree.TxTree, forfeitPkScript []byte,
+) error {
+	if err := checkForfeit(
+		forfeit, vtxoIndex, vtxoPrevout, connectorTree, forfeitPkScript,
+	); err != nil {
+		return fmt.Errorf("%w %s: %s", ErrInvalidForfeit, forfeit.UnsignedTx.TxID(), err)
+	}
+	return nil
+}
+
+func checkForfeit(
+	forfeit *psbt.Packet, vtxoIndex int, vtxoPrevout *wire.TxOut,
+	connectorTree *tree.TxTree, forfeitPkScript []byte,
+) error {
+	if len(forfeit.Inputs) != 2 || len(forfeit.UnsignedTx.TxIn) != 2 {
+		return fmt.Errorf("expected 2 inputs, got %d", len(forfeit.UnsignedTx.TxIn))
+	}
+
+	// the vtxo must be forfeited with the amount we signed the intent for
+	vtxoInput := forfeit.Inputs[vtxoIndex]
+	if vtxoInput.WitnessUtxo == nil || vtxoPrevout == nil ||
+		vtxoInput.WitnessUtxo.Value != vtxoPrevout.Value ||
+		!bytes.Equal(vtxoInput.WitnessUtxo.PkScript, vtxoPrevout.PkScript) {
+		return fmt.Errorf("vtxo witness utxo doesn't match the intent proof")
+	}
+
+	connectorIndex := vtxoIndex ^ 1 // if vtxoIndex is 0, connectorIndex is 1, and vice versa
+	connector := forfeit.UnsignedTx.TxIn[connectorIndex].PreviousOutPoint
+	connectorOutput, err := findConnector(connectorTree, connector)
+	if err != nil {
+		return err
+	}
+	connectorInput := forfeit.Inputs[connectorIndex]
+	if connectorInput.WitnessUtxo == nil ||
+		connectorInput.WitnessUtxo.Value != connectorOutput.Value ||
+		!bytes.Equal(connectorInput.WitnessUtxo.PkScript, connectorOutput.PkScript) {
+		return fmt.Errorf("connector witness utxo doesn't match the connector tree")
+	}
+
+	outputs := forfeit.UnsignedTx.TxOut
+	if len(outputs) != 2 {
+		return fmt.Errorf("expected 2 outputs, got %d", len(outputs))
+	}
+	if !bytes.Equal(outputs[0].PkScript, forfeitPkScript) {
+		return fmt.Errorf("output is not paying the arkd forfeit address")
+	}
+	expectedAmount := vtxoPrevout.Value + connectorOutput.Value - txutils.ANCHOR_VALUE
+	if outputs[0].Value != expectedAmount {
+		return fmt.Errorf("output amount %d, expected %d", outputs[0].Value, expectedAmount)
+	}
+	if outputs[1].Value != txutils.ANCHOR_VALUE ||
+		!bytes.Equal(outputs[1].PkScript, txutils.ANCHOR_PKSCRIPT) {
+		return fmt.Errorf("missing anchor output")
+	}
+
+	return nil
+}
+
+// findConnector returns the connector output if the outpoint is a non-anchor
+// output of a leaf of the tree
+func findConnector(connectorTree *tree.TxTree, outpoint wire.OutPoint) (*wire.TxOut, error) {
+	var leaf *psbt.Packet
+	for _, tx := range connectorTree.Leaves() {
+		if tx.UnsignedTx.TxHash() == outpoint.Hash {
+			leaf = tx
+			break
+		}
+	}
+	if leaf == nil {
+		return nil, fmt.Errorf("connector %s is not a leaf of the tree", outpoint)
+	}
+	if len(leaf.UnsignedTx.TxOut) <= int(outpoint.Index) {
+		return nil, fmt.Errorf("connector %s index out of range", outpoint)
+	}
+
+	output := leaf.UnsignedTx.TxOut[outpoint.Index]
+	if bytes.Equal(output.PkScript, txutils.ANCHOR_PKSCRIPT) {
+		return nil, fmt.Errorf("connector %s is an anchor", outpoint)
+	}
+	return output, nil
+}
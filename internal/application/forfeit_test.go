@@ -0,0 +1,202 @@
+package application
+
+import (
+	"encoding/hex"
+	"testing"
+
+	arkscript "github.com/arkade-os/arkd/pkg/ark-lib/script"
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/arkd/pkg/ark-lib/txutils"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForfeit(t *testing.T) {
+	newPkScript := func(t *testing.T) []byte {
+		t.Helper()
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		pkScript, err := arkscript.P2TRScript(key.PubKey())
+		require.NoError(t, err)
+		return pkScript
+	}
+	arkdKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	forfeitPkScript := newPkScript(t)
+	vtxoPrevout := &wire.TxOut{Value: 10_000, PkScript: newPkScript(t)}
+
+	connectorLeaves := make([]tree.Leaf, 0, 2)
+	for range 2 {
+		connectorLeaves = append(connectorLeaves, tree.Leaf{
+			Outputs: []tree.LeafOutput{{
+				Amount: 330, Script: hex.EncodeToString(newPkScript(t)),
+			}},
+			CosignersPublicKeys: []string{
+				hex.EncodeToString(arkdKey.PubKey().SerializeCompressed()),
+			},
+		})
+	}
+
+	// commitment tx with a batch output and a connector output
+	newCommitmentTx := func(t *testing.T) (*psbt.Packet, *tree.TxTree) {
+		t.Helper()
+		connectorPkScript, connectorAmount, err := tree.BuildConnectorOutput(connectorLeaves)
+		require.NoError(t, err)
+
+		ptx, err := psbt.New(
+			[]*wire.OutPoint{{Hash: chainhash.Hash{1}, Index: 0}},
+			[]*wire.TxOut{
+				{Value: 50_000, PkScript: newPkScript(t)},
+				{Value: connectorAmount, PkScript: connectorPkScript},
+				txutils.AnchorOutput(),
+			},
+			3, 0, []uint32{wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+
+		connectorTree, err := tree.BuildConnectorTree(
+			&wire.OutPoint{Hash: ptx.UnsignedTx.TxHash(), Index: 1}, connectorLeaves,
+		)
+		require.NoError(t, err)
+		return ptx, connectorTree
+	}
+
+	newForfeit := func(t *testing.T, connectorTree *tree.TxTree) *psbt.Packet {
+		t.Helper()
+		leaf := connectorTree.Leaves()[0]
+		connector := leaf.UnsignedTx.TxOut[0]
+		forfeit, err := tree.BuildForfeitTx(
+			[]*wire.OutPoint{
+				{Hash: chainhash.Hash{2}, Index: 0},
+				{Hash: leaf.UnsignedTx.TxHash(), Index: 0},
+			},
+			[]uint32{wire.MaxTxInSequenceNum, wire.MaxTxInSequenceNum},
+			[]*wire.TxOut{vtxoPrevout, connector},
+			forfeitPkScript, 0,
+		)
+		require.NoError(t, err)
+		return forfeit
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		commitmentTx, connectorTree := newCommitmentTx(t)
+		require.NoError(t, validateConnectorTree(connectorTree, commitmentTx))
+
+		forfeit := newForfeit(t, connectorTree)
+		require.NoError(t, validateForfeit(forfeit, 0, vtxoPrevout, connectorTree, forfeitPkScript))
+	})
+
+	t.Run("connector tree", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			tamper func(commitmentTx *psbt.Packet, connectorTree *tree.TxTree)
+		}{
+			{
+				name: "not rooted in commitment tx",
+				tamper: func(_ *psbt.Packet, connectorTree *tree.TxTree) {
+					connectorTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint.Hash = chainhash.Hash{3}
+				},
+			},
+			{
+				name: "spending the batch output",
+				tamper: func(_ *psbt.Packet, connectorTree *tree.TxTree) {
+					connectorTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint.Index = 0
+				},
+			},
+			{
+				name: "spending the anchor",
+				tamper: func(_ *psbt.Packet, connectorTree *tree.TxTree) {
+					connectorTree.Root.UnsignedTx.TxIn[0].PreviousOutPoint.Index = 2
+				},
+			},
+			{
+				name: "broken parent/child chain",
+				tamper: func(_ *psbt.Packet, connectorTree *tree.TxTree) {
+					connectorTree.Root.UnsignedTx.TxOut[0].Value += 1
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				commitmentTx, connectorTree := newCommitmentTx(t)
+				tc.tamper(commitmentTx, connectorTree)
+				err := validateConnectorTree(connectorTree, commitmentTx)
+				require.ErrorIs(t, err, ErrInvalidConnectorTree)
+			})
+		}
+	})
+
+	t.Run("forfeit", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			tamper func(forfeit *psbt.Packet)
+		}{
+			{
+				name: "connector not in tree",
+				tamper: func(forfeit *psbt.Packet) {
+					forfeit.UnsignedTx.TxIn[1].PreviousOutPoint.Hash = chainhash.Hash{4}
+				},
+			},
+			{
+				name: "connector is an anchor",
+				tamper: func(forfeit *psbt.Packet) {
+					forfeit.UnsignedTx.TxIn[1].PreviousOutPoint.Index = 1
+				},
+			},
+			{
+				name: "vtxo amount mismatch",
+				tamper: func(forfeit *psbt.Packet) {
+					forfeit.Inputs[0].WitnessUtxo = &wire.TxOut{
+						Value: 5_000, PkScript: vtxoPrevout.PkScript,
+					}
+				},
+			},
+			{
+				name: "not paying arkd",
+				tamper: func(forfeit *psbt.Packet) {
+					forfeit.UnsignedTx.TxOut[0].PkScript = vtxoPrevout.PkScript
+				},
+			},
+			{
+				name: "amount not forfeited",
+				tamper: func(forfeit *psbt.Packet) {
+					forfeit.UnsignedTx.TxOut[0].Value -= 1_000
+				},
+			},
+			{
+				name: "missing anchor",
+				tamper: func(forfeit *psbt.Packet) {
+					forfeit.UnsignedTx.TxOut = forfeit.UnsignedTx.TxOut[:1]
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, connectorTree := newCommitmentTx(t)
+				forfeit := newForfeit(t, connectorTree)
+				tc.tamper(forfeit)
+				err := validateForfeit(forfeit, 0, vtxoPrevout, connectorTree, forfeitPkScript)
+				require.ErrorIs(t, err, ErrInvalidForfeit)
+			})
+		}
+
+		t.Run("connector not a leaf", func(t *testing.T) {
+			_, connectorTree := newCommitmentTx(t)
+			require.NotEmpty(t, connectorTree.Children)
+
+			forfeit := newForfeit(t, connectorTree)
+			root := connectorTree.Root.UnsignedTx
+			forfeit.UnsignedTx.TxIn[1].PreviousOutPoint = wire.OutPoint{Hash: root.TxHash()}
+			forfeit.Inputs[1].WitnessUtxo = root.TxOut[0]
+
+			err := validateForfeit(forfeit, 0, vtxoPrevout, connectorTree, forfeitPkScript)
+			require.ErrorIs(t, err, ErrInvalidForfeit)
+			require.ErrorContains(t, err, "not a leaf")
+		})
+	})
+}
@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// components of the service reported by the health checks
+const (
+	HealthComponentKeystore      = "keystore"
+	HealthComponentArkd          = "arkd"
+	HealthComponentFinalizations = "finalizations"
+)
+
+// interval between two evaluations of the health of a watcher
+const healthWatchInterval = time.Second
+
+type HealthConfig struct {
+	// MaxPendingFinalizations is the backlog of pending finalizations above
+	// which the service is not ready, 0 means unlimited
+	MaxPendingFinalizations int
+}
+
+var DefaultHealthConfig = HealthConfig{
+	MaxPendingFinalizations: 100,
+}
+
+// HealthCheck is the state of a component the service depends on
+type HealthCheck struct {
+	Component string
+	Healthy   bool
+	Detail    string
+}
+
+type Health struct {
+	// Ready is set if all the components are healthy
+	Ready  bool
+	Checks []HealthCheck
+}
+
+// Check returns the health check of the component, false if unknown
+func (h Health) Check(component string) (HealthCheck, bool) {
+	for _, check := range h.Checks {
+		if check.Component == component {
+			return check, true
+		}
+	}
+	return HealthCheck{}, false
+}
+
+// HealthService derives the readiness of the service from the state of the
+// keystore, the served arkd instances and the finalization backlog.
+type HealthService interface {
+	Check(context.Context) (*Health, error)
+	// Watch sends the current health, then every change until ctx is done
+	Watch(context.Context) (<-chan Health, error)
+}
+
+type healthService struct {
+	keystore      Keystore
+	arkServers    *ArkServers
+	finalizations FinalizationQueue
+	config        HealthConfig
+}
+
+func NewHealthService(
+	keystore Keystore, arkServers *ArkServers,
+	finalizations FinalizationQueue, config HealthConfig,
+) HealthService {
+	return &healthService{keystore, arkServers, finalizations, config}
+}
+
+func (h *healthService) Check(ctx context.Context) (*Health, error) {
+	finalizations, err := h.checkFinalizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []HealthCheck{h.checkKeystore(), h.checkArkd(), *finalizations}
+	ready := true
+	for _, check := range checks {
+		ready = ready && check.Healthy
+	}
+	return &Health{Ready: ready, Checks: checks}, nil
+}
+
+func (h *healthService) Watch(ctx context.Context) (<-chan Health, error) {
+	health, err := h.Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Health)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(healthWatchInterval)
+		defer ticker.Stop()
+
+		last := *health
+		for {
+			select {
+			case ch <- last:
+			case <-ctx.Done():
+				return
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+
+				next, err := h.Check(ctx)
+				if err != nil {
+					log.WithError(err).Warn("failed to check health")
+					continue
+				}
+				if !reflect.DeepEqual(*next, last) {
+					last = *next
+					break
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (h *healthService) checkKeystore() HealthCheck {
+	check := HealthCheck{Component: HealthComponentKeystore}
+	switch {
+	case !h.keystore.IsInitialized():
+		check.Detail = "not initialized"
+	case h.keystore.IsLocked():
+		check.Detail = "locked"
+	default:
+		check.Healthy = true
+		check.Detail = "unlocked"
+	}
+	return check
+}
+
+// checkArkd is healthy while at least one arkd answered its last info fetch,
+// the requests bound to the unreachable ones fail meanwhile
+func (h *healthService) checkArkd() HealthCheck {
+	servers := h.arkServers.list()
+
+	reachable := 0
+	unreachable := make([]string, 0)
+	for _, server := range servers {
+		if server.UpdatedAt > 0 && server.LastError == "" {
+			reachable++
+			continue
+		}
+		reason := server.LastError
+		if reason == "" {
+			reason = "never reached"
+		}
+		unreachable = append(unreachable, fmt.Sprintf("%s: %s", server.URL, reason))
+	}
+
+	detail := fmt.Sprintf("%d/%d reachable", reachable, len(servers))
+	if len(unreachable) > 0 {
+		detail = fmt.Sprintf("%s (%s)", detail, strings.Join(unreachable, ", "))
+	}
+	return HealthCheck{
+		Component: HealthComponentArkd,
+		Healthy:   reachable > 0,
+		Detail:    detail,
+	}
+}
+
+// checkFinalizations is unhealthy if the pending backlog is too large, the
+// stuck finalizations wait for an operator and don't affect readiness
+func (h *healthService) checkFinalizations(ctx context.Context) (*HealthCheck, error) {
+	finalizations, err := h.finalizations.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list finalizations: %w", err)
+	}
+
+	pending, stuck := 0, 0
+	for _, finalization := range finalizations {
+		switch finalization.Status {
+		case FinalizationStatusPending:
+			pending++
+		case FinalizationStatusStuck:
+			stuck++
+		}
+	}
+
+	maxPending := h.config.MaxPendingFinalizations
+	return &HealthCheck{
+		Component: HealthComponentFinalizations,
+		Healthy:   maxPending <= 0 || pending <= maxPending,
+		Detail:    fmt.Sprintf("%d pending, %d stuck", pending, stuck),
+	}, nil
+}
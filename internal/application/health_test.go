@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthService(t *testing.T) {
+	newHealthService := func(
+		t *testing.T, keystore *mockKeystore, servers *ArkServers, store *mockFinalizationStore,
+	) HealthService {
+		t.Helper()
+		q, err := NewFinalizationQueue(
+			t.Context(), servers, store, newMockJournal(), testFinalizeRetryConfig, NoopMetrics,
+		)
+		require.NoError(t, err)
+		t.Cleanup(q.Close)
+		return NewHealthService(keystore, servers, q, HealthConfig{MaxPendingFinalizations: 1})
+	}
+	requireCheck := func(t *testing.T, health *Health, component string, healthy bool, detail string) {
+		t.Helper()
+		check, ok := health.Check(component)
+		require.True(t, ok)
+		require.Equal(t, healthy, check.Healthy)
+		require.Equal(t, detail, check.Detail)
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		svc := newHealthService(
+			t, &mockKeystore{initialized: true},
+			newTestArkServers(&arkServer{}), newMockFinalizationStore(),
+		)
+
+		health, err := svc.Check(t.Context())
+		require.NoError(t, err)
+		require.True(t, health.Ready)
+		requireCheck(t, health, HealthComponentKeystore, true, "unlocked")
+		requireCheck(t, health, HealthComponentArkd, true, "1/1 reachable")
+		requireCheck(t, health, HealthComponentFinalizations, true, "0 pending, 0 stuck")
+
+		_, ok := health.Check("unknown")
+		require.False(t, ok)
+	})
+
+	t.Run("keystore", func(t *testing.T) {
+		keystore := &mockKeystore{}
+		svc := newHealthService(t, keystore, newTestArkServers(&arkServer{}), newMockFinalizationStore())
+
+		health, err := svc.Check(t.Context())
+		require.NoError(t, err)
+		require.False(t, health.Ready)
+		requireCheck(t, health, HealthComponentKeystore, false, "not initialized")
+
+		keystore.initialized, keystore.locked = true, true
+		health, err = svc.Check(t.Context())
+		require.NoError(t, err)
+		require.False(t, health.Ready)
+		requireCheck(t, health, HealthComponentKeystore, false, "locked")
+	})
+
+	t.Run("arkd", func(t *testing.T) {
+		servers := newTestArkServers(&arkServer{url: "mainnet:7070"})
+		servers.watchers = append(servers.watchers, &arkdWatcher{
+			url: "down:7070", lastErr: fmt.Errorf("connection refused"),
+		})
+		svc := newHealthService(t, &mockKeystore{initialized: true}, servers, newMockFinalizationStore())
+
+		// one reachable arkd is enough to serve requests
+		health, err := svc.Check(t.Context())
+		require.NoError(t, err)
+		require.True(t, health.Ready)
+		requireCheck(
+			t, health, HealthComponentArkd, true,
+			"1/2 reachable (down:7070: connection refused)",
+		)
+
+		servers.watchers[0].lastErr = fmt.Errorf("timeout")
+		health, err = svc.Check(t.Context())
+		require.NoError(t, err)
+		require.False(t, health.Ready)
+		requireCheck(
+			t, health, HealthComponentArkd, false,
+			"0/2 reachable (mainnet:7070: timeout, down:7070: connection refused)",
+		)
+	})
+
+	t.Run("finalization backlog", func(t *testing.T) {
+		store := newMockFinalizationStore()
+		svc := newHealthService(
+			t, &mockKeystore{initialized: true}, newTestArkServers(&arkServer{}), store,
+		)
+
+		// stuck finalizations wait for an operator and don't affect readiness
+		require.NoError(t, store.Upsert(t.Context(), Finalization{Txid: "stuck-1", Status: FinalizationStatusStuck}))
+		require.NoError(t, store.Upsert(t.Context(), Finalization{Txid: "stuck-2", Status: FinalizationStatusStuck}))
+		require.NoError(t, store.Upsert(t.Context(), Finalization{Txid: "pending-1", Status: FinalizationStatusPending}))
+		health, err := svc.Check(t.Context())
+		require.NoError(t, err)
+		require.True(t, health.Ready)
+		requireCheck(t, health, HealthComponentFinalizations, true, "1 pending, 2 stuck")
+
+		require.NoError(t, store.Upsert(t.Context(), Finalization{Txid: "pending-2", Status: FinalizationStatusPending}))
+		health, err = svc.Check(t.Context())
+		require.NoError(t, err)
+		require.False(t, health.Ready)
+		requireCheck(t, health, HealthComponentFinalizations, false, "2 pending, 2 stuck")
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		keystore := &mockKeystore{initialized: true, locked: true}
+		svc := newHealthService(t, keystore, newTestArkServers(&arkServer{}), newMockFinalizationStore())
+
+		ctx, cancel := context.WithCancel(t.Context())
+		updates, err := svc.Watch(ctx)
+		require.NoError(t, err)
+
+		health := <-updates
+		require.False(t, health.Ready)
+
+		keystore.unlock()
+		select {
+		case health = <-updates:
+			require.True(t, health.Ready)
+		case <-time.After(2 * healthWatchInterval):
+			t.Fatal("health change not sent")
+		}
+
+		cancel()
+		require.Eventually(t, func() bool {
+			_, ok := <-updates
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+// mockKeystore only reports its state, the signing paths are not exercised
+type mockKeystore struct {
+	Keystore
+	lock        sync.Mutex
+	initialized bool
+	locked      bool
+}
+
+func (m *mockKeystore) IsInitialized() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.initialized
+}
+
+func (m *mockKeystore) IsLocked() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.locked
+}
+
+func (m *mockKeystore) unlock() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.locked = false
+}
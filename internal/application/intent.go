@@ -2,31 +2,88 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/ArkLabsHQ/introspector/pkg/arkade"
 	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
-	"github.com/btcsuite/btcd/btcutil/psbt"
 	log "github.com/sirupsen/logrus"
 )
 
+var ErrInvalidIntent = errors.New("invalid intent")
+
 // SubmitIntent aims to execute arkade scripts on unsigned intent proof
 // it must be used before registration of the intent
-func (s *service) SubmitIntent(ctx context.Context, intent Intent) (*psbt.Packet, error) {
+// it is idempotent: a proof already handled gets the cached response
+// in strict mode, it fails if any entry of the introspector packet is not signed
+func (s *service) SubmitIntent(ctx context.Context, intent Intent, strict bool) (*SignedIntent, error) {
+	proofTxid := intent.Proof.UnsignedTx.TxID()
+
+	// the message is recorded with the intent, the proof must commit to it
+	encoded, err := intent.Message.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode register message: %w", err)
+	}
+	if err := validateProofMessage(&intent.Proof.Packet, encoded); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIntent, err)
+	}
+
+	unlock := s.submissionLocks.Lock(proofTxid)
+	defer unlock()
+
+	submission, err := s.getSubmission(ctx, SubmissionKindIntent, proofTxid)
+	if err != nil {
+		return nil, err
+	}
+	if submission != nil {
+		log.WithField("txid", proofTxid).Debug("intent already submitted, returning cached response")
+		signedProof, err := decodePsbt(submission.Tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode cached intent proof: %w", err)
+		}
+		signers, err := s.getKeyring()
+		if err != nil {
+			return nil, err
+		}
+		defer signers.zero()
+		entries, err := getSignedEntries(signedProof, signers)
+		if err != nil {
+			return nil, err
+		}
+		if strict {
+			if err := checkAllSigned(entries); err != nil {
+				return nil, err
+			}
+		}
+		s.startTreeCosigners(proofTxid, intent.Message)
+		return &SignedIntent{Proof: signedProof, Entries: entries}, nil
+	}
+
+	return s.submitIntent(ctx, intent, strict)
+}
+
+func (s *service) submitIntent(ctx context.Context, intent Intent, strict bool) (*SignedIntent, error) {
+	signers, err := s.getKeyring()
+	if err != nil {
+		return nil, err
+	}
+	defer signers.zero()
+
 	if err := validateRegisterMessage(intent.Message); err != nil {
-		return nil, fmt.Errorf("invalid message: %w", err)
+		return nil, fmt.Errorf("%w: invalid message: %s", ErrInvalidIntent, err)
 	}
 
 	ptx := &intent.Proof.Packet
 
+	_, span := tracer.Start(ctx, "BuildPrevOutFetcher")
 	prevOutFetcher, err := prevOutFetcherForIntent(ptx)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create prevout fetcher: %w", err)
 	}
 
 	// Parse IntrospectorPacket from the transaction's OP_RETURN output
-	packet, err := arkade.FindIntrospectorPacket(ptx.UnsignedTx)
+	packet, err := s.readIntrospectorPacket(ctx, ptx.UnsignedTx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse introspector packet: %w", err)
 	}
@@ -34,9 +91,15 @@ func (s *service) SubmitIntent(ctx context.Context, intent Intent) (*psbt.Packet
 	if len(packet) == 0 {
 		return nil, fmt.Errorf("no introspector packet found in transaction")
 	}
+	if err := s.checkPacket(packet); err != nil {
+		return nil, err
+	}
+	reservation := s.reserveSpending(ptx.UnsignedTx, prevOutFetcher)
+	defer reservation.release()
 
-	signerPublicKey := s.signer.secretKey.PubKey()
-
+	router := &serverRouter{servers: s.arkServers}
+	entries := make([]EntryOutcome, 0, len(packet))
+	signatures := make([]Signature, 0, len(packet)+1)
 	for _, entry := range packet {
 		inputIndex := int(entry.Vin)
 
@@ -47,34 +110,60 @@ func (s *service) SubmitIntent(ctx context.Context, intent Intent) (*psbt.Packet
 			continue
 		}
 
-		script, err := arkade.ReadArkadeScript(ptx, signerPublicKey, entry)
+		script, signer, err := signers.readArkadeScript(ptx, entry)
 		if err != nil {
-			// skip if the input is not a valid arkade script
+			entries = append(entries, newEntryOutcome(inputIndex, err))
+			continue
+		}
+
+		if _, err := router.route(script.ClosurePubKeys()); err != nil {
+			entries = append(entries, newEntryOutcome(inputIndex, err))
 			continue
 		}
 
-		if err := script.Execute(
-			ptx.UnsignedTx,
-			prevOutFetcher,
-			inputIndex,
+		if err := s.executeScript(
+			ctx, RequestTypeIntent, script, ptx.UnsignedTx, prevOutFetcher, inputIndex,
 		); err != nil {
 			log.WithError(err).WithField("input_index", inputIndex).Error("arkade script execution failed")
 			return nil, fmt.Errorf("failed to execute arkade script at input %d: %w", inputIndex, err)
 		}
 
-		if err := s.signer.signInput(ptx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
+		if err := reservation.add(inputIndex); err != nil {
+			return nil, fmt.Errorf("%w vin=%d", err, inputIndex)
+		}
+		if err := signer.signInput(ctx, ptx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
 			return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
 		}
+		signatures = append(signatures, newSignature(SignatureKindIntentProof, ptx, inputIndex, script.Hash()))
+		entries = append(entries, newEntryOutcome(inputIndex, nil))
 
 		// if input index 1 is valid and signed, we can also sign the intent message input (index 0)
 		if inputIndex == 1 {
-			if err := s.signer.signInput(ptx, 0, script.Hash(), prevOutFetcher); err != nil {
+			if err := signer.signInput(ctx, ptx, 0, script.Hash(), prevOutFetcher); err != nil {
 				return nil, fmt.Errorf("failed to sign fake message input: %w", err)
 			}
+			signatures = append(signatures, newSignature(SignatureKindIntentProof, ptx, 0, script.Hash()))
+		}
+	}
+
+	// nothing is journaled nor cached, the client can fix the entries and retry
+	if strict {
+		if err := checkAllSigned(entries); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(signatures) > 0 {
+		if err := s.journalSignatures(ctx, signatures); err != nil {
+			return nil, err
 		}
+		reservation.commit(ctx)
+		s.saveSubmission(ctx, SubmissionKindIntent, ptx.UnsignedTx.TxID(), SubmissionStatusSigned, ptx, nil, "")
+		s.saveIntent(ctx, ptx, intent.Message, router.server)
+		s.startTreeCosigners(ptx.UnsignedTx.TxID(), intent.Message)
 	}
 
-	return ptx, nil
+	return &SignedIntent{Proof: ptx, Entries: entries}, nil
 }
 
 func validateRegisterMessage(message intent.RegisterMessage) error {
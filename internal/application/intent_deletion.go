@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrInvalidIntentDeletion = errors.New("invalid intent deletion")
+
+// SubmitIntentDeletion signs a proof deleting a registered intent. The inputs
+// already signed in an intent proof with the same arkade script are signed
+// right away if the signature commits to the whole proof, the others only if
+// their script succeeds on the delete proof.
+func (s *service) SubmitIntentDeletion(ctx context.Context, deletion IntentDeletion) (*SignedIntent, error) {
+	signers, err := s.getKeyring()
+	if err != nil {
+		return nil, err
+	}
+	defer signers.zero()
+
+	ptx := &deletion.Proof.Packet
+	if err := validateDeleteMessage(ptx, deletion.Message); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIntentDeletion, err)
+	}
+
+	_, span := tracer.Start(ctx, "BuildPrevOutFetcher")
+	prevOutFetcher, err := prevOutFetcherForIntent(ptx)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prevout fetcher: %w", err)
+	}
+
+	packet, err := s.readIntrospectorPacket(ctx, ptx.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse introspector packet: %w", err)
+	}
+
+	if len(packet) == 0 {
+		return nil, fmt.Errorf("no introspector packet found in transaction")
+	}
+	if err := s.checkPacket(packet); err != nil {
+		return nil, err
+	}
+
+	entries := make([]EntryOutcome, 0, len(packet))
+	signatures := make([]Signature, 0, len(packet)+1)
+	for _, entry := range packet {
+		inputIndex := int(entry.Vin)
+
+		// input 0 is the message input, signed along with input 1
+		if inputIndex == 0 {
+			continue
+		}
+
+		script, signer, err := signers.readArkadeScript(ptx, entry)
+		if err != nil {
+			entries = append(entries, newEntryOutcome(inputIndex, err))
+			continue
+		}
+
+		outpoint := ptx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint
+		signed, err := s.isIntentSigned(ctx, outpoint, script.Hash())
+		if err != nil {
+			return nil, err
+		}
+		// a signature that doesn't commit to the whole proof could be reused
+		// for another tx, the script must then succeed on this one
+		if !signed || !commitsToTx(ptx.Inputs[inputIndex].SighashType) {
+			if err := s.executeScript(
+				ctx, RequestTypeIntentDeletion, script, ptx.UnsignedTx, prevOutFetcher, inputIndex,
+			); err != nil {
+				log.WithError(err).WithField("input_index", inputIndex).Error("arkade script execution failed")
+				return nil, fmt.Errorf("failed to execute arkade script at input %d: %w", inputIndex, err)
+			}
+		}
+
+		if err := signer.signInput(ctx, ptx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
+			return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
+		}
+		signatures = append(signatures, newSignature(SignatureKindIntentDeletion, ptx, inputIndex, script.Hash()))
+		entries = append(entries, newEntryOutcome(inputIndex, nil))
+
+		if inputIndex == 1 {
+			if err := signer.signInput(ctx, ptx, 0, script.Hash(), prevOutFetcher); err != nil {
+				return nil, fmt.Errorf("failed to sign fake message input: %w", err)
+			}
+			signatures = append(signatures, newSignature(SignatureKindIntentDeletion, ptx, 0, script.Hash()))
+		}
+	}
+
+	if len(signatures) > 0 {
+		if err := s.journalSignatures(ctx, signatures); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SignedIntent{Proof: ptx, Entries: entries}, nil
+}
+
+// commitsToTx returns true if the sighash type covers all the inputs and
+// outputs of the tx
+func commitsToTx(sighashType txscript.SigHashType) bool {
+	return sighashType == txscript.SigHashDefault || sighashType == txscript.SigHashAll
+}
+
+// isIntentSigned returns true if the outpoint was signed in an intent proof
+// with the given arkade script
+func (s *service) isIntentSigned(ctx context.Context, outpoint wire.OutPoint, scriptHash []byte) (bool, error) {
+	signatures, err := s.journal.GetByOutpoint(ctx, outpoint)
+	if err != nil {
+		return false, fmt.Errorf("failed to read signing journal: %w", err)
+	}
+
+	hash := hex.EncodeToString(scriptHash)
+	for _, signature := range signatures {
+		if signature.Kind == SignatureKindIntentProof && signature.ScriptHash == hash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validateDeleteMessage checks the message is not expired and that the proof
+// commits to it, so that a register proof can't be signed as a deletion
+func validateDeleteMessage(ptx *psbt.Packet, message intent.DeleteMessage) error {
+	if message.ExpireAt > 0 && time.Unix(message.ExpireAt, 0).Before(time.Now()) {
+		return fmt.Errorf("delete message expired")
+	}
+
+	encoded, err := message.Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode delete message: %w", err)
+	}
+	return validateProofMessage(ptx, encoded)
+}
+
+// validateProofMessage checks that the message input of the proof commits to
+// the encoded message
+func validateProofMessage(ptx *psbt.Packet, encoded string) error {
+	if len(ptx.UnsignedTx.TxIn) < 2 || len(ptx.Inputs) != len(ptx.UnsignedTx.TxIn) {
+		return fmt.Errorf("proof must have at least 2 inputs")
+	}
+	prevout := ptx.Inputs[1].WitnessUtxo
+	if prevout == nil {
+		return fmt.Errorf("missing witness utxo for input 1")
+	}
+
+	// the message input is the same for any proof of this message and first input
+	expected, err := intent.New(encoded, []intent.Input{{
+		OutPoint:    &ptx.UnsignedTx.TxIn[1].PreviousOutPoint,
+		WitnessUtxo: prevout,
+	}}, nil)
+	if err != nil {
+		return err
+	}
+	if expected.UnsignedTx.TxIn[0].PreviousOutPoint != ptx.UnsignedTx.TxIn[0].PreviousOutPoint {
+		return fmt.Errorf("proof doesn't commit to the message")
+	}
+	return nil
+}
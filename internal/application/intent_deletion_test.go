@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/arkade-os/arkd/pkg/ark-lib/extension"
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentDeletion(t *testing.T) {
+	ctx := context.Background()
+	outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	prevout := &wire.TxOut{Value: 1_000, PkScript: []byte{txscript.OP_1, txscript.OP_DATA_1, 0x01}}
+
+	newDeleteMessage := func(expireAt int64) intent.DeleteMessage {
+		return intent.DeleteMessage{
+			BaseMessage: intent.BaseMessage{Type: intent.IntentMessageTypeDelete},
+			ExpireAt:    expireAt,
+		}
+	}
+	newProof := func(t *testing.T, message string) *intent.Proof {
+		t.Helper()
+		proof, err := intent.New(message, []intent.Input{{
+			OutPoint: &outpoint, WitnessUtxo: prevout,
+		}}, nil)
+		require.NoError(t, err)
+		return proof
+	}
+
+	t.Run("delete message", func(t *testing.T) {
+		message := newDeleteMessage(0)
+		encoded, err := message.Encode()
+		require.NoError(t, err)
+
+		proof := newProof(t, encoded)
+		require.NoError(t, validateDeleteMessage(&proof.Packet, message))
+
+		// a proof of another message can't be signed as a deletion
+		register, err := intent.RegisterMessage{
+			BaseMessage: intent.BaseMessage{Type: intent.IntentMessageTypeRegister},
+		}.Encode()
+		require.NoError(t, err)
+		proof = newProof(t, register)
+		require.Error(t, validateDeleteMessage(&proof.Packet, message))
+
+		expired := newDeleteMessage(time.Now().Add(-time.Minute).Unix())
+		encoded, err = expired.Encode()
+		require.NoError(t, err)
+		proof = newProof(t, encoded)
+		require.Error(t, validateDeleteMessage(&proof.Packet, expired))
+	})
+
+	t.Run("signed intent", func(t *testing.T) {
+		journal := newMockJournal()
+		svc := &service{journal: journal}
+		scriptHash := []byte{0x01}
+
+		signed, err := svc.isIntentSigned(ctx, outpoint, scriptHash)
+		require.NoError(t, err)
+		require.False(t, signed)
+
+		spend := Signature{Outpoint: outpoint, Txid: "tx1", Kind: SignatureKindArkTx, ScriptHash: "01"}
+		require.NoError(t, journal.Add(ctx, spend))
+		signed, err = svc.isIntentSigned(ctx, outpoint, scriptHash)
+		require.NoError(t, err)
+		require.False(t, signed)
+
+		proof := Signature{Outpoint: outpoint, Txid: "tx2", Kind: SignatureKindIntentProof, ScriptHash: "01"}
+		require.NoError(t, journal.Add(ctx, proof))
+		signed, err = svc.isIntentSigned(ctx, outpoint, scriptHash)
+		require.NoError(t, err)
+		require.True(t, signed)
+
+		// a different script must be executed again
+		signed, err = svc.isIntentSigned(ctx, outpoint, []byte{0x02})
+		require.NoError(t, err)
+		require.False(t, signed)
+	})
+
+	t.Run("signature reuse", func(t *testing.T) {
+		require.True(t, commitsToTx(txscript.SigHashDefault))
+		require.True(t, commitsToTx(txscript.SigHashAll))
+		require.False(t, commitsToTx(txscript.SigHashNone))
+		require.False(t, commitsToTx(txscript.SigHashAll|txscript.SigHashAnyOneCanPay))
+	})
+
+	t.Run("unreadable entries", func(t *testing.T) {
+		secretKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		svc := &service{
+			keystore: &mockSigningKeystore{secretKey: secretKey},
+			guard:    NewSigningGuard(),
+			metrics:  NoopMetrics,
+			journal:  newMockJournal(),
+		}
+
+		message := newDeleteMessage(0)
+		encoded, err := message.Encode()
+		require.NoError(t, err)
+		packet, err := arkade.NewPacket(
+			arkade.IntrospectorEntry{Vin: 1, Script: []byte{txscript.OP_TRUE}},
+			arkade.IntrospectorEntry{Vin: 5, Script: []byte{txscript.OP_TRUE}},
+		)
+		require.NoError(t, err)
+		packetOut, err := extension.Extension{packet}.TxOut()
+		require.NoError(t, err)
+		proof, err := intent.New(encoded, []intent.Input{{
+			OutPoint: &outpoint, WitnessUtxo: prevout,
+		}}, []*wire.TxOut{packetOut})
+		require.NoError(t, err)
+
+		// the entries are reported instead of being silently skipped
+		signed, err := svc.SubmitIntentDeletion(ctx, IntentDeletion{Proof: *proof, Message: message})
+		require.NoError(t, err)
+		require.Len(t, signed.Entries, 2)
+		for i, vin := range []int{1, 5} {
+			require.Equal(t, vin, signed.Entries[i].InputIndex)
+			require.Equal(t, EntryStatusRejected, signed.Entries[i].Status)
+			require.NotEmpty(t, signed.Entries[i].Reason)
+		}
+		require.Error(t, checkAllSigned(signed.Entries))
+
+		// the key copies don't outlive the request
+		keystore := svc.keystore.(*mockSigningKeystore)
+		require.Len(t, keystore.handedOut, 1)
+		require.True(t, keystore.handedOut[0].Key.IsZero())
+	})
+}
+
+// mockSigningKeystore holds a single current key, and records the copies
+// handed out
+type mockSigningKeystore struct {
+	Keystore
+	secretKey *btcec.PrivateKey
+	handedOut []*btcec.PrivateKey
+}
+
+func (m *mockSigningKeystore) SignerKeys() ([]SignerKey, error) {
+	return []SignerKey{{PublicKey: m.secretKey.PubKey(), Current: true}}, nil
+}
+
+func (m *mockSigningKeystore) SecretKeys() ([]*btcec.PrivateKey, error) {
+	secretKey := &btcec.PrivateKey{Key: m.secretKey.Key}
+	m.handedOut = append(m.handedOut, secretKey)
+	return []*btcec.PrivateKey{secretKey}, nil
+}
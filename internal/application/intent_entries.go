@@ -0,0 +1,99 @@
+package application
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+)
+
+// ErrIntentNotSigned is returned in strict mode if an entry of the
+// introspector packet is not signed
+var ErrIntentNotSigned = errors.New("intent entries not signed")
+
+type EntryStatus string
+
+const (
+	EntryStatusSigned EntryStatus = "signed"
+	// the entry is bound to a key that is not ours
+	EntryStatusSkipped EntryStatus = "skipped"
+	// the arkade script of the entry can't be read, e.g. malformed tapscript
+	EntryStatusRejected EntryStatus = "rejected"
+)
+
+// EntryOutcome tells what happened to an entry of the introspector packet
+type EntryOutcome struct {
+	InputIndex int
+	Status     EntryStatus
+	Reason     string
+}
+
+type SignedIntent struct {
+	Proof   *psbt.Packet
+	Entries []EntryOutcome
+}
+
+// newEntryOutcome maps the error reading or signing the entry to its outcome
+func newEntryOutcome(inputIndex int, err error) EntryOutcome {
+	switch {
+	case err == nil:
+		return EntryOutcome{InputIndex: inputIndex, Status: EntryStatusSigned}
+	case errors.Is(err, arkade.ErrTweakedArkadePubKeyNotFound):
+		return EntryOutcome{InputIndex: inputIndex, Status: EntryStatusSkipped, Reason: err.Error()}
+	default:
+		return EntryOutcome{InputIndex: inputIndex, Status: EntryStatusRejected, Reason: err.Error()}
+	}
+}
+
+// getSignedEntries computes the outcome of each entry of an already signed
+// intent proof, an entry being signed if its input carries our signature
+func getSignedEntries(ptx *psbt.Packet, signers keyring) ([]EntryOutcome, error) {
+	packet, err := arkade.FindIntrospectorPacket(ptx.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse introspector packet: %w", err)
+	}
+
+	entries := make([]EntryOutcome, 0, len(packet))
+	for _, entry := range packet {
+		inputIndex := int(entry.Vin)
+		if inputIndex == 0 {
+			continue
+		}
+
+		script, _, err := signers.readArkadeScript(ptx, entry)
+		if err == nil && !hasSignature(ptx, inputIndex, schnorr.SerializePubKey(script.PubKey())) {
+			err = fmt.Errorf("input %d not signed", inputIndex)
+		}
+		entries = append(entries, newEntryOutcome(inputIndex, err))
+	}
+	return entries, nil
+}
+
+func hasSignature(ptx *psbt.Packet, inputIndex int, xOnlyPubKey []byte) bool {
+	if inputIndex >= len(ptx.Inputs) {
+		return false
+	}
+	for _, sig := range ptx.Inputs[inputIndex].TaprootScriptSpendSig {
+		if bytes.Equal(sig.XOnlyPubKey, xOnlyPubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAllSigned(entries []EntryOutcome) error {
+	reasons := make([]string, 0)
+	for _, entry := range entries {
+		if entry.Status != EntryStatusSigned {
+			reasons = append(reasons, fmt.Sprintf("vin %d %s: %s", entry.InputIndex, entry.Status, entry.Reason))
+		}
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("%w: %s", ErrIntentNotSigned, strings.Join(reasons, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentEntries(t *testing.T) {
+	t.Run("outcomes", func(t *testing.T) {
+		require.Equal(t, EntryOutcome{InputIndex: 1, Status: EntryStatusSigned}, newEntryOutcome(1, nil))
+
+		skipped := newEntryOutcome(2, arkade.ErrTweakedArkadePubKeyNotFound)
+		require.Equal(t, EntryStatusSkipped, skipped.Status)
+		require.NotEmpty(t, skipped.Reason)
+
+		rejected := newEntryOutcome(3, fmt.Errorf("malformed tapscript"))
+		require.Equal(t, EntryStatusRejected, rejected.Status)
+		require.Equal(t, "malformed tapscript", rejected.Reason)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		require.NoError(t, checkAllSigned(nil))
+		require.NoError(t, checkAllSigned([]EntryOutcome{newEntryOutcome(1, nil)}))
+
+		err := checkAllSigned([]EntryOutcome{
+			newEntryOutcome(1, nil),
+			newEntryOutcome(2, arkade.ErrTweakedArkadePubKeyNotFound),
+			newEntryOutcome(3, fmt.Errorf("malformed tapscript")),
+		})
+		require.ErrorIs(t, err, ErrIntentNotSigned)
+		require.Contains(t, err.Error(), "vin 2 skipped")
+		require.Contains(t, err.Error(), "vin 3 rejected: malformed tapscript")
+	})
+}
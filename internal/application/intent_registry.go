@@ -0,0 +1,215 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ErrIntentNotFound = errors.New("intent not found")
+	ErrIntentExpired  = errors.New("intent expired")
+	// ErrIntentMismatch is returned when a finalization doesn't match the
+	// intent we signed
+	ErrIntentMismatch = errors.New("finalization doesn't match the signed intent")
+)
+
+const (
+	// how often the expired intents are purged from the registry
+	intentPurgeInterval = 10 * time.Minute
+	// how long the intents whose message never expires are kept
+	intentRecordTTL = 24 * time.Hour
+)
+
+// IntentRecord is an intent proof we signed in SubmitIntent
+type IntentRecord struct {
+	ProofTxid string
+	// outpoints of the proof inputs, the message input excluded
+	Inputs  []string
+	Outputs []IntentOutput
+	// hex sha256 of the encoded register message
+	MessageHash string
+	ValidAt     int64
+	// expiry of the message, or intentRecordTTL after the record creation if
+	// the message never expires
+	ExpireAt int64
+	// signer pubkey of the arkd the inputs are bound to, the default one if empty
+	ArkdPubKey string
+	CreatedAt  int64
+}
+
+type IntentOutput struct {
+	Amount   int64
+	PkScript string
+	// onchain outputs are paid by the commitment tx, the others are vtxos
+	Onchain bool
+}
+
+func (r IntentRecord) IsExpired(now time.Time) bool {
+	return r.ExpireAt > 0 && now.Unix() > r.ExpireAt
+}
+
+type IntentStore interface {
+	Add(ctx context.Context, record IntentRecord) error
+	// Get returns ErrIntentNotFound if the proof txid is unknown
+	Get(ctx context.Context, proofTxid string) (*IntentRecord, error)
+	List(ctx context.Context) ([]IntentRecord, error)
+	// DeleteExpired removes the records expired before the given unix time
+	DeleteExpired(ctx context.Context, before int64) (int, error)
+	Close()
+}
+
+func newIntentRecord(ptx *psbt.Packet, message intent.RegisterMessage) (*IntentRecord, error) {
+	messageHash, err := hashRegisterMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expireAt := message.ExpireAt
+	if expireAt <= 0 {
+		expireAt = now.Add(intentRecordTTL).Unix()
+	}
+	record := &IntentRecord{
+		ProofTxid:   ptx.UnsignedTx.TxID(),
+		Inputs:      make([]string, 0, len(ptx.UnsignedTx.TxIn)),
+		Outputs:     make([]IntentOutput, 0, len(ptx.UnsignedTx.TxOut)),
+		MessageHash: messageHash,
+		ValidAt:     message.ValidAt,
+		ExpireAt:    expireAt,
+		CreatedAt:   now.Unix(),
+	}
+	for index, input := range ptx.UnsignedTx.TxIn {
+		// input 0 is the fake message input
+		if index == 0 {
+			continue
+		}
+		record.Inputs = append(record.Inputs, input.PreviousOutPoint.String())
+	}
+	for index, output := range ptx.UnsignedTx.TxOut {
+		// the introspector packet is not a receiver
+		if txscript.GetScriptClass(output.PkScript) == txscript.NullDataTy {
+			continue
+		}
+		record.Outputs = append(record.Outputs, IntentOutput{
+			Amount:   output.Value,
+			PkScript: hex.EncodeToString(output.PkScript),
+			Onchain:  slices.Contains(message.OnchainOutputIndexes, index),
+		})
+	}
+	return record, nil
+}
+
+func hashRegisterMessage(message intent.RegisterMessage) (string, error) {
+	encoded, err := message.Encode()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode register message: %w", err)
+	}
+	hash := sha256.Sum256([]byte(encoded))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// saveIntent doesn't fail the request, the signatures are already journaled
+// and the intent can be submitted again to be recorded
+func (s *service) saveIntent(
+	ctx context.Context, ptx *psbt.Packet, message intent.RegisterMessage, server *arkServer,
+) {
+	record, err := newIntentRecord(ptx, message)
+	if err == nil {
+		record.ArkdPubKey = server.id
+		err = s.intents.Add(ctx, *record)
+	}
+	if err != nil {
+		log.WithError(err).WithField("txid", ptx.UnsignedTx.TxID()).Warn("failed to record intent")
+	}
+}
+
+// getSignedIntent returns the record of the intent being finalized, which
+// must not be expired and must carry the same register message
+func (s *service) getSignedIntent(ctx context.Context, signedIntent Intent) (*IntentRecord, error) {
+	proofTxid := signedIntent.Proof.UnsignedTx.TxID()
+	record, err := s.intents.Get(ctx, proofTxid)
+	if err != nil {
+		if errors.Is(err, ErrIntentNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrIntentNotFound, proofTxid)
+		}
+		return nil, fmt.Errorf("failed to read intent: %w", err)
+	}
+	if record.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("%w: %s", ErrIntentExpired, proofTxid)
+	}
+
+	messageHash, err := hashRegisterMessage(signedIntent.Message)
+	if err != nil {
+		return nil, err
+	}
+	if messageHash != record.MessageHash {
+		return nil, fmt.Errorf("%w: register message differs", ErrIntentMismatch)
+	}
+	return record, nil
+}
+
+// verifyOnchainOutputs checks that the commitment tx pays every onchain
+// output of the intent
+func verifyOnchainOutputs(record *IntentRecord, commitmentTx *psbt.Packet) error {
+	used := make(map[int]bool)
+	for _, output := range record.Outputs {
+		if !output.Onchain {
+			continue
+		}
+		pkScript, err := hex.DecodeString(output.PkScript)
+		if err != nil {
+			return fmt.Errorf("invalid recorded output script: %w", err)
+		}
+
+		found := false
+		for index, txOut := range commitmentTx.UnsignedTx.TxOut {
+			if used[index] || txOut.Value != output.Amount || !bytes.Equal(txOut.PkScript, pkScript) {
+				continue
+			}
+			used[index] = true
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf(
+				"%w: onchain output %s of %d sats not found in commitment tx",
+				ErrIntentMismatch, output.PkScript, output.Amount,
+			)
+		}
+	}
+	return nil
+}
+
+// purgeExpiredIntents periodically removes the expired intents from the
+// registry until the service is closed
+func (s *service) purgeExpiredIntents() {
+	ticker := time.NewTicker(intentPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.intents.DeleteExpired(s.ctx, time.Now().Unix())
+			if err != nil {
+				log.WithError(err).Warn("failed to purge expired intents")
+				continue
+			}
+			if count > 0 {
+				log.Debugf("purged %d expired intent(s)", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentRegistry(t *testing.T) {
+	ctx := context.Background()
+	onchainScript := []byte{txscript.OP_1, txscript.OP_DATA_1, 0x01}
+	vtxoScript := []byte{txscript.OP_1, txscript.OP_DATA_1, 0x02}
+
+	newProof := func(t *testing.T) *psbt.Packet {
+		t.Helper()
+		ptx, err := psbt.New(
+			[]*wire.OutPoint{
+				{Hash: chainhash.Hash{1}, Index: 0},
+				{Hash: chainhash.Hash{2}, Index: 1},
+			},
+			[]*wire.TxOut{
+				{Value: 1_000, PkScript: onchainScript},
+				{Value: 2_000, PkScript: vtxoScript},
+				{Value: 0, PkScript: []byte{txscript.OP_RETURN, txscript.OP_DATA_1, 0x00}},
+			},
+			2, 0, []uint32{wire.MaxTxInSequenceNum, wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+		return ptx
+	}
+	newMessage := func(expireAt int64) intent.RegisterMessage {
+		return intent.RegisterMessage{
+			BaseMessage:          intent.BaseMessage{Type: intent.IntentMessageTypeRegister},
+			OnchainOutputIndexes: []int{0},
+			ExpireAt:             expireAt,
+		}
+	}
+	newCommitmentTx := func(t *testing.T, outputs ...*wire.TxOut) *psbt.Packet {
+		t.Helper()
+		ptx, err := psbt.New(
+			[]*wire.OutPoint{{Hash: chainhash.Hash{3}, Index: 0}},
+			outputs, 3, 0, []uint32{wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+		return ptx
+	}
+
+	t.Run("record", func(t *testing.T) {
+		ptx := newProof(t)
+		record, err := newIntentRecord(ptx, newMessage(0))
+		require.NoError(t, err)
+
+		require.Equal(t, ptx.UnsignedTx.TxID(), record.ProofTxid)
+		require.Equal(t, []string{ptx.UnsignedTx.TxIn[1].PreviousOutPoint.String()}, record.Inputs)
+		require.Equal(t, []IntentOutput{
+			{Amount: 1_000, PkScript: "510101", Onchain: true},
+			{Amount: 2_000, PkScript: "510102"},
+		}, record.Outputs)
+
+		// a message that never expires gets the default ttl so that it's purged
+		require.Equal(t, record.CreatedAt+int64(intentRecordTTL.Seconds()), record.ExpireAt)
+		expireAt := time.Now().Add(time.Hour).Unix()
+		record, err = newIntentRecord(ptx, newMessage(expireAt))
+		require.NoError(t, err)
+		require.Equal(t, expireAt, record.ExpireAt)
+	})
+
+	t.Run("proof message", func(t *testing.T) {
+		message := newMessage(0)
+		encoded, err := message.Encode()
+		require.NoError(t, err)
+		input := intent.Input{
+			OutPoint:    &wire.OutPoint{Hash: chainhash.Hash{2}, Index: 1},
+			WitnessUtxo: &wire.TxOut{Value: 2_000, PkScript: vtxoScript},
+		}
+		proof, err := intent.New(encoded, []intent.Input{input}, nil)
+		require.NoError(t, err)
+		require.NoError(t, validateProofMessage(&proof.Packet, encoded))
+
+		// the recorded message must be the one the proof commits to
+		svc := &service{}
+		_, err = svc.SubmitIntent(ctx, Intent{Proof: *proof, Message: newMessage(1)}, false)
+		require.ErrorIs(t, err, ErrInvalidIntent)
+	})
+
+	t.Run("signed intent", func(t *testing.T) {
+		ptx := newProof(t)
+		svc := &service{intents: newMockIntentStore()}
+
+		_, err := svc.getSignedIntent(ctx, Intent{Proof: intent.Proof{Packet: *ptx}, Message: newMessage(0)})
+		require.ErrorIs(t, err, ErrIntentNotFound)
+
+		svc.saveIntent(ctx, ptx, newMessage(0), &arkServer{id: "arkd"})
+		record, err := svc.getSignedIntent(ctx, Intent{Proof: intent.Proof{Packet: *ptx}, Message: newMessage(0)})
+		require.NoError(t, err)
+		require.Equal(t, ptx.UnsignedTx.TxID(), record.ProofTxid)
+		require.Equal(t, "arkd", record.ArkdPubKey)
+
+		// same proof, different register message
+		_, err = svc.getSignedIntent(ctx, Intent{Proof: intent.Proof{Packet: *ptx}, Message: newMessage(1)})
+		require.ErrorIs(t, err, ErrIntentMismatch)
+	})
+
+	t.Run("expired intent", func(t *testing.T) {
+		ptx := newProof(t)
+		svc := &service{intents: newMockIntentStore()}
+		message := newMessage(time.Now().Add(-time.Minute).Unix())
+
+		svc.saveIntent(ctx, ptx, message, &arkServer{})
+		_, err := svc.getSignedIntent(ctx, Intent{Proof: intent.Proof{Packet: *ptx}, Message: message})
+		require.ErrorIs(t, err, ErrIntentExpired)
+	})
+
+	t.Run("onchain outputs", func(t *testing.T) {
+		record, err := newIntentRecord(newProof(t), newMessage(0))
+		require.NoError(t, err)
+
+		commitmentTx := newCommitmentTx(t,
+			&wire.TxOut{Value: 50_000, PkScript: vtxoScript},
+			&wire.TxOut{Value: 1_000, PkScript: onchainScript},
+		)
+		require.NoError(t, verifyOnchainOutputs(record, commitmentTx))
+
+		// the vtxo outputs are not paid by the commitment tx
+		commitmentTx = newCommitmentTx(t, &wire.TxOut{Value: 2_000, PkScript: vtxoScript})
+		require.ErrorIs(t, verifyOnchainOutputs(record, commitmentTx), ErrIntentMismatch)
+
+		commitmentTx = newCommitmentTx(t, &wire.TxOut{Value: 999, PkScript: onchainScript})
+		require.ErrorIs(t, verifyOnchainOutputs(record, commitmentTx), ErrIntentMismatch)
+	})
+}
+
+type mockIntentStore struct {
+	records map[string]IntentRecord
+}
+
+func newMockIntentStore() *mockIntentStore {
+	return &mockIntentStore{records: make(map[string]IntentRecord)}
+}
+
+func (m *mockIntentStore) Add(_ context.Context, record IntentRecord) error {
+	m.records[record.ProofTxid] = record
+	return nil
+}
+
+func (m *mockIntentStore) Get(_ context.Context, proofTxid string) (*IntentRecord, error) {
+	record, ok := m.records[proofTxid]
+	if !ok {
+		return nil, ErrIntentNotFound
+	}
+	return &record, nil
+}
+
+func (m *mockIntentStore) List(_ context.Context) ([]IntentRecord, error) {
+	records := make([]IntentRecord, 0, len(m.records))
+	for _, record := range m.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (m *mockIntentStore) DeleteExpired(_ context.Context, before int64) (int, error) {
+	count := 0
+	for txid, record := range m.records {
+		if record.ExpireAt > 0 && record.ExpireAt < before {
+			delete(m.records, txid)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockIntentStore) Close() {}
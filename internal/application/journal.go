@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var ErrEquivocation = errors.New("outpoint already signed for a different transaction")
+
+type SignatureKind string
+
+const (
+	SignatureKindArkTx        SignatureKind = "ark"
+	SignatureKindCheckpointTx SignatureKind = "checkpoint"
+	SignatureKindIntentProof  SignatureKind = "intent"
+	SignatureKindForfeitTx    SignatureKind = "forfeit"
+	SignatureKindCommitmentTx SignatureKind = "commitment"
+	SignatureKindOnchainTx    SignatureKind = "onchain"
+	// a proof deleting a registered intent
+	SignatureKindIntentDeletion SignatureKind = "intent-deletion"
+)
+
+// Signature is a journal entry for an input signed by the introspector
+type Signature struct {
+	// Outpoint is the prevout spent by the signed input
+	Outpoint   wire.OutPoint
+	Txid       string
+	InputIndex int
+	Kind       SignatureKind
+	// hex-encoded hash of the executed arkade script
+	ScriptHash string
+	SigHash    txscript.SigHashType
+	// Failed is set once the transaction is known to be rejected
+	Failed    bool
+	CreatedAt int64
+}
+
+// conflictsWith returns true if both signatures spend the same outpoint in
+// different transactions that could both end up valid.
+// Intent proofs and their deletions are not spends, and a VTXO may be
+// forfeited in several batches if the previous ones failed, so neither counts
+// as a conflict.
+func (s Signature) conflictsWith(other Signature) bool {
+	if s.Outpoint != other.Outpoint || s.Txid == other.Txid {
+		return false
+	}
+	if s.Failed || other.Failed {
+		return false
+	}
+	if !s.isSpend() || !other.isSpend() {
+		return false
+	}
+	return s.Kind != SignatureKindForfeitTx || other.Kind != SignatureKindForfeitTx
+}
+
+func (s Signature) isSpend() bool {
+	return s.Kind != SignatureKindIntentProof && s.Kind != SignatureKindIntentDeletion
+}
+
+// SigningJournal durably records every signature produced by the service.
+type SigningJournal interface {
+	// Add stores the signatures, overwriting the ones already recorded for
+	// the same txid and input index.
+	Add(ctx context.Context, signatures ...Signature) error
+	GetByOutpoint(ctx context.Context, outpoint wire.OutPoint) ([]Signature, error)
+	// ListRecent returns at most limit signatures, the most recent first
+	ListRecent(ctx context.Context, limit int) ([]Signature, error)
+	// MarkFailed flags all the signatures of the given transactions as failed
+	MarkFailed(ctx context.Context, txids ...string) error
+	Close()
+}
+
+func newSignature(kind SignatureKind, ptx *psbt.Packet, inputIndex int, scriptHash []byte) Signature {
+	return Signature{
+		Outpoint:   ptx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint,
+		Txid:       ptx.UnsignedTx.TxID(),
+		InputIndex: inputIndex,
+		Kind:       kind,
+		ScriptHash: hex.EncodeToString(scriptHash),
+		SigHash:    ptx.Inputs[inputIndex].SighashType,
+		CreatedAt:  time.Now().Unix(),
+	}
+}
+
+// journalSignatures records the signatures before they leave the service.
+// With equivocation protection enabled, it fails without recording anything
+// if any of the outpoints was already signed in a conflicting transaction.
+func (s *service) journalSignatures(ctx context.Context, signatures []Signature) error {
+	s.journalLock.Lock()
+	defer s.journalLock.Unlock()
+
+	if s.equivocationProtection {
+		for _, signature := range signatures {
+			signed, err := s.journal.GetByOutpoint(ctx, signature.Outpoint)
+			if err != nil {
+				return fmt.Errorf("failed to read signing journal: %w", err)
+			}
+			for _, previous := range signed {
+				if previous.conflictsWith(signature) {
+					return fmt.Errorf(
+						"%w: %s signed in %s tx %s",
+						ErrEquivocation, signature.Outpoint, previous.Kind, previous.Txid,
+					)
+				}
+			}
+		}
+	}
+
+	if err := s.journal.Add(ctx, signatures...); err != nil {
+		return fmt.Errorf("failed to write signing journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalSignatures(t *testing.T) {
+	ctx := context.Background()
+	outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+
+	newSig := func(kind SignatureKind, txid string) Signature {
+		return Signature{Outpoint: outpoint, Txid: txid, Kind: kind}
+	}
+
+	t.Run("protection disabled", func(t *testing.T) {
+		svc := &service{journal: newMockJournal()}
+		require.NoError(t, svc.journalSignatures(ctx, []Signature{newSig(SignatureKindCheckpointTx, "tx1")}))
+		require.NoError(t, svc.journalSignatures(ctx, []Signature{newSig(SignatureKindCheckpointTx, "tx2")}))
+	})
+
+	testCases := []struct {
+		name     string
+		previous Signature
+		next     Signature
+		failed   bool
+		conflict bool
+	}{
+		{
+			name:     "same tx",
+			previous: newSig(SignatureKindCheckpointTx, "tx1"),
+			next:     newSig(SignatureKindCheckpointTx, "tx1"),
+		},
+		{
+			name:     "different tx",
+			previous: newSig(SignatureKindCheckpointTx, "tx1"),
+			next:     newSig(SignatureKindCheckpointTx, "tx2"),
+			conflict: true,
+		},
+		{
+			name:     "previous tx failed",
+			previous: newSig(SignatureKindCheckpointTx, "tx1"),
+			next:     newSig(SignatureKindCheckpointTx, "tx2"),
+			failed:   true,
+		},
+		{
+			name:     "forfeit after offchain spend",
+			previous: newSig(SignatureKindCheckpointTx, "tx1"),
+			next:     newSig(SignatureKindForfeitTx, "tx2"),
+			conflict: true,
+		},
+		{
+			name:     "forfeits in different batches",
+			previous: newSig(SignatureKindForfeitTx, "tx1"),
+			next:     newSig(SignatureKindForfeitTx, "tx2"),
+		},
+		{
+			name:     "intent proof",
+			previous: newSig(SignatureKindIntentProof, "tx1"),
+			next:     newSig(SignatureKindOnchainTx, "tx2"),
+		},
+		{
+			name:     "intent deletion",
+			previous: newSig(SignatureKindCheckpointTx, "tx1"),
+			next:     newSig(SignatureKindIntentDeletion, "tx2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			journal := newMockJournal()
+			svc := &service{journal: journal, equivocationProtection: true}
+
+			require.NoError(t, svc.journalSignatures(ctx, []Signature{tc.previous}))
+			if tc.failed {
+				require.NoError(t, journal.MarkFailed(ctx, tc.previous.Txid))
+			}
+
+			err := svc.journalSignatures(ctx, []Signature{tc.next})
+			if tc.conflict {
+				require.ErrorIs(t, err, ErrEquivocation)
+				signed, err := journal.GetByOutpoint(ctx, outpoint)
+				require.NoError(t, err)
+				require.Len(t, signed, 1)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+type mockJournal struct {
+	lock       sync.Mutex
+	signatures map[string]Signature
+}
+
+func newMockJournal() *mockJournal {
+	return &mockJournal{signatures: make(map[string]Signature)}
+}
+
+func (m *mockJournal) Add(_ context.Context, signatures ...Signature) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, sig := range signatures {
+		m.signatures[sig.Txid+sig.Outpoint.String()] = sig
+	}
+	return nil
+}
+
+func (m *mockJournal) GetByOutpoint(_ context.Context, outpoint wire.OutPoint) ([]Signature, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var result []Signature
+	for _, sig := range m.signatures {
+		if sig.Outpoint == outpoint {
+			result = append(result, sig)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockJournal) ListRecent(_ context.Context, limit int) ([]Signature, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	result := make([]Signature, 0, len(m.signatures))
+	for _, sig := range m.signatures {
+		result = append(result, sig)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt > result[j].CreatedAt })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockJournal) MarkFailed(_ context.Context, txids ...string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for key, sig := range m.signatures {
+		for _, txid := range txids {
+			if sig.Txid == txid {
+				sig.Failed = true
+				m.signatures[key] = sig
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockJournal) Close() {}
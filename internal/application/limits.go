@@ -0,0 +1,50 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+)
+
+var ErrLimitExceeded = errors.New("request limit exceeded")
+
+// RequestLimits bounds the work a single request can ask for, 0 means
+// unlimited
+type RequestLimits struct {
+	MaxCheckpoints int
+	MaxForfeits    int
+	// MaxTreeNodes applies to the connector and the vtxo trees separately
+	MaxTreeNodes int
+	// MaxScriptWork is the summed size in bytes of the scripts and the
+	// witnesses of the introspector packet of a request
+	MaxScriptWork int
+	// MaxRegisteredScripts caps the scripts of the registry, the registrations
+	// are refused once it's full
+	MaxRegisteredScripts int
+}
+
+var DefaultRequestLimits = RequestLimits{
+	MaxCheckpoints:       256,
+	MaxForfeits:          256,
+	MaxTreeNodes:         8_192,
+	MaxScriptWork:        1_000_000,
+	MaxRegisteredScripts: 10_000,
+}
+
+// Check returns ErrLimitExceeded if count is above max, unless max is 0
+func (l RequestLimits) Check(name string, count, max int) error {
+	if max > 0 && count > max {
+		return fmt.Errorf("%w: %d %s, the maximum is %d", ErrLimitExceeded, count, name, max)
+	}
+	return nil
+}
+
+// checkScriptWork is run before executing any script of the packet
+func (l RequestLimits) checkScriptWork(packet arkade.IntrospectorPacket) error {
+	work := 0
+	for _, entry := range packet {
+		work += len(entry.Script) + entry.Witness.SerializeSize()
+	}
+	return l.Check("bytes of script work", work, l.MaxScriptWork)
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLimits(t *testing.T) {
+	limits := RequestLimits{MaxCheckpoints: 2}
+	require.NoError(t, limits.Check("checkpoint txs", 2, limits.MaxCheckpoints))
+	require.ErrorIs(t, limits.Check("checkpoint txs", 3, limits.MaxCheckpoints), ErrLimitExceeded)
+	// 0 is unlimited
+	require.NoError(t, limits.Check("forfeit txs", 1_000, limits.MaxForfeits))
+
+	packet := arkade.IntrospectorPacket{
+		{Vin: 0, Script: []byte{txscript.OP_TRUE}, Witness: wire.TxWitness{make([]byte, 10)}},
+		{Vin: 1, Script: []byte{txscript.OP_TRUE}, Witness: wire.TxWitness{make([]byte, 10)}},
+	}
+	// 1 byte of script and 12 bytes of serialized witness per entry
+	svc := &service{
+		limits:  RequestLimits{MaxScriptWork: 26},
+		guard:   NewSigningGuard(),
+		metrics: NoopMetrics,
+	}
+	require.NoError(t, svc.limits.checkScriptWork(packet))
+
+	svc.limits.MaxScriptWork = 25
+	require.ErrorIs(t, svc.checkPacket(packet), ErrLimitExceeded)
+}
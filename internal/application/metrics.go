@@ -0,0 +1,28 @@
+package application
+
+import "time"
+
+// Metrics records the measures of the signing paths, the rpc and arkd calls
+// are measured by the interface and the arkd client wrapper.
+type Metrics interface {
+	// ObserveScript records an arkade script execution with the names of
+	// the opcodes it stepped through
+	ObserveScript(duration time.Duration, opcodes []string, err error)
+	// ObservePacketEntries records the number of entries of an introspector packet
+	ObservePacketEntries(entries int)
+	// ObserveFinalizerRole records whether the introspector was the last
+	// signer of the checkpoints of a submitted tx
+	ObserveFinalizerRole(isFinalizer bool)
+	// ObserveFinalizeAttempt records an attempt to finalize a tx with arkd
+	ObserveFinalizeAttempt(err error)
+}
+
+// NoopMetrics discards all the measures
+var NoopMetrics Metrics = noopMetrics{}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveScript(time.Duration, []string, error) {}
+func (noopMetrics) ObservePacketEntries(int)                     {}
+func (noopMetrics) ObserveFinalizerRole(bool)                    {}
+func (noopMetrics) ObserveFinalizeAttempt(error)                 {}
@@ -16,33 +16,45 @@ import (
 // SubmitOnchainTx executes arkade scripts on a plain Bitcoin PSBT and signs
 // every input whose tapscript closure contains the introspector's tweaked
 // key.
-// Rejects any input whose tapscript closure also contains the arkd signer
-// pubkey: those inputs must go through SubmitTx so that the offchain
+// Rejects any input whose tapscript closure also contains a known signer
+// pubkey of a served arkd, or all of them while an arkd with no known key is
+// unreachable: those inputs must go through SubmitTx so that the offchain
 // checks (checkpoints, forfeit flow) are enforced. Accepting them here
 // would be a path to bypass those checks.
 func (s *service) SubmitOnchainTx(ctx context.Context, tx OnchainTx) (*psbt.Packet, error) {
+	signers, err := s.getKeyring()
+	if err != nil {
+		return nil, err
+	}
+	defer signers.zero()
+
 	ptx := tx.Tx
 
+	_, span := tracer.Start(ctx, "BuildPrevOutFetcher")
 	prevOutFetcher, err := prevOutFetcherForOnchainTx(ptx)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create prevout fetcher: %w", err)
 	}
 
-	packet, err := arkade.FindIntrospectorPacket(ptx.UnsignedTx)
+	packet, err := s.readIntrospectorPacket(ctx, ptx.UnsignedTx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse introspector packet: %w", err)
 	}
 	if len(packet) == 0 {
 		return nil, fmt.Errorf("no introspector packet found in transaction")
 	}
+	if err := s.checkPacket(packet); err != nil {
+		return nil, err
+	}
+	reservation := s.reserveSpending(ptx.UnsignedTx, prevOutFetcher)
+	defer reservation.release()
 
-	signerPublicKey := s.signer.secretKey.PubKey()
-	nSigned := 0
-
+	signatures := make([]Signature, 0, len(packet))
 	for _, entry := range packet {
 		inputIndex := int(entry.Vin)
 
-		script, err := arkade.ReadArkadeScript(ptx, signerPublicKey, entry)
+		script, signer, err := signers.readArkadeScript(ptx, entry)
 		if err != nil {
 			if errors.Is(err, arkade.ErrTweakedArkadePubKeyNotFound) && len(ptx.Inputs) > 1 {
 				continue
@@ -50,30 +62,36 @@ func (s *service) SubmitOnchainTx(ctx context.Context, tx OnchainTx) (*psbt.Pack
 			return nil, fmt.Errorf("failed to read arkade script: %w vin=%d", err, inputIndex)
 		}
 
-		if containsPubKey(script.ClosurePubKeys(), s.arkdPubKey) {
-			return nil, fmt.Errorf(
-				"tapscript on input #%d contains arkd signer pubkey: can't be used onchain",
-				inputIndex,
-			)
+		if err := s.arkServers.checkUnbound(script.ClosurePubKeys()); err != nil {
+			return nil, fmt.Errorf("tapscript on input #%d can't be used onchain: %w", inputIndex, err)
 		}
 
 		log.Debugf("executing arkade script: %x", script.Script())
-		if err := script.Execute(ptx.UnsignedTx, prevOutFetcher, inputIndex); err != nil {
+		if err := s.executeScript(
+			ctx, RequestTypeOnchainTx, script, ptx.UnsignedTx, prevOutFetcher, inputIndex,
+		); err != nil {
 			return nil, fmt.Errorf("failed to execute arkade script: %w vin=%d", err, inputIndex)
 		}
 		log.Debugf("execution of %x succeeded", script.Script())
 
-		if err := s.signer.signInput(ptx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
+		if err := reservation.add(inputIndex); err != nil {
+			return nil, fmt.Errorf("%w vin=%d", err, inputIndex)
+		}
+		if err := signer.signInput(ctx, ptx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
 			return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
 		}
-
-		nSigned++
+		signatures = append(signatures, newSignature(SignatureKindOnchainTx, ptx, inputIndex, script.Hash()))
 	}
 
-	if nSigned == 0 {
+	if len(signatures) == 0 {
 		return nil, fmt.Errorf("failed to find any valid input/entry pairs")
 	}
 
+	if err := s.journalSignatures(ctx, signatures); err != nil {
+		return nil, err
+	}
+	reservation.commit(ctx)
+
 	return ptx, nil
 }
 
@@ -0,0 +1,647 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/arkade-os/arkd/pkg/ark-lib/asset"
+	"github.com/arkade-os/arkd/pkg/ark-lib/extension"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrSigningPaused   = errors.New("signing is paused")
+	ErrPolicyViolation = errors.New("signing policy violation")
+	ErrInvalidPolicy   = errors.New("invalid signing policy")
+	ErrNoPolicyFile    = errors.New("no policy file configured")
+)
+
+// RequestType is the kind of signing request an arkade script is executed for
+type RequestType string
+
+const (
+	RequestTypeTx             RequestType = "submit_tx"
+	RequestTypeOnchainTx      RequestType = "submit_onchain_tx"
+	RequestTypeIntent         RequestType = "submit_intent"
+	RequestTypeIntentDeletion RequestType = "submit_intent_deletion"
+)
+
+func (r RequestType) validate() error {
+	switch r {
+	case RequestTypeTx, RequestTypeOnchainTx, RequestTypeIntent, RequestTypeIntentDeletion:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown request type %s", ErrInvalidPolicy, r)
+}
+
+// Policy restricts what the introspector signs, operators can replace it at
+// runtime through the admin endpoints or by reloading the policy file
+type Policy struct {
+	// AllowedScriptHashes are the hex hashes of the arkade scripts that can
+	// be signed
+	AllowedScriptHashes []string
+	// AllowedTemplates are the hex hashes of the script templates that can be
+	// signed, see ScriptTemplateHash. Any script is allowed if both
+	// AllowedScriptHashes and AllowedTemplates are empty
+	AllowedTemplates []string
+	// DeniedOpcodes are the names of the opcodes a script must not contain,
+	// wherever they are in the script
+	DeniedOpcodes []string
+	// ScriptRequestTypes restricts the request types a script hash can be
+	// used with, scripts not listed can be used with any request type
+	ScriptRequestTypes map[string][]RequestType
+	// MaxPacketEntries caps the entries of an introspector packet, 0 for unlimited
+	MaxPacketEntries int
+	// MaxTxAmount caps the sats spent by the inputs of a request, 0 for unlimited
+	MaxTxAmount int64
+	// MaxTxAssetAmount caps the amount of every asset spent by the inputs of
+	// a request, 0 for unlimited
+	MaxTxAssetAmount uint64
+	// Window is the rolling period of MaxWindowAmount and MaxWindowAssetAmount
+	Window time.Duration
+	// MaxWindowAmount caps the sats spent by the requests of the last Window,
+	// 0 for unlimited
+	MaxWindowAmount int64
+	// MaxWindowAssetAmount caps the amount of every asset spent by the
+	// requests of the last Window, 0 for unlimited
+	MaxWindowAssetAmount uint64
+}
+
+func (p Policy) validate() error {
+	for _, scriptHash := range slices.Concat(
+		p.AllowedScriptHashes, p.AllowedTemplates, slices.Collect(maps.Keys(p.ScriptRequestTypes)),
+	) {
+		if err := validateHash(scriptHash); err != nil {
+			return err
+		}
+	}
+	for _, name := range p.DeniedOpcodes {
+		if _, ok := arkade.OpcodeByName[strings.ToUpper(name)]; !ok {
+			return fmt.Errorf("%w: unknown opcode %s", ErrInvalidPolicy, name)
+		}
+	}
+	for _, requestTypes := range p.ScriptRequestTypes {
+		for _, requestType := range requestTypes {
+			if err := requestType.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	if p.MaxPacketEntries < 0 {
+		return fmt.Errorf("%w: max packet entries must not be negative", ErrInvalidPolicy)
+	}
+	if p.MaxTxAmount < 0 || p.MaxWindowAmount < 0 {
+		return fmt.Errorf("%w: max amounts must not be negative", ErrInvalidPolicy)
+	}
+	if p.Window < 0 {
+		return fmt.Errorf("%w: window must not be negative", ErrInvalidPolicy)
+	}
+	if (p.MaxWindowAmount > 0 || p.MaxWindowAssetAmount > 0) && p.Window == 0 {
+		return fmt.Errorf("%w: window amounts require a window", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+func (p Policy) clone() Policy {
+	clone := p
+	clone.AllowedScriptHashes = slices.Clone(p.AllowedScriptHashes)
+	clone.AllowedTemplates = slices.Clone(p.AllowedTemplates)
+	clone.DeniedOpcodes = slices.Clone(p.DeniedOpcodes)
+	clone.ScriptRequestTypes = nil
+	if p.ScriptRequestTypes != nil {
+		clone.ScriptRequestTypes = make(map[string][]RequestType, len(p.ScriptRequestTypes))
+		for scriptHash, requestTypes := range p.ScriptRequestTypes {
+			clone.ScriptRequestTypes[scriptHash] = slices.Clone(requestTypes)
+		}
+	}
+	return clone
+}
+
+func validateHash(value string) error {
+	decoded, err := hex.DecodeString(value)
+	if err != nil || len(decoded) != 32 {
+		return fmt.Errorf("%w: invalid hash %s", ErrInvalidPolicy, value)
+	}
+	return nil
+}
+
+// ScriptTemplateHash returns the hash of the template of an arkade script:
+// its opcodes with every data push replaced by OP_PUSHDATA4, so that the
+// instances of a contract differing only by their keys, hashes or amounts
+// share the same template
+func ScriptTemplateHash(script []byte) ([]byte, error) {
+	template := make([]byte, 0, len(script))
+	tokenizer := arkade.MakeScriptTokenizer(0, script)
+	for tokenizer.Next() {
+		opcode := tokenizer.Opcode()
+		if opcode >= txscript.OP_DATA_1 && opcode <= txscript.OP_PUSHDATA4 {
+			opcode = txscript.OP_PUSHDATA4
+		}
+		template = append(template, opcode)
+	}
+	if err := tokenizer.Err(); err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(template)
+	return hash[:], nil
+}
+
+// spending is the amounts spent by a signing request
+type spending struct {
+	at     time.Time
+	amount int64
+	assets map[string]uint64
+}
+
+// Spending is a signed request counted in the window of the signing policy
+type Spending struct {
+	Amount    int64
+	Assets    map[string]uint64
+	CreatedAt int64
+}
+
+// SpendingStore persists the requests counted in the policy window, so that
+// a restart doesn't reset the window caps
+type SpendingStore interface {
+	Add(ctx context.Context, spending Spending) error
+	List(ctx context.Context) ([]Spending, error)
+	// DeleteBefore removes the spendings created before the given unix time
+	DeleteBefore(ctx context.Context, before int64) error
+	Close()
+}
+
+// SigningGuard holds the signing state shared by the service and the admin
+// endpoints: whether signing is paused, the current policy and the amounts
+// spent during the policy window
+type SigningGuard struct {
+	lock   sync.RWMutex
+	paused bool
+	policy Policy
+	// path of the policy file, reloaded by ReloadPolicy
+	policyFile string
+
+	// lowercase hashes of the policy
+	allowedScripts     map[string]struct{}
+	allowedTemplates   map[string]struct{}
+	deniedOpcodes      map[byte]string
+	scriptRequestTypes map[string][]RequestType
+
+	spendingLock sync.Mutex
+	spendings    []*spending
+	// store of the committed spendings, nil to keep them in memory only
+	spendingStore SpendingStore
+}
+
+// NewSigningGuard returns a guard with signing resumed and an empty policy
+func NewSigningGuard() *SigningGuard {
+	return &SigningGuard{}
+}
+
+// Pause makes every signing path fail with ErrSigningPaused until Resume
+func (g *SigningGuard) Pause() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.paused = true
+}
+
+func (g *SigningGuard) Resume() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.paused = false
+}
+
+func (g *SigningGuard) IsPaused() bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.paused
+}
+
+func (g *SigningGuard) Policy() Policy {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.policy.clone()
+}
+
+// SetPolicy replaces the policy, the requests being signed are checked
+// against the policy in place when their scripts are executed
+func (g *SigningGuard) SetPolicy(policy Policy) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+
+	allowedScripts := lowercaseSet(policy.AllowedScriptHashes)
+	allowedTemplates := lowercaseSet(policy.AllowedTemplates)
+	deniedOpcodes := make(map[byte]string, len(policy.DeniedOpcodes))
+	for _, name := range policy.DeniedOpcodes {
+		name = strings.ToUpper(name)
+		deniedOpcodes[arkade.OpcodeByName[name]] = name
+	}
+	scriptRequestTypes := make(map[string][]RequestType, len(policy.ScriptRequestTypes))
+	for scriptHash, requestTypes := range policy.ScriptRequestTypes {
+		scriptRequestTypes[strings.ToLower(scriptHash)] = slices.Clone(requestTypes)
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.policy = policy.clone()
+	g.allowedScripts = allowedScripts
+	g.allowedTemplates = allowedTemplates
+	g.deniedOpcodes = deniedOpcodes
+	g.scriptRequestTypes = scriptRequestTypes
+	return nil
+}
+
+// LoadPolicyFile applies the policy of the given file and remembers its path
+// for ReloadPolicy
+func (g *SigningGuard) LoadPolicyFile(path string) error {
+	policy, err := ReadPolicyFile(path)
+	if err != nil {
+		return err
+	}
+	if err := g.SetPolicy(policy); err != nil {
+		return err
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.policyFile = path
+	return nil
+}
+
+// LoadSpendings counts the spendings persisted by the previous runs in the
+// window and persists the new ones in the store
+func (g *SigningGuard) LoadSpendings(ctx context.Context, store SpendingStore) error {
+	persisted, err := store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load spendings: %w", err)
+	}
+
+	g.spendingLock.Lock()
+	defer g.spendingLock.Unlock()
+	for _, s := range persisted {
+		g.spendings = append(g.spendings, &spending{
+			at:     time.Unix(s.CreatedAt, 0),
+			amount: s.Amount,
+			assets: s.Assets,
+		})
+	}
+	g.spendingStore = store
+	return nil
+}
+
+func (g *SigningGuard) close() {
+	if g.spendingStore != nil {
+		g.spendingStore.Close()
+	}
+}
+
+// ReloadPolicy re-reads the policy file, the policy in place is kept if the
+// file is invalid
+func (g *SigningGuard) ReloadPolicy() (*Policy, error) {
+	g.lock.RLock()
+	path := g.policyFile
+	g.lock.RUnlock()
+
+	if path == "" {
+		return nil, ErrNoPolicyFile
+	}
+	if err := g.LoadPolicyFile(path); err != nil {
+		return nil, err
+	}
+	policy := g.Policy()
+	return &policy, nil
+}
+
+// checkScript checks an arkade script against the allow lists, the denied
+// opcodes and the request types allowed for it
+func (g *SigningGuard) checkScript(requestType RequestType, script, hash []byte) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	scriptHash := hex.EncodeToString(hash)
+
+	if len(g.allowedScripts) > 0 || len(g.allowedTemplates) > 0 {
+		_, allowed := g.allowedScripts[scriptHash]
+		if !allowed && len(g.allowedTemplates) > 0 {
+			templateHash, err := ScriptTemplateHash(script)
+			if err != nil {
+				return fmt.Errorf("%w: failed to parse script %s: %s", ErrPolicyViolation, scriptHash, err)
+			}
+			_, allowed = g.allowedTemplates[hex.EncodeToString(templateHash)]
+		}
+		if !allowed {
+			return fmt.Errorf("%w: script %s is not allowed", ErrPolicyViolation, scriptHash)
+		}
+	}
+
+	if requestTypes, ok := g.scriptRequestTypes[scriptHash]; ok && !slices.Contains(requestTypes, requestType) {
+		return fmt.Errorf(
+			"%w: script %s can't be used with %s", ErrPolicyViolation, scriptHash, requestType,
+		)
+	}
+
+	if len(g.deniedOpcodes) > 0 {
+		tokenizer := arkade.MakeScriptTokenizer(0, script)
+		for tokenizer.Next() {
+			if name, denied := g.deniedOpcodes[tokenizer.Opcode()]; denied {
+				return fmt.Errorf(
+					"%w: script %s contains denied opcode %s", ErrPolicyViolation, scriptHash, name,
+				)
+			}
+		}
+		if err := tokenizer.Err(); err != nil {
+			return fmt.Errorf("%w: failed to parse script %s: %s", ErrPolicyViolation, scriptHash, err)
+		}
+	}
+	return nil
+}
+
+func (g *SigningGuard) checkPacketEntries(entries int) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if g.policy.MaxPacketEntries > 0 && entries > g.policy.MaxPacketEntries {
+		return fmt.Errorf(
+			"%w: %d packet entries exceed the maximum of %d",
+			ErrPolicyViolation, entries, g.policy.MaxPacketEntries,
+		)
+	}
+	return nil
+}
+
+// reserve grows the amounts of a pending request by the given ones and
+// checks them against the per tx and per window caps. The request is counted
+// in the window until it's released, an addition rejected here is not
+func (g *SigningGuard) reserve(reserved *spending, amount int64, assets map[string]uint64) error {
+	policy := g.Policy()
+
+	g.spendingLock.Lock()
+	defer g.spendingLock.Unlock()
+
+	txAmount := reserved.amount + amount
+	txAssets := make(map[string]uint64, len(reserved.assets)+len(assets))
+	maps.Copy(txAssets, reserved.assets)
+	for assetId, assetAmount := range assets {
+		txAssets[assetId] += assetAmount
+	}
+
+	if policy.MaxTxAmount > 0 && txAmount > policy.MaxTxAmount {
+		return fmt.Errorf(
+			"%w: %d sats exceed the maximum of %d per tx", ErrPolicyViolation, txAmount, policy.MaxTxAmount,
+		)
+	}
+	if policy.MaxTxAssetAmount > 0 {
+		for assetId, assetAmount := range txAssets {
+			if assetAmount > policy.MaxTxAssetAmount {
+				return fmt.Errorf(
+					"%w: %d of asset %s exceed the maximum of %d per tx",
+					ErrPolicyViolation, assetAmount, assetId, policy.MaxTxAssetAmount,
+				)
+			}
+		}
+	}
+
+	// without window there is nothing to count
+	if policy.Window > 0 {
+		now := time.Now()
+		g.spendings = slices.DeleteFunc(g.spendings, func(s *spending) bool {
+			return s != reserved && now.Sub(s.at) >= policy.Window
+		})
+
+		windowAmount := txAmount
+		windowAssets := maps.Clone(txAssets)
+		for _, s := range g.spendings {
+			if s == reserved {
+				continue
+			}
+			windowAmount += s.amount
+			for assetId, assetAmount := range s.assets {
+				if _, ok := windowAssets[assetId]; ok {
+					windowAssets[assetId] += assetAmount
+				}
+			}
+		}
+
+		if policy.MaxWindowAmount > 0 && windowAmount > policy.MaxWindowAmount {
+			return fmt.Errorf(
+				"%w: %d sats spent in the last %s exceed the maximum of %d",
+				ErrPolicyViolation, windowAmount, policy.Window, policy.MaxWindowAmount,
+			)
+		}
+		if policy.MaxWindowAssetAmount > 0 {
+			for assetId, assetAmount := range windowAssets {
+				if assetAmount > policy.MaxWindowAssetAmount {
+					return fmt.Errorf(
+						"%w: %d of asset %s spent in the last %s exceed the maximum of %d",
+						ErrPolicyViolation, assetAmount, assetId, policy.Window, policy.MaxWindowAssetAmount,
+					)
+				}
+			}
+		}
+
+		if !slices.Contains(g.spendings, reserved) {
+			g.spendings = append(g.spendings, reserved)
+		}
+	}
+
+	reserved.amount = txAmount
+	reserved.assets = txAssets
+	return nil
+}
+
+// persist stores a committed request if it's counted in the window, and
+// removes the ones out of the window. The signatures being already
+// journaled, a failure is only logged
+func (g *SigningGuard) persist(ctx context.Context, committed *spending) {
+	window := g.Policy().Window
+
+	g.spendingLock.Lock()
+	store := g.spendingStore
+	counted := slices.Contains(g.spendings, committed)
+	g.spendingLock.Unlock()
+	if store == nil || !counted {
+		return
+	}
+
+	if err := store.Add(ctx, Spending{
+		Amount:    committed.amount,
+		Assets:    committed.assets,
+		CreatedAt: committed.at.Unix(),
+	}); err != nil {
+		log.WithError(err).Warn("failed to persist spending")
+	}
+	if err := store.DeleteBefore(ctx, time.Now().Add(-window).Unix()); err != nil {
+		log.WithError(err).Warn("failed to delete expired spendings")
+	}
+}
+
+// release removes a pending request from the window
+func (g *SigningGuard) release(reserved *spending) {
+	g.spendingLock.Lock()
+	defer g.spendingLock.Unlock()
+	g.spendings = slices.DeleteFunc(g.spendings, func(s *spending) bool {
+		return s == reserved
+	})
+}
+
+// checkPacket records the size of an introspector packet and checks it against
+// the request limits and the signing policy
+func (s *service) checkPacket(packet arkade.IntrospectorPacket) error {
+	s.metrics.ObservePacketEntries(len(packet))
+	if err := s.limits.checkScriptWork(packet); err != nil {
+		return err
+	}
+	return s.guard.checkPacketEntries(len(packet))
+}
+
+// spendingReservation counts the amounts spent by the inputs signed for a
+// request in the policy window. It's released if the request fails before
+// its signatures are journaled
+type spendingReservation struct {
+	guard          *SigningGuard
+	tx             *wire.MsgTx
+	prevOutFetcher txscript.PrevOutputFetcher
+	vins           map[int]struct{}
+	spending       *spending
+	done           bool
+}
+
+// reserveSpending starts the reservation of the amounts spent by a request,
+// only the inputs resolved to a key of the keyring are added to it
+func (s *service) reserveSpending(
+	tx *wire.MsgTx, prevOutFetcher txscript.PrevOutputFetcher,
+) *spendingReservation {
+	return &spendingReservation{
+		guard:          s.guard,
+		tx:             tx,
+		prevOutFetcher: prevOutFetcher,
+		vins:           make(map[int]struct{}),
+		spending:       &spending{at: time.Now()},
+	}
+}
+
+// add counts the amounts spent by an input before it's signed
+func (r *spendingReservation) add(vin int) error {
+	if _, ok := r.vins[vin]; ok || vin >= len(r.tx.TxIn) {
+		return nil
+	}
+	amount, assets, err := spentAmounts(r.tx, r.prevOutFetcher, map[int]struct{}{vin: {}})
+	if err != nil {
+		return err
+	}
+	if err := r.guard.reserve(r.spending, amount, assets); err != nil {
+		return err
+	}
+	r.vins[vin] = struct{}{}
+	return nil
+}
+
+// commit keeps the amounts of the signed inputs in the window
+func (r *spendingReservation) commit(ctx context.Context) {
+	r.done = true
+	r.guard.persist(ctx, r.spending)
+}
+
+// release forgets the amounts of a failed request, it does nothing once
+// committed
+func (r *spendingReservation) release() {
+	if r.done {
+		return
+	}
+	r.done = true
+	r.guard.release(r.spending)
+}
+
+// checkScriptPolicy checks the arkade script of an input against the signing
+// policy before it's executed
+func (s *service) checkScriptPolicy(requestType RequestType, script *arkade.ArkadeScript) error {
+	return s.guard.checkScript(requestType, script.Script(), script.Hash())
+}
+
+// executeScript checks the arkade script of an input against the signing
+// policy, runs it and records it in the metrics and the traces
+func (s *service) executeScript(
+	ctx context.Context, requestType RequestType, script *arkade.ArkadeScript,
+	tx *wire.MsgTx, prevOutFetcher arkade.ArkPrevOutFetcher, inputIndex int,
+) error {
+	if err := s.checkScriptPolicy(requestType, script); err != nil {
+		return err
+	}
+
+	_, span := tracer.Start(ctx, "ExecuteScript", trace.WithAttributes(
+		attribute.Int("vin", inputIndex),
+		attribute.String("script_hash", hex.EncodeToString(script.Hash())),
+	))
+
+	opcodes := make([]string, 0)
+	start := time.Now()
+	err := script.Execute(
+		tx, prevOutFetcher, inputIndex,
+		arkade.WithOpcodeCallback(func(opcode string) {
+			opcodes = append(opcodes, opcode)
+		}),
+	)
+	s.metrics.ObserveScript(time.Since(start), opcodes, err)
+
+	span.SetAttributes(attribute.Int("steps", len(opcodes)))
+	endSpan(span, err)
+	return err
+}
+
+// spentAmounts returns the sats and the asset amounts spent by the given
+// inputs of a tx, the assets are read from the asset packet of the tx
+// extension if any
+func spentAmounts(
+	tx *wire.MsgTx, prevOutFetcher txscript.PrevOutputFetcher, vins map[int]struct{},
+) (int64, map[string]uint64, error) {
+	amount := int64(0)
+	for vin := range vins {
+		if prevOut := prevOutFetcher.FetchPrevOutput(tx.TxIn[vin].PreviousOutPoint); prevOut != nil {
+			amount += prevOut.Value
+		}
+	}
+
+	assets := make(map[string]uint64)
+	ext, err := extension.NewExtensionFromTx(tx)
+	if err != nil {
+		if errors.Is(err, extension.ErrExtensionNotFound) {
+			return amount, assets, nil
+		}
+		return 0, nil, fmt.Errorf("failed to parse extension: %w", err)
+	}
+	for _, group := range ext.GetAssetPacket() {
+		// issuances don't spend any asset
+		if group.AssetId == nil {
+			continue
+		}
+		for _, input := range group.Inputs {
+			if input.Type != asset.AssetInputTypeLocal {
+				continue
+			}
+			if _, ok := vins[int(input.Vin)]; ok {
+				assets[group.AssetId.String()] += input.Amount
+			}
+		}
+	}
+	return amount, assets, nil
+}
+
+func lowercaseSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = struct{}{}
+	}
+	return set
+}
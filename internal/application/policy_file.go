@@ -0,0 +1,65 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// policyFile is the json representation of a Policy, the window is a
+// duration string like "24h"
+type policyFile struct {
+	AllowedScriptHashes  []string                 `json:"allowed_script_hashes"`
+	AllowedTemplates     []string                 `json:"allowed_templates"`
+	DeniedOpcodes        []string                 `json:"denied_opcodes"`
+	ScriptRequestTypes   map[string][]RequestType `json:"script_request_types"`
+	MaxPacketEntries     int                      `json:"max_packet_entries"`
+	MaxTxAmount          int64                    `json:"max_tx_amount"`
+	MaxTxAssetAmount     uint64                   `json:"max_tx_asset_amount"`
+	Window               string                   `json:"window"`
+	MaxWindowAmount      int64                    `json:"max_window_amount"`
+	MaxWindowAssetAmount uint64                   `json:"max_window_asset_amount"`
+}
+
+// ReadPolicyFile parses and validates the json policy file at the given path,
+// unknown fields are rejected so that a typo doesn't silently drop a rule
+func ReadPolicyFile(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var file policyFile
+	if err := decoder.Decode(&file); err != nil {
+		return Policy{}, fmt.Errorf("%w: %s", ErrInvalidPolicy, err)
+	}
+
+	var window time.Duration
+	if file.Window != "" {
+		if window, err = time.ParseDuration(file.Window); err != nil {
+			return Policy{}, fmt.Errorf("%w: invalid window %s", ErrInvalidPolicy, file.Window)
+		}
+	}
+
+	policy := Policy{
+		AllowedScriptHashes:  file.AllowedScriptHashes,
+		AllowedTemplates:     file.AllowedTemplates,
+		DeniedOpcodes:        file.DeniedOpcodes,
+		ScriptRequestTypes:   file.ScriptRequestTypes,
+		MaxPacketEntries:     file.MaxPacketEntries,
+		MaxTxAmount:          file.MaxTxAmount,
+		MaxTxAssetAmount:     file.MaxTxAssetAmount,
+		Window:               window,
+		MaxWindowAmount:      file.MaxWindowAmount,
+		MaxWindowAssetAmount: file.MaxWindowAssetAmount,
+	}
+	if err := policy.validate(); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
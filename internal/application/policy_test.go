@@ -0,0 +1,353 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/arkade-os/arkd/pkg/ark-lib/asset"
+	"github.com/arkade-os/arkd/pkg/ark-lib/extension"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningGuard(t *testing.T) {
+	scriptHash := make([]byte, 32)
+	scriptHash[0] = 0xab
+	otherScriptHash := make([]byte, 32)
+
+	script := mustScript(t, txscript.NewScriptBuilder().
+		AddData(make([]byte, 32)).AddOp(arkade.OP_CHECKSIG))
+
+	t.Run("pause", func(t *testing.T) {
+		svc := &service{guard: NewSigningGuard()}
+
+		svc.guard.Pause()
+		_, err := svc.getKeyring()
+		require.ErrorIs(t, err, ErrSigningPaused)
+
+		svc.guard.Resume()
+		require.False(t, svc.guard.IsPaused())
+	})
+
+	t.Run("empty policy", func(t *testing.T) {
+		guard := NewSigningGuard()
+		require.NoError(t, guard.checkScript(RequestTypeTx, script, scriptHash))
+		require.NoError(t, guard.checkPacketEntries(1000))
+		require.NoError(t, reserve(guard, 1_000_000, map[string]uint64{"asset": 1_000}))
+	})
+
+	t.Run("allowed scripts", func(t *testing.T) {
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{
+			AllowedScriptHashes: []string{strings.ToUpper(hex.EncodeToString(scriptHash))},
+			MaxPacketEntries:    2,
+		}))
+
+		require.NoError(t, guard.checkScript(RequestTypeTx, script, scriptHash))
+		require.ErrorIs(t, guard.checkScript(RequestTypeTx, script, otherScriptHash), ErrPolicyViolation)
+		require.NoError(t, guard.checkPacketEntries(2))
+		require.ErrorIs(t, guard.checkPacketEntries(3), ErrPolicyViolation)
+
+		// clearing the policy allows everything again
+		require.NoError(t, guard.SetPolicy(Policy{}))
+		require.NoError(t, guard.checkScript(RequestTypeTx, script, otherScriptHash))
+		require.NoError(t, guard.checkPacketEntries(3))
+	})
+
+	t.Run("allowed templates", func(t *testing.T) {
+		// same template as script, with a different key
+		sameTemplate := mustScript(t, txscript.NewScriptBuilder().
+			AddData(make([]byte, 33)).AddOp(arkade.OP_CHECKSIG))
+		otherTemplate := mustScript(t, txscript.NewScriptBuilder().
+			AddData(make([]byte, 32)).AddOp(arkade.OP_CHECKSIGVERIFY).AddOp(arkade.OP_TRUE))
+
+		templateHash, err := ScriptTemplateHash(script)
+		require.NoError(t, err)
+
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{
+			AllowedScriptHashes: []string{hex.EncodeToString(scriptHash)},
+			AllowedTemplates:    []string{hex.EncodeToString(templateHash)},
+		}))
+
+		require.NoError(t, guard.checkScript(RequestTypeTx, sameTemplate, otherScriptHash))
+		require.ErrorIs(t, guard.checkScript(RequestTypeTx, otherTemplate, otherScriptHash), ErrPolicyViolation)
+		// allowed by its hash
+		require.NoError(t, guard.checkScript(RequestTypeTx, otherTemplate, scriptHash))
+	})
+
+	t.Run("denied opcodes", func(t *testing.T) {
+		// the denied opcode is rejected even in a branch that is not executed
+		withDeniedOpcode := mustScript(t, txscript.NewScriptBuilder().
+			AddOp(arkade.OP_FALSE).AddOp(arkade.OP_IF).AddOp(arkade.OP_CHECKSIGFROMSTACK).
+			AddOp(arkade.OP_ENDIF).AddOp(arkade.OP_TRUE))
+
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{DeniedOpcodes: []string{"op_checksigfromstack"}}))
+
+		require.NoError(t, guard.checkScript(RequestTypeTx, script, scriptHash))
+		err := guard.checkScript(RequestTypeTx, withDeniedOpcode, scriptHash)
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		require.ErrorContains(t, err, "OP_CHECKSIGFROMSTACK")
+	})
+
+	t.Run("request types", func(t *testing.T) {
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{
+			ScriptRequestTypes: map[string][]RequestType{
+				hex.EncodeToString(scriptHash): {RequestTypeIntent, RequestTypeIntentDeletion},
+			},
+		}))
+
+		require.NoError(t, guard.checkScript(RequestTypeIntent, script, scriptHash))
+		require.ErrorIs(t, guard.checkScript(RequestTypeTx, script, scriptHash), ErrPolicyViolation)
+		// scripts not listed can be used with any request type
+		require.NoError(t, guard.checkScript(RequestTypeOnchainTx, script, otherScriptHash))
+	})
+
+	t.Run("tx amounts", func(t *testing.T) {
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{MaxTxAmount: 1_000, MaxTxAssetAmount: 10}))
+
+		require.NoError(t, reserve(guard, 1_000, map[string]uint64{"asset": 10}))
+		require.NoError(t, reserve(guard, 1_000, map[string]uint64{"asset": 10}))
+		require.ErrorIs(t, reserve(guard, 1_001, nil), ErrPolicyViolation)
+		require.ErrorIs(t, reserve(guard, 0, map[string]uint64{"asset": 11}), ErrPolicyViolation)
+	})
+
+	t.Run("window amounts", func(t *testing.T) {
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{
+			Window:               time.Hour,
+			MaxWindowAmount:      1_000,
+			MaxWindowAssetAmount: 10,
+		}))
+
+		require.NoError(t, reserve(guard, 600, map[string]uint64{"asset": 6}))
+		require.ErrorIs(t, reserve(guard, 600, nil), ErrPolicyViolation)
+		require.ErrorIs(t, reserve(guard, 0, map[string]uint64{"asset": 6}), ErrPolicyViolation)
+		// rejected requests are not counted
+		require.NoError(t, reserve(guard, 400, map[string]uint64{"asset": 4, "other": 10}))
+
+		// the spendings out of the window are forgotten
+		for i := range guard.spendings {
+			guard.spendings[i].at = guard.spendings[i].at.Add(-time.Hour)
+		}
+		require.NoError(t, reserve(guard, 1_000, map[string]uint64{"asset": 10}))
+	})
+
+	t.Run("spending reservation", func(t *testing.T) {
+		svc := &service{guard: NewSigningGuard()}
+		require.NoError(t, svc.guard.SetPolicy(Policy{
+			MaxTxAmount:     3_000,
+			Window:          time.Hour,
+			MaxWindowAmount: 4_000,
+		}))
+
+		tx := wire.NewMsgTx(2)
+		prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+		for i, value := range []int64{1_000, 2_000, 4_000} {
+			outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: uint32(i)}
+			tx.AddTxIn(&wire.TxIn{PreviousOutPoint: outpoint})
+			prevOuts.AddPrevOut(outpoint, &wire.TxOut{Value: value})
+		}
+
+		// only the inputs added are counted, the one of 4000 sats is left to
+		// another signer
+		reservation := svc.reserveSpending(tx, prevOuts)
+		require.NoError(t, reservation.add(0))
+		require.NoError(t, reservation.add(1))
+		require.NoError(t, reservation.add(1))
+		require.ErrorIs(t, reservation.add(2), ErrPolicyViolation)
+		require.Equal(t, int64(3_000), reservation.spending.amount)
+
+		// a failed request is not counted in the window
+		reservation.release()
+		require.Empty(t, svc.guard.spendings)
+
+		reservation = svc.reserveSpending(tx, prevOuts)
+		require.NoError(t, reservation.add(1))
+		reservation.commit(t.Context())
+		reservation.release()
+		require.Len(t, svc.guard.spendings, 1)
+
+		reservation = svc.reserveSpending(tx, prevOuts)
+		require.NoError(t, reservation.add(0))
+		require.ErrorIs(t, reservation.add(1), ErrPolicyViolation)
+		reservation.release()
+		require.Len(t, svc.guard.spendings, 1)
+	})
+
+	t.Run("persisted window", func(t *testing.T) {
+		now := time.Now()
+		store := &mockSpendingStore{spendings: []Spending{
+			{Amount: 1_000, CreatedAt: now.Add(-2 * time.Hour).Unix()},
+			{Amount: 600, CreatedAt: now.Unix()},
+		}}
+		svc := &service{guard: NewSigningGuard()}
+		require.NoError(t, svc.guard.SetPolicy(Policy{Window: time.Hour, MaxWindowAmount: 1_000}))
+		require.NoError(t, svc.guard.LoadSpendings(t.Context(), store))
+
+		tx := wire.NewMsgTx(2)
+		prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+		for i, value := range []int64{400, 500} {
+			outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: uint32(i)}
+			tx.AddTxIn(&wire.TxIn{PreviousOutPoint: outpoint})
+			prevOuts.AddPrevOut(outpoint, &wire.TxOut{Value: value})
+		}
+
+		// the spendings of the previous runs still count
+		reservation := svc.reserveSpending(tx, prevOuts)
+		require.ErrorIs(t, reservation.add(1), ErrPolicyViolation)
+		require.NoError(t, reservation.add(0))
+		reservation.commit(t.Context())
+
+		// the committed request is persisted, the expired one is removed
+		require.Equal(t, []Spending{
+			{Amount: 600, CreatedAt: now.Unix()},
+			{Amount: 400, Assets: map[string]uint64{}, CreatedAt: reservation.spending.at.Unix()},
+		}, store.spendings)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		guard := NewSigningGuard()
+		require.NoError(t, guard.SetPolicy(Policy{MaxPacketEntries: 1}))
+
+		for _, policy := range []Policy{
+			{AllowedScriptHashes: []string{"zz"}},
+			{AllowedScriptHashes: []string{"abcd"}},
+			{AllowedTemplates: []string{"abcd"}},
+			{DeniedOpcodes: []string{"OP_UNKNOWN"}},
+			{ScriptRequestTypes: map[string][]RequestType{hex.EncodeToString(scriptHash): {"submit"}}},
+			{MaxPacketEntries: -1},
+			{MaxTxAmount: -1},
+			{MaxWindowAmount: 1},
+		} {
+			require.ErrorIs(t, guard.SetPolicy(policy), ErrInvalidPolicy)
+		}
+		// the previous policy is kept
+		require.Equal(t, Policy{MaxPacketEntries: 1}, guard.Policy())
+	})
+
+	t.Run("policy file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "policy.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"denied_opcodes": ["OP_CHECKSIGFROMSTACK"],
+			"script_request_types": {"`+hex.EncodeToString(scriptHash)+`": ["submit_intent"]},
+			"max_tx_amount": 100000,
+			"window": "24h",
+			"max_window_amount": 1000000
+		}`), 0o600))
+
+		guard := NewSigningGuard()
+		_, err := guard.ReloadPolicy()
+		require.ErrorIs(t, err, ErrNoPolicyFile)
+
+		require.NoError(t, guard.LoadPolicyFile(path))
+		require.Equal(t, Policy{
+			DeniedOpcodes: []string{"OP_CHECKSIGFROMSTACK"},
+			ScriptRequestTypes: map[string][]RequestType{
+				hex.EncodeToString(scriptHash): {RequestTypeIntent},
+			},
+			MaxTxAmount:     100_000,
+			Window:          24 * time.Hour,
+			MaxWindowAmount: 1_000_000,
+		}, guard.Policy())
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"max_packet_entries": 5}`), 0o600))
+		policy, err := guard.ReloadPolicy()
+		require.NoError(t, err)
+		require.Equal(t, Policy{MaxPacketEntries: 5}, *policy)
+
+		// an invalid file keeps the policy in place
+		for _, content := range []string{
+			`{"max_packet_entry": 5}`,
+			`{"window": "one day"}`,
+			`{"max_tx_amount": -1}`,
+		} {
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			_, err = guard.ReloadPolicy()
+			require.ErrorIs(t, err, ErrInvalidPolicy)
+		}
+		require.Equal(t, Policy{MaxPacketEntries: 5}, guard.Policy())
+	})
+}
+
+func TestSpentAmounts(t *testing.T) {
+	assetId := asset.AssetId{Txid: chainhash.Hash{2}, Index: 0}
+
+	tx := wire.NewMsgTx(2)
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, value := range []int64{1_000, 2_000, 4_000} {
+		outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: uint32(i)}
+		tx.AddTxIn(&wire.TxIn{PreviousOutPoint: outpoint})
+		prevOuts.AddPrevOut(outpoint, &wire.TxOut{Value: value})
+	}
+	tx.AddTxOut(&wire.TxOut{Value: 7_000})
+
+	amount, assets, err := spentAmounts(tx, prevOuts, map[int]struct{}{0: {}, 2: {}})
+	require.NoError(t, err)
+	require.Equal(t, int64(5_000), amount)
+	require.Empty(t, assets)
+
+	assetPacket, err := asset.NewPacket([]asset.AssetGroup{{
+		AssetId: &assetId,
+		Inputs: []asset.AssetInput{
+			{Type: asset.AssetInputTypeLocal, Vin: 0, Amount: 10},
+			{Type: asset.AssetInputTypeLocal, Vin: 1, Amount: 20},
+			{Type: asset.AssetInputTypeLocal, Vin: 2, Amount: 40},
+		},
+		Outputs: []asset.AssetOutput{{Type: asset.AssetOutputTypeLocal, Vout: 0, Amount: 70}},
+	}})
+	require.NoError(t, err)
+	txOut, err := extension.Extension{assetPacket}.TxOut()
+	require.NoError(t, err)
+	tx.AddTxOut(txOut)
+
+	amount, assets, err = spentAmounts(tx, prevOuts, map[int]struct{}{0: {}, 2: {}})
+	require.NoError(t, err)
+	require.Equal(t, int64(5_000), amount)
+	require.Equal(t, map[string]uint64{assetId.String(): 50}, assets)
+}
+
+type mockSpendingStore struct {
+	spendings []Spending
+}
+
+func (m *mockSpendingStore) Add(_ context.Context, spending Spending) error {
+	m.spendings = append(m.spendings, spending)
+	return nil
+}
+
+func (m *mockSpendingStore) List(_ context.Context) ([]Spending, error) {
+	return slices.Clone(m.spendings), nil
+}
+
+func (m *mockSpendingStore) DeleteBefore(_ context.Context, before int64) error {
+	m.spendings = slices.DeleteFunc(m.spendings, func(s Spending) bool {
+		return s.CreatedAt < before
+	})
+	return nil
+}
+
+func (m *mockSpendingStore) Close() {}
+
+// reserve counts a new request in the window of the guard
+func reserve(guard *SigningGuard, amount int64, assets map[string]uint64) error {
+	return guard.reserve(&spending{at: time.Now()}, amount, assets)
+}
+
+func mustScript(t *testing.T, builder *txscript.ScriptBuilder) []byte {
+	script, err := builder.Script()
+	require.NoError(t, err)
+	return script
+}
@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	ErrScriptNotFound = errors.New("script not found")
+	ErrInvalidScript  = errors.New("invalid script")
+)
+
+const (
+	// maxScriptMetadataLength caps the name, the version and every abi field
+	// of a registered script
+	maxScriptMetadataLength = 256
+	maxScriptArguments      = 64
+)
+
+// ScriptArgument describes a witness argument of a registered script
+type ScriptArgument struct {
+	Name string
+	// Type is a hint for the wallets building the witness, eg. "signature",
+	// "pubkey", "bytes" or "int"
+	Type string
+}
+
+type ScriptRegistration struct {
+	Script  []byte
+	Name    string
+	Version string
+	// Abi lists the witness arguments of the script, in stack order
+	Abi []ScriptArgument
+}
+
+// RegisteredScript is an arkade script stored by the registry, the introspector
+// packet entries can reference it by hash instead of inlining its bytes
+type RegisteredScript struct {
+	// Hash is the hex ArkadeScriptHash of the script
+	Hash string
+	// TemplateHash is the hex ScriptTemplateHash of the script
+	TemplateHash string
+	Script       []byte
+	Name         string
+	Version      string
+	Abi          []ScriptArgument
+	CreatedAt    int64
+}
+
+// ScriptRegistry stores the registered scripts by hash, the content being
+// addressed by its hash, the scripts are never updated nor deleted
+type ScriptRegistry interface {
+	// Add stores the script unless its hash is already registered, it returns
+	// the stored script
+	Add(ctx context.Context, script RegisteredScript) (*RegisteredScript, error)
+	// Get returns ErrScriptNotFound if the hash is not registered
+	Get(ctx context.Context, hash string) (*RegisteredScript, error)
+	Count(ctx context.Context) (int, error)
+	Close()
+}
+
+func (s *service) RegisterScript(
+	ctx context.Context, registration ScriptRegistration,
+) (*RegisteredScript, error) {
+	if err := registration.validate(); err != nil {
+		return nil, err
+	}
+
+	templateHash, err := ScriptTemplateHash(registration.Script)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidScript, err)
+	}
+	hash := hex.EncodeToString(arkade.ArkadeScriptHash(registration.Script))
+
+	// the registrations are serialized so that the registry can't grow past
+	// its cap, registering a script again is allowed once full
+	s.registerLock.Lock()
+	defer s.registerLock.Unlock()
+
+	if s.limits.MaxRegisteredScripts > 0 {
+		registered, err := s.scripts.Get(ctx, hash)
+		if err == nil {
+			return registered, nil
+		}
+		if !errors.Is(err, ErrScriptNotFound) {
+			return nil, err
+		}
+		count, err := s.scripts.Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.limits.Check(
+			"registered scripts", count+1, s.limits.MaxRegisteredScripts,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.scripts.Add(ctx, RegisteredScript{
+		Hash:         hash,
+		TemplateHash: hex.EncodeToString(templateHash),
+		Script:       registration.Script,
+		Name:         registration.Name,
+		Version:      registration.Version,
+		Abi:          registration.Abi,
+		CreatedAt:    time.Now().Unix(),
+	})
+}
+
+func (s *service) GetScript(ctx context.Context, hash string) (*RegisteredScript, error) {
+	decoded, err := hex.DecodeString(hash)
+	if err != nil || len(decoded) != arkade.ScriptHashLength {
+		return nil, fmt.Errorf("%w: invalid script hash %s", ErrInvalidScript, hash)
+	}
+	return s.scripts.Get(ctx, hex.EncodeToString(decoded))
+}
+
+// readIntrospectorPacket parses the introspector packet of a tx and resolves
+// the bytes of the scripts referenced by hash with the registry
+func (s *service) readIntrospectorPacket(
+	ctx context.Context, tx *wire.MsgTx,
+) (arkade.IntrospectorPacket, error) {
+	packet, err := findIntrospectorPacket(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range packet {
+		if !entry.IsReference() {
+			continue
+		}
+		registered, err := s.scripts.Get(ctx, hex.EncodeToString(entry.ScriptHash))
+		if err != nil {
+			if errors.Is(err, ErrScriptNotFound) {
+				return nil, fmt.Errorf(
+					"%w: vin %d references unregistered script %x", err, entry.Vin, entry.ScriptHash,
+				)
+			}
+			return nil, err
+		}
+		packet[i].Script = registered.Script
+	}
+	return packet, nil
+}
+
+func (r ScriptRegistration) validate() error {
+	if len(r.Script) == 0 {
+		return fmt.Errorf("%w: missing script", ErrInvalidScript)
+	}
+	if len(r.Script) > arkade.MaxScriptLength {
+		return fmt.Errorf(
+			"%w: script exceeds the maximum length of %d", ErrInvalidScript, arkade.MaxScriptLength,
+		)
+	}
+	if len(r.Abi) > maxScriptArguments {
+		return fmt.Errorf(
+			"%w: abi exceeds the maximum of %d arguments", ErrInvalidScript, maxScriptArguments,
+		)
+	}
+	fields := []string{r.Name, r.Version}
+	for _, arg := range r.Abi {
+		fields = append(fields, arg.Name, arg.Type)
+	}
+	for _, field := range fields {
+		if len(field) > maxScriptMetadataLength {
+			return fmt.Errorf(
+				"%w: metadata exceeds the maximum length of %d", ErrInvalidScript, maxScriptMetadataLength,
+			)
+		}
+	}
+	return nil
+}
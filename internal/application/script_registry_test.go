@@ -0,0 +1,149 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/arkade-os/arkd/pkg/ark-lib/extension"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptRegistry(t *testing.T) {
+	ctx := context.Background()
+	script := mustScript(t, txscript.NewScriptBuilder().
+		AddData(make([]byte, 32)).AddOp(arkade.OP_CHECKSIG))
+	scriptHash := arkade.ArkadeScriptHash(script)
+
+	svc := &service{scripts: newMockScriptRegistry()}
+
+	t.Run("register", func(t *testing.T) {
+		for _, registration := range []ScriptRegistration{
+			{},
+			{Script: make([]byte, arkade.MaxScriptLength+1)},
+			{Script: script, Name: strings.Repeat("a", maxScriptMetadataLength+1)},
+			{Script: script, Abi: make([]ScriptArgument, maxScriptArguments+1)},
+			// truncated push
+			{Script: []byte{txscript.OP_DATA_2, 0x01}},
+		} {
+			_, err := svc.RegisterScript(ctx, registration)
+			require.ErrorIs(t, err, ErrInvalidScript)
+		}
+
+		templateHash, err := ScriptTemplateHash(script)
+		require.NoError(t, err)
+
+		registered, err := svc.RegisterScript(ctx, ScriptRegistration{
+			Script:  script,
+			Name:    "vault",
+			Version: "1",
+			Abi:     []ScriptArgument{{Name: "sig", Type: "signature"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(scriptHash), registered.Hash)
+		require.Equal(t, hex.EncodeToString(templateHash), registered.TemplateHash)
+
+		// the first registration is kept
+		again, err := svc.RegisterScript(ctx, ScriptRegistration{Script: script, Name: "other"})
+		require.NoError(t, err)
+		require.Equal(t, registered, again)
+	})
+
+	t.Run("registry full", func(t *testing.T) {
+		svc := &service{
+			scripts: newMockScriptRegistry(),
+			limits:  RequestLimits{MaxRegisteredScripts: 1},
+		}
+		_, err := svc.RegisterScript(ctx, ScriptRegistration{Script: script})
+		require.NoError(t, err)
+
+		other := mustScript(t, txscript.NewScriptBuilder().AddOp(arkade.OP_TRUE))
+		_, err = svc.RegisterScript(ctx, ScriptRegistration{Script: other})
+		require.ErrorIs(t, err, ErrLimitExceeded)
+
+		// a script already registered is still returned
+		registered, err := svc.RegisterScript(ctx, ScriptRegistration{Script: script})
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(scriptHash), registered.Hash)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		_, err := svc.GetScript(ctx, "abcd")
+		require.ErrorIs(t, err, ErrInvalidScript)
+
+		_, err = svc.GetScript(ctx, hex.EncodeToString(make([]byte, 32)))
+		require.ErrorIs(t, err, ErrScriptNotFound)
+
+		registered, err := svc.GetScript(ctx, strings.ToUpper(hex.EncodeToString(scriptHash)))
+		require.NoError(t, err)
+		require.Equal(t, script, registered.Script)
+	})
+
+	t.Run("resolve references", func(t *testing.T) {
+		newTx := func(entries ...arkade.IntrospectorEntry) *wire.MsgTx {
+			packet, err := arkade.NewPacket(entries...)
+			require.NoError(t, err)
+			txOut, err := extension.Extension{packet}.TxOut()
+			require.NoError(t, err)
+
+			tx := wire.NewMsgTx(2)
+			tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}}})
+			tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{2}}})
+			tx.AddTxOut(txOut)
+			return tx
+		}
+		inlined := []byte{txscript.OP_TRUE}
+
+		packet, err := svc.readIntrospectorPacket(ctx, newTx(
+			arkade.IntrospectorEntry{Vin: 0, ScriptHash: scriptHash},
+			arkade.IntrospectorEntry{Vin: 1, Script: inlined},
+		))
+		require.NoError(t, err)
+		require.Len(t, packet, 2)
+		require.Equal(t, script, packet[0].Script)
+		require.Equal(t, scriptHash, packet[0].Hash())
+		require.Equal(t, inlined, packet[1].Script)
+
+		_, err = svc.readIntrospectorPacket(ctx, newTx(
+			arkade.IntrospectorEntry{Vin: 0, ScriptHash: make([]byte, 32)},
+		))
+		require.ErrorIs(t, err, ErrScriptNotFound)
+	})
+}
+
+type mockScriptRegistry struct {
+	scripts map[string]RegisteredScript
+}
+
+func newMockScriptRegistry() *mockScriptRegistry {
+	return &mockScriptRegistry{scripts: make(map[string]RegisteredScript)}
+}
+
+func (m *mockScriptRegistry) Add(_ context.Context, script RegisteredScript) (*RegisteredScript, error) {
+	if _, ok := m.scripts[script.Hash]; !ok {
+		script.Script = bytes.Clone(script.Script)
+		m.scripts[script.Hash] = script
+	}
+	stored := m.scripts[script.Hash]
+	return &stored, nil
+}
+
+func (m *mockScriptRegistry) Get(_ context.Context, hash string) (*RegisteredScript, error) {
+	script, ok := m.scripts[hash]
+	if !ok {
+		return nil, ErrScriptNotFound
+	}
+	return &script, nil
+}
+
+func (m *mockScriptRegistry) Count(_ context.Context) (int, error) {
+	return len(m.scripts), nil
+}
+
+func (m *mockScriptRegistry) Close() {}
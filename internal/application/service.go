@@ -3,18 +3,25 @@ package application
 import (
 	"context"
 	"encoding/hex"
-	"fmt"
+	"sync"
+	"time"
 
 	"github.com/arkade-os/arkd/pkg/ark-lib/intent"
 	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
-	"github.com/arkade-os/go-sdk/client"
-	grpcclient "github.com/arkade-os/go-sdk/client/grpc"
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 )
 
 type Info struct {
-	SignerPublicKey string
+	SignerPublicKey   string
+	DeprecatedSigners []DeprecatedSigner
+	ArkServers        []ArkServer
+}
+
+// DeprecatedSigner is a retired key that still signs for the VTXOs bound to it
+type DeprecatedSigner struct {
+	PublicKey string
+	// unix timestamp after which the key stops signing, 0 if not set
+	SunsetAt int64
 }
 
 type OffchainTx struct {
@@ -27,11 +34,18 @@ type Intent struct {
 	Message intent.RegisterMessage
 }
 
+type IntentDeletion struct {
+	Proof   intent.Proof
+	Message intent.DeleteMessage
+}
+
 type BatchFinalization struct {
 	Intent        Intent
 	Forfeits      []*psbt.Packet
 	ConnectorTree *tree.TxTree
-	CommitmentTx  *psbt.Packet
+	// VtxoTree is required to sign the forfeits or boarding inputs of an intent with vtxo outputs
+	VtxoTree     *tree.TxTree
+	CommitmentTx *psbt.Packet
 }
 
 type SignedBatchFinalization struct {
@@ -46,60 +60,179 @@ type OnchainTx struct {
 type Service interface {
 	GetInfo(context.Context) (*Info, error)
 	SubmitTx(context.Context, OffchainTx) (*OffchainTx, error)
-	SubmitIntent(context.Context, Intent) (*psbt.Packet, error)
+	SubmitTxAsync(context.Context, OffchainTx) (string, error)
+	// GetTxStatus returns ErrTxNotFound if the tx was never submitted
+	GetTxStatus(ctx context.Context, txid string) (*TxStatus, error)
+	WatchTx(ctx context.Context, txid string) (<-chan TxStatus, error)
+	// SubmitIntent reports the outcome of each introspector packet entry, in
+	// strict mode it fails with ErrIntentNotSigned if any is not signed
+	SubmitIntent(ctx context.Context, intent Intent, strict bool) (*SignedIntent, error)
+	SubmitIntentDeletion(context.Context, IntentDeletion) (*SignedIntent, error)
 	SubmitFinalization(context.Context, BatchFinalization) (*SignedBatchFinalization, error)
 	SubmitOnchainTx(context.Context, OnchainTx) (*psbt.Packet, error)
+	// RegisterDelegate and GetDelegate fail with ErrDelegateSolverDisabled
+	// unless the built-in solver is enabled
+	RegisterDelegate(context.Context, DelegateRegistration) (*Delegate, error)
+	GetDelegate(ctx context.Context, outpoint string) (*Delegate, error)
+	// CreateTreeCosigner fails with ErrTreeCosignerDisabled unless the
+	// introspector cosigns vtxo trees
+	CreateTreeCosigner(context.Context) (*TreeCosigner, error)
+	// RegisterScript stores the script in the registry, registering a script
+	// again returns the first registration
+	RegisterScript(context.Context, ScriptRegistration) (*RegisteredScript, error)
+	// GetScript returns ErrScriptNotFound if the hash is not registered
+	GetScript(ctx context.Context, hash string) (*RegisteredScript, error)
 	Close()
 }
 
 type service struct {
-	signer     signer
-	publicKey  string
-	arkdClient client.TransportClient
-	arkdPubKey *btcec.PublicKey
-}
+	keystore   Keystore
+	arkServers *ArkServers
 
-func New(ctx context.Context, secretKey *btcec.PrivateKey, arkdURL string) (Service, error) {
-	publicKey := hex.EncodeToString(secretKey.PubKey().SerializeCompressed())
+	journal SigningJournal
+	// if set, refuse to sign a spend conflicting with a journaled one
+	equivocationProtection bool
+	journalLock            sync.Mutex
 
-	arkdClient, err := grpcclient.NewClient(arkdURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create arkd client: %w", err)
+	finalizations FinalizationQueue
+
+	submissions     SubmissionStore
+	submissionLocks *keyedMutex
+	txJobs          *txJobs
+
+	intents IntentStore
+
+	delegates   DelegateStore
+	solver      DelegateSolverConfig
+	solverWg    sync.WaitGroup
+	solvingLock sync.Mutex
+	// outpoints of the delegates in a batch session
+	solving map[string]struct{}
+
+	cosigner  TreeCosignerConfig
+	cosigners *treeCosigners
+
+	scripts      ScriptRegistry
+	registerLock sync.Mutex
+
+	limits  RequestLimits
+	guard   *SigningGuard
+	metrics Metrics
+
+	// ctx of the async tx submissions, cancelled on Close
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func New(
+	keystore Keystore, arkServers *ArkServers,
+	journal SigningJournal, finalizations FinalizationQueue, submissions SubmissionStore,
+	intents IntentStore, delegates DelegateStore, scripts ScriptRegistry,
+	solver DelegateSolverConfig, cosigner TreeCosignerConfig, equivocationProtection bool,
+	limits RequestLimits, guard *SigningGuard, metrics Metrics,
+) (Service, error) {
+	svcCtx, cancel := context.WithCancel(context.Background())
+	svc := &service{
+		keystore:               keystore,
+		arkServers:             arkServers,
+		journal:                journal,
+		equivocationProtection: equivocationProtection,
+		finalizations:          finalizations,
+		submissions:            submissions,
+		submissionLocks:        newKeyedMutex(),
+		txJobs:                 newTxJobs(),
+		intents:                intents,
+		delegates:              delegates,
+		scripts:                scripts,
+		solver:                 solver,
+		solving:                make(map[string]struct{}),
+		cosigner:               cosigner,
+		cosigners:              newTreeCosigners(),
+		limits:                 limits,
+		guard:                  guard,
+		metrics:                metrics,
+		ctx:                    svcCtx,
+		cancel:                 cancel,
+	}
+	go svc.purgeExpiredIntents()
+	go svc.purgeTxJobs()
+	if solver.Enabled {
+		svc.solverWg.Add(1)
+		go svc.runDelegateSolver()
 	}
 
-	arkdInfo, err := arkdClient.GetInfo(ctx)
+	return svc, nil
+}
+
+func (s *service) Close() {
+	s.cancel()
+	s.solverWg.Wait()
+	s.cosigners.wg.Wait()
+	s.finalizations.Close()
+	s.arkServers.close()
+	s.journal.Close()
+	s.submissions.Close()
+	s.intents.Close()
+	s.delegates.Close()
+	s.scripts.Close()
+	s.guard.close()
+}
+
+// GetInfo only requires the keystore to be initialized, it works while locked
+func (s *service) GetInfo(ctx context.Context) (*Info, error) {
+	signerKeys, err := s.keystore.SignerKeys()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch arkd info: %w", err)
+		return nil, err
 	}
-	if arkdInfo == nil {
-		return nil, fmt.Errorf("arkd info is required")
+
+	info := &Info{
+		SignerPublicKey:   hex.EncodeToString(signerKeys[0].PublicKey.SerializeCompressed()),
+		DeprecatedSigners: make([]DeprecatedSigner, 0, len(signerKeys)-1),
+		ArkServers:        s.arkServers.list(),
 	}
-	if arkdInfo.SignerPubKey == "" {
-		return nil, fmt.Errorf("arkd info does not include signer pubkey")
+	now := time.Now()
+	for _, key := range signerKeys[1:] {
+		if !key.IsActive(now) {
+			continue
+		}
+		info.DeprecatedSigners = append(info.DeprecatedSigners, DeprecatedSigner{
+			PublicKey: hex.EncodeToString(key.PublicKey.SerializeCompressed()),
+			SunsetAt:  key.SunsetAt,
+		})
 	}
+	return info, nil
+}
 
-	decodedKey, err := hex.DecodeString(arkdInfo.SignerPubKey)
+// getKeyring returns the signers of the active keys if the keystore is
+// unlocked and signing is not paused, the caller zeroes them once done
+func (s *service) getKeyring() (keyring, error) {
+	if s.guard.IsPaused() {
+		return nil, ErrSigningPaused
+	}
+	signerKeys, err := s.keystore.SignerKeys()
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode arkd signer pubkey: %w", err)
+		return nil, err
 	}
-
-	arkdPubKey, err := btcec.ParsePubKey(decodedKey)
+	secretKeys, err := s.keystore.SecretKeys()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse arkd signer pubkey: %w", err)
+		return nil, err
 	}
 
-	return &service{
-		signer:     signer{secretKey},
-		publicKey:  publicKey,
-		arkdClient: arkdClient,
-		arkdPubKey: arkdPubKey,
-	}, nil
-}
-
-func (s *service) Close() {
-	s.arkdClient.Close()
-}
-
-func (s *service) GetInfo(ctx context.Context) (*Info, error) {
-	return &Info{SignerPublicKey: s.publicKey}, nil
+	now := time.Now()
+	signers := make(keyring, 0, len(secretKeys))
+	for _, secretKey := range secretKeys {
+		active := false
+		for _, key := range signerKeys {
+			if key.PublicKey.IsEqual(secretKey.PubKey()) && key.IsActive(now) {
+				active = true
+				break
+			}
+		}
+		if !active {
+			secretKey.Zero()
+			continue
+		}
+		signers = append(signers, signer{secretKey})
+	}
+	return signers, nil
 }
@@ -1,6 +1,8 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ArkLabsHQ/introspector/pkg/arkade"
@@ -8,18 +10,54 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/txscript"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type signer struct {
 	secretKey *btcec.PrivateKey
 }
 
-func (s signer) signInput(ptx *psbt.Packet, inputIndex int, tweak []byte, prevoutFetcher txscript.PrevOutputFetcher) error {
+// keyring holds the signers of the active keys, current key first. The keys
+// are copies owned by the request, zeroed once it's done
+type keyring []signer
+
+func (k keyring) zero() {
+	for _, s := range k {
+		s.secretKey.Zero()
+	}
+}
+
+// readArkadeScript looks for the signer whose tweaked key is part of the
+// closure spent by the entry's input.
+// It returns arkade.ErrTweakedArkadePubKeyNotFound if none of them matches.
+func (k keyring) readArkadeScript(ptx *psbt.Packet, entry arkade.IntrospectorEntry) (*arkade.ArkadeScript, signer, error) {
+	for _, s := range k {
+		script, err := arkade.ReadArkadeScript(ptx, s.secretKey.PubKey(), entry)
+		if err != nil {
+			if errors.Is(err, arkade.ErrTweakedArkadePubKeyNotFound) {
+				continue
+			}
+			return nil, signer{}, err
+		}
+		return script, s, nil
+	}
+	return nil, signer{}, arkade.ErrTweakedArkadePubKeyNotFound
+}
+
+func (s signer) signInput(
+	ctx context.Context, ptx *psbt.Packet, inputIndex int, tweak []byte,
+	prevoutFetcher txscript.PrevOutputFetcher,
+) (err error) {
+	_, span := tracer.Start(ctx, "SignInput", trace.WithAttributes(attribute.Int("vin", inputIndex)))
+	defer func() { endSpan(span, err) }()
+
 	if len(ptx.Inputs) <= inputIndex || len(ptx.UnsignedTx.TxIn) <= inputIndex {
 		return fmt.Errorf("input index out of range, cannot sign")
 	}
 
 	signingKey := arkade.ComputeArkadeScriptPrivateKey(s.secretKey, tweak)
+	defer signingKey.Zero()
 
 	input := ptx.Inputs[inputIndex]
 	// if not a taproot input, skip because arkd-wallet is taproot only accounts
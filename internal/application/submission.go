@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrSubmissionNotFound = errors.New("submission not found")
+
+type SubmissionKind string
+
+const (
+	SubmissionKindTx     SubmissionKind = "tx"
+	SubmissionKindIntent SubmissionKind = "intent"
+)
+
+type SubmissionStatus string
+
+const (
+	// the response only carries our signatures
+	SubmissionStatusSigned SubmissionStatus = "signed"
+	// the tx was accepted by arkd, the finalization is handled by the queue
+	SubmissionStatusSubmitted SubmissionStatus = "submitted"
+)
+
+// Submission caches the response to a SubmitTx or SubmitIntent request so
+// that a retry returns the same signed PSBTs without processing it again.
+type Submission struct {
+	// Id is the ark txid or the intent proof txid
+	Id     string
+	Kind   SubmissionKind
+	Status SubmissionStatus
+	// base64 signed ark tx or intent proof
+	Tx string
+	// base64 signed checkpoints, for txs only
+	Checkpoints []string
+	// signer pubkey of the arkd that accepted the tx, for submitted txs only
+	ArkdPubKey string
+	CreatedAt  int64
+}
+
+type SubmissionStore interface {
+	Add(ctx context.Context, submission Submission) error
+	// Get returns ErrSubmissionNotFound if the id is unknown
+	Get(ctx context.Context, kind SubmissionKind, id string) (*Submission, error)
+	Close()
+}
+
+// getSubmission returns nil if the request was never handled
+func (s *service) getSubmission(ctx context.Context, kind SubmissionKind, id string) (*Submission, error) {
+	submission, err := s.submissions.Get(ctx, kind, id)
+	if err != nil {
+		if errors.Is(err, ErrSubmissionNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read submission: %w", err)
+	}
+	return submission, nil
+}
+
+// saveSubmission doesn't fail the request, the signatures are already
+// journaled and a retry would only process the request again
+func (s *service) saveSubmission(
+	ctx context.Context, kind SubmissionKind, id string, status SubmissionStatus,
+	tx *psbt.Packet, checkpoints []*psbt.Packet, arkdPubKey string,
+) {
+	submission := Submission{
+		Id:          id,
+		Kind:        kind,
+		Status:      status,
+		Checkpoints: make([]string, 0, len(checkpoints)),
+		ArkdPubKey:  arkdPubKey,
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	err := func() error {
+		encoded, err := tx.B64Encode()
+		if err != nil {
+			return err
+		}
+		submission.Tx = encoded
+		for _, checkpoint := range checkpoints {
+			encoded, err := checkpoint.B64Encode()
+			if err != nil {
+				return err
+			}
+			submission.Checkpoints = append(submission.Checkpoints, encoded)
+		}
+		return s.submissions.Add(ctx, submission)
+	}()
+	if err != nil {
+		log.WithError(err).WithField("id", id).Warnf("failed to save %s submission", kind)
+	}
+}
+
+// resumeSubmittedTx returns the cached response of an already handled tx.
+// If we were the finalizer and got interrupted before enqueuing the
+// finalization, it is started again.
+func (s *service) resumeSubmittedTx(ctx context.Context, submission *Submission) (*OffchainTx, error) {
+	if submission.Status == SubmissionStatusSubmitted {
+		_, err := s.finalizations.Get(ctx, submission.Id)
+		if err != nil {
+			if !errors.Is(err, ErrFinalizationNotFound) {
+				return nil, fmt.Errorf("failed to read finalization: %w", err)
+			}
+			log.WithField("txid", submission.Id).Info("resuming interrupted finalization")
+			if _, err := s.finalize(ctx, submission.Id, submission.ArkdPubKey, submission.Checkpoints); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return decodeSubmittedTx(submission)
+}
+
+func decodeSubmittedTx(submission *Submission) (*OffchainTx, error) {
+	arkTx, err := decodePsbt(submission.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cached ark tx: %w", err)
+	}
+	checkpoints := make([]*psbt.Packet, 0, len(submission.Checkpoints))
+	for i, checkpoint := range submission.Checkpoints {
+		ptx, err := decodePsbt(checkpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode cached checkpoint %d: %w", i, err)
+		}
+		checkpoints = append(checkpoints, ptx)
+	}
+
+	return &OffchainTx{ArkTx: arkTx, Checkpoints: checkpoints}, nil
+}
+
+func decodePsbt(b64 string) (*psbt.Packet, error) {
+	return psbt.NewFromRawBytes(strings.NewReader(b64), true)
+}
+
+// keyedMutex serializes the handling of the requests for the same id, so
+// that a retry waits for the in-flight request and gets its cached response
+type keyedMutex struct {
+	lock  sync.Mutex
+	locks map[string]*refMutex
+}
+
+type refMutex struct {
+	sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*refMutex)}
+}
+
+func (k *keyedMutex) Lock(key string) (unlock func()) {
+	k.lock.Lock()
+	mtx, ok := k.locks[key]
+	if !ok {
+		mtx = &refMutex{}
+		k.locks[key] = mtx
+	}
+	mtx.refs++
+	k.lock.Unlock()
+
+	mtx.Lock()
+	return func() {
+		mtx.Unlock()
+
+		k.lock.Lock()
+		defer k.lock.Unlock()
+		mtx.refs--
+		if mtx.refs == 0 {
+			delete(k.locks, key)
+		}
+	}
+}
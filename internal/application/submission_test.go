@@ -0,0 +1,114 @@
+package application
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	locks := newKeyedMutex()
+
+	var running, maxRunning atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.Lock("txid")
+			defer unlock()
+
+			n := running.Add(1)
+			if n > maxRunning.Load() {
+				maxRunning.Store(n)
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+
+	// a different key is not blocked
+	unlock := locks.Lock("other")
+	unlock()
+
+	wg.Wait()
+	require.Equal(t, int32(1), maxRunning.Load())
+	require.Empty(t, locks.locks)
+}
+
+func TestResumeSubmittedTx(t *testing.T) {
+	arkTx := newTestPsbt(t, 0)
+	checkpoint := newTestPsbt(t, 1)
+
+	testCases := []struct {
+		name              string
+		status            SubmissionStatus
+		finalized         bool
+		wantFinalizeCalls int
+	}{
+		{
+			name:   "signed only",
+			status: SubmissionStatusSigned,
+		},
+		{
+			name:      "submitted and finalized",
+			status:    SubmissionStatusSubmitted,
+			finalized: true,
+		},
+		{
+			name:              "submitted but finalization interrupted",
+			status:            SubmissionStatusSubmitted,
+			wantFinalizeCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockArkdClient{}
+			store := newMockFinalizationStore()
+			if tc.finalized {
+				require.NoError(t, store.Upsert(t.Context(), Finalization{
+					Txid:   "txid",
+					Status: FinalizationStatusFinalized,
+				}))
+			}
+			svc := &service{finalizations: newTestFinalizationQueue(t, client, store)}
+
+			got, err := svc.resumeSubmittedTx(t.Context(), &Submission{
+				Id:          "txid",
+				Kind:        SubmissionKindTx,
+				Status:      tc.status,
+				Tx:          arkTx,
+				Checkpoints: []string{checkpoint},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.wantFinalizeCalls, client.finalizeCalls)
+
+			gotArkTx, err := got.ArkTx.B64Encode()
+			require.NoError(t, err)
+			require.Equal(t, arkTx, gotArkTx)
+			require.Len(t, got.Checkpoints, 1)
+			gotCheckpoint, err := got.Checkpoints[0].B64Encode()
+			require.NoError(t, err)
+			require.Equal(t, checkpoint, gotCheckpoint)
+		})
+	}
+}
+
+func newTestPsbt(t *testing.T, index uint32) string {
+	t.Helper()
+	tx := wire.NewMsgTx(3)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}, Index: index}})
+	tx.AddTxOut(&wire.TxOut{Value: 1_000, PkScript: []byte{0x51}})
+	ptx, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+	b64, err := ptx.B64Encode()
+	require.NoError(t, err)
+	return b64
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+
+	"github.com/ArkLabsHQ/introspector/pkg/arkade"
+	"github.com/btcsuite/btcd/wire"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the spans are exported only if a tracer provider is configured
+var tracer = otel.Tracer("github.com/ArkLabsHQ/introspector/internal/application")
+
+// endSpan records the error, if any, before ending the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func findIntrospectorPacket(ctx context.Context, tx *wire.MsgTx) (arkade.IntrospectorPacket, error) {
+	_, span := tracer.Start(ctx, "ParseIntrospectorPacket")
+	packet, err := arkade.FindIntrospectorPacket(tx)
+	span.SetAttributes(attribute.Int("entries", len(packet)))
+	endSpan(span, err)
+	return packet, err
+}
@@ -5,10 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/ArkLabsHQ/introspector/pkg/arkade"
 	"github.com/arkade-os/arkd/pkg/ark-lib/script"
@@ -20,7 +18,39 @@ import (
 
 // SubmitTx aims to execute arkade scripts on offchain ark transactions
 // execution of the script runs only on ark tx, if valid, the associated checkpoint tx
+// it is idempotent: a tx already handled gets the cached response
 func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, error) {
+	arkTxid := tx.ArkTx.UnsignedTx.TxID()
+
+	unlock := s.submissionLocks.Lock(arkTxid)
+	defer unlock()
+
+	submission, err := s.getSubmission(ctx, SubmissionKindTx, arkTxid)
+	if err != nil {
+		return nil, err
+	}
+	if submission != nil {
+		log.WithField("txid", arkTxid).Debug("tx already submitted, returning cached response")
+		// the status of the tx is read from the cache from now on
+		s.txJobs.remove(arkTxid)
+		return s.resumeSubmittedTx(ctx, submission)
+	}
+
+	signedTx, err := s.submitTx(ctx, tx)
+	if err != nil {
+		s.txJobs.fail(arkTxid, err)
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+func (s *service) submitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, error) {
+	signers, err := s.getKeyring()
+	if err != nil {
+		return nil, err
+	}
+	defer signers.zero()
+
 	arkPtx := tx.ArkTx
 
 	// index checkpoints by txid for easy lookup while signing ark transaction
@@ -34,13 +64,15 @@ func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, err
 		orderedCheckpointTxids = append(orderedCheckpointTxids, checkpoint.UnsignedTx.TxID())
 	}
 
+	_, span := tracer.Start(ctx, "BuildPrevOutFetcher")
 	prevOutFetcher, err := prevOutFetcherForArkTx(arkPtx, tx.Checkpoints)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create prevout fetcher: %w", err)
 	}
 
 	// Parse IntrospectorPacket from the transaction's OP_RETURN output
-	packet, err := arkade.FindIntrospectorPacket(arkPtx.UnsignedTx)
+	packet, err := s.readIntrospectorPacket(ctx, arkPtx.UnsignedTx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse introspector packet: %w", err)
 	}
@@ -48,15 +80,19 @@ func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, err
 	if len(packet) == 0 {
 		return nil, fmt.Errorf("no introspector packet found in transaction")
 	}
+	if err := s.checkPacket(packet); err != nil {
+		return nil, err
+	}
+	reservation := s.reserveSpending(arkPtx.UnsignedTx, prevOutFetcher)
+	defer reservation.release()
 
-	signerPublicKey := s.signer.secretKey.PubKey()
-
-	finalizerAcc := newFinalizerAccumulator(s.arkdPubKey)
+	router := &serverRouter{servers: s.arkServers}
+	var finalizerAcc *finalizerAccumulator
 
-	var nSigned = 0
+	signatures := make([]Signature, 0, 2*len(packet))
 	for _, entry := range packet {
 		inputIndex := int(entry.Vin)
-		script, err := arkade.ReadArkadeScript(arkPtx, signerPublicKey, entry)
+		script, signer, err := signers.readArkadeScript(arkPtx, entry)
 		if err != nil {
 			// there may be input/entry pairs attributed to a different signer
 			if errors.Is(err, arkade.ErrTweakedArkadePubKeyNotFound) && len(arkPtx.Inputs) > 1 {
@@ -65,51 +101,76 @@ func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, err
 			return nil, fmt.Errorf("failed to read arkade script: %w vin=%d", err, inputIndex)
 		}
 
+		server, err := router.route(script.ClosurePubKeys())
+		if err != nil {
+			return nil, fmt.Errorf("%w vin=%d", err, inputIndex)
+		}
+		if finalizerAcc == nil {
+			finalizerAcc = newFinalizerAccumulator(server.pubKeys...)
+		}
+
 		log.Debugf("executing arkade script: %x", script.Script())
-		if err := script.Execute(
-			arkPtx.UnsignedTx,
-			prevOutFetcher,
-			inputIndex,
+		if err := s.executeScript(
+			ctx, RequestTypeTx, script, arkPtx.UnsignedTx, prevOutFetcher, inputIndex,
 		); err != nil {
 			return nil, fmt.Errorf("failed to execute arkade script: %w vin=%d", err, inputIndex)
 		}
 		log.Debugf("execution of %x succeeded", script.Script())
 
-		if err := s.signer.signInput(arkPtx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
-			return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
-		}
-
 		// search for checkpoint
 		inputTxid := arkPtx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint.Hash.String()
 		checkpointPtx, ok := indexedCheckpoints[inputTxid]
 		if !ok {
 			return nil, fmt.Errorf("checkpoint not found for input %d", inputIndex)
 		}
+		if err := validateCheckpoint(
+			checkpointPtx, arkPtx, inputIndex, server.checkpointTapscript,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := reservation.add(inputIndex); err != nil {
+			return nil, fmt.Errorf("%w vin=%d", err, inputIndex)
+		}
+		if err := signer.signInput(ctx, arkPtx, inputIndex, script.Hash(), prevOutFetcher); err != nil {
+			return nil, fmt.Errorf("failed to sign input %d: %w", inputIndex, err)
+		}
+		signatures = append(signatures, newSignature(SignatureKindArkTx, arkPtx, inputIndex, script.Hash()))
 
 		checkpointPrevoutFetcher, err := computePrevoutFetcher(checkpointPtx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create prevout fetcher for checkpoint: %w", err)
 		}
 
-		if err := s.signer.signInput(checkpointPtx, 0, script.Hash(), checkpointPrevoutFetcher); err != nil {
+		if err := signer.signInput(ctx, checkpointPtx, 0, script.Hash(), checkpointPrevoutFetcher); err != nil {
 			return nil, fmt.Errorf("failed to sign checkpoint input %d: %w", inputIndex, err)
 		}
+		signatures = append(signatures, newSignature(SignatureKindCheckpointTx, checkpointPtx, 0, script.Hash()))
 
 		if err = finalizerAcc.checkScript(entry.Vin, script); err != nil {
 			return nil, fmt.Errorf("failed to check script for finalizer: %w", err)
 		}
-
-		nSigned++
 	}
 
-	if nSigned == 0 {
+	if len(signatures) == 0 {
 		return nil, fmt.Errorf("failed to find any valid input/entry pairs")
 	}
+	arkTxid := arkPtx.UnsignedTx.TxID()
+	s.txJobs.update(arkTxid, TxStageExecuted, nil, false)
+
+	if err := s.journalSignatures(ctx, signatures); err != nil {
+		return nil, err
+	}
+	reservation.commit(ctx)
 
 	signedCheckpointTxs := make([]*psbt.Packet, 0, len(orderedCheckpointTxids))
 	for _, txid := range orderedCheckpointTxids {
 		signedCheckpointTxs = append(signedCheckpointTxs, indexedCheckpoints[txid])
 	}
+	signedTx := &OffchainTx{
+		ArkTx:       arkPtx,
+		Checkpoints: signedCheckpointTxs,
+	}
 
 	isFinalizer, err := finalizerAcc.isFinalizer()
 	if err != nil {
@@ -117,17 +178,21 @@ func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, err
 	}
 
 	log.WithField("is_finalizer", isFinalizer).Debug("finalizer role analysis completed")
+	s.metrics.ObserveFinalizerRole(isFinalizer)
 
 	if !isFinalizer {
-		return &OffchainTx{
-			ArkTx:       arkPtx,
-			Checkpoints: signedCheckpointTxs,
-		}, nil
+		s.saveSubmission(
+			ctx, SubmissionKindTx, arkTxid, SubmissionStatusSigned, arkPtx, signedCheckpointTxs, "",
+		)
+		s.txJobs.update(arkTxid, TxStageSigned, signedTx, true)
+		return signedTx, nil
 	}
+	s.txJobs.update(arkTxid, TxStageSigned, signedTx, false)
 
 	// we must verify that we have all the required checkpoint signatures before submitting to arkd
 	// otherwise, finalizing with arkd will fail later
-	if err = verifyNonArkdCheckpointSignatures(signedCheckpointTxs, s.arkdPubKey); err != nil {
+	server := router.server
+	if err = verifyNonArkdCheckpointSignatures(signedCheckpointTxs, server.pubKeys...); err != nil {
 		return nil, fmt.Errorf("failed to verify non-arkd signatures on checkpoints: %w", err)
 	}
 
@@ -145,8 +210,13 @@ func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, err
 		return nil, fmt.Errorf("failed to encode ark tx for finalization: %w", err)
 	}
 
-	txid, finalArkTx, arkdCheckpointTxs, err := s.arkdClient.SubmitTx(ctx, arkTx, encodedCheckpoints)
+	txid, finalArkTx, arkdCheckpointTxs, err := server.client.SubmitTx(ctx, arkTx, encodedCheckpoints)
 	if err != nil {
+		// arkd rejected the tx, our signatures can't be used to spend the outpoints anymore
+		failedTxids := append([]string{arkTxid}, orderedCheckpointTxids...)
+		if err := s.journal.MarkFailed(ctx, failedTxids...); err != nil {
+			log.WithError(err).Warn("failed to mark tx as failed in signing journal")
+		}
 		return nil, fmt.Errorf("failed to submit tx on arkd: %w", err)
 	}
 
@@ -177,33 +247,44 @@ func (s *service) SubmitTx(ctx context.Context, tx OffchainTx) (*OffchainTx, err
 
 	log.WithField("txid", txid).WithFields(log.Fields(logCheckpoints)).Info("finalizing tx")
 
-	// TODO: if retry fails, persist retry task in background queue
-	if err := s.retryFinalize(ctx, txid, finalEncodedCheckpoints); err != nil {
-		return nil, err
-	}
-
 	finalArkPtx, err := psbt.NewFromRawBytes(strings.NewReader(finalArkTx), true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode final ark tx: %w", err)
 	}
 
-	return &OffchainTx{
+	// arkd must not be called again on retry, cache the response before finalizing
+	s.saveSubmission(
+		ctx, SubmissionKindTx, txid, SubmissionStatusSubmitted, finalArkPtx, signedCheckpointTxs,
+		server.id,
+	)
+	finalTx := &OffchainTx{
 		ArkTx:       finalArkPtx,
 		Checkpoints: signedCheckpointTxs,
-	}, nil
+	}
+	s.txJobs.update(arkTxid, TxStageSubmitted, finalTx, false)
+
+	finalized, err := s.finalize(ctx, txid, server.id, finalEncodedCheckpoints)
+	if err != nil {
+		return nil, err
+	}
+	if finalized {
+		s.txJobs.update(arkTxid, TxStageFinalized, nil, true)
+	}
+
+	return finalTx, nil
 }
 
 type finalizerAccumulator struct {
-	arkdPubKeyXonly []byte
-	isLastByVin     map[uint16]bool
-	vins            []uint16
+	// current and deprecated arkd signer keys
+	arkdPubKeys []*btcec.PublicKey
+	isLastByVin map[uint16]bool
+	vins        []uint16
 }
 
-func newFinalizerAccumulator(arkdPubKey *btcec.PublicKey) *finalizerAccumulator {
-	arkdPubKeyXonly := schnorr.SerializePubKey(arkdPubKey)
+func newFinalizerAccumulator(arkdPubKeys ...*btcec.PublicKey) *finalizerAccumulator {
 	return &finalizerAccumulator{
-		arkdPubKeyXonly: arkdPubKeyXonly,
-		isLastByVin:     make(map[uint16]bool),
+		arkdPubKeys: arkdPubKeys,
+		isLastByVin: make(map[uint16]bool),
 	}
 }
 
@@ -221,7 +302,7 @@ func (a *finalizerAccumulator) checkScript(vin uint16, script *arkade.ArkadeScri
 	lastSignerXOnly := schnorr.SerializePubKey(lastSigner)
 
 	// if arkd is the last signer, check the second-to-last
-	if bytes.Equal(lastSignerXOnly, a.arkdPubKeyXonly) {
+	if containsPubKey(a.arkdPubKeys, lastSigner) {
 		lastNonArkdSigner := script.ClosurePubKeys()[nClosurePubKeys-2]
 		lastNonArkdSignerXonly := schnorr.SerializePubKey(lastNonArkdSigner)
 		a.isLastByVin[vin] = bytes.Equal(lastNonArkdSignerXonly, tweakedSignerPublicKeyXOnly)
@@ -253,7 +334,7 @@ func (a *finalizerAccumulator) isFinalizer() (bool, error) {
 	return referenceIsLast, nil
 }
 
-func verifyNonArkdCheckpointSignatures(checkpoints []*psbt.Packet, arkdPubKey *btcec.PublicKey) error {
+func verifyNonArkdCheckpointSignatures(checkpoints []*psbt.Packet, arkdPubKeys ...*btcec.PublicKey) error {
 	for checkpointIndex, ptx := range checkpoints {
 		if len(ptx.Inputs) == 0 || len(ptx.UnsignedTx.TxIn) == 0 {
 			return fmt.Errorf("checkpoint %d: missing input 0", checkpointIndex)
@@ -268,7 +349,7 @@ func verifyNonArkdCheckpointSignatures(checkpoints []*psbt.Packet, arkdPubKey *b
 			return fmt.Errorf("checkpoint %d: %w", checkpointIndex, err)
 		}
 		if _, err := script.VerifyTapscriptSigs(
-			ptx, prevoutFetcher, script.WithSkipPublicKeys(arkdPubKey),
+			ptx, prevoutFetcher, script.WithSkipPublicKeys(arkdPubKeys...),
 		); err != nil {
 			return fmt.Errorf("checkpoint %d: %w", checkpointIndex, err)
 		}
@@ -276,63 +357,18 @@ func verifyNonArkdCheckpointSignatures(checkpoints []*psbt.Packet, arkdPubKey *b
 	return nil
 }
 
-var finalizeRetryConfig = struct {
-	MinAttempts  int
-	InitialDelay time.Duration
-	MaxDelay     time.Duration
-	Multiplier   float64
-	Jitter       float64
-}{
-	MinAttempts:  10,
-	InitialDelay: 1 * time.Second,
-	MaxDelay:     10 * time.Second,
-	Multiplier:   2.0,
-	Jitter:       0.2, // + or - 20% randomness
-}
-
-func (s *service) retryFinalize(ctx context.Context, txid string, checkpoints []string) error {
-	// copy global to local for this retry run
-	retryConfig := finalizeRetryConfig
-	backoffDelay := retryConfig.InitialDelay
-	attempt := 0
-
-	for {
-		attempt++
-
-		if err := s.arkdClient.FinalizeTx(ctx, txid, checkpoints); err == nil {
-			return nil
-		} else {
-			log.WithField("txid", txid).WithField("attempt", attempt).Errorf("finalizing tx failed: %s", err)
-		}
-
-		delay := applyJitter(backoffDelay, retryConfig.Jitter)
-		backoffDelay = min(retryConfig.MaxDelay, backoffDelay*time.Duration(retryConfig.Multiplier))
-
-		// try a minimum number of times before respecting ctx.Done
-		if attempt < retryConfig.MinAttempts {
-			time.Sleep(delay)
-			continue
+// finalize submits the final checkpoints of a tx accepted by arkd, if it
+// doesn't succeed in time the queue keeps retrying in background and false
+// is returned
+func (s *service) finalize(
+	ctx context.Context, txid, arkdPubKey string, checkpoints []string,
+) (bool, error) {
+	if err := s.finalizations.Finalize(ctx, txid, arkdPubKey, checkpoints); err != nil {
+		if !errors.Is(err, ErrFinalizationQueued) {
+			return false, err
 		}
-
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("finalize retry cancelled after attempt %d: %w", attempt, ctx.Err())
-		case <-time.After(delay):
-		}
-	}
-}
-
-// applyJitter adds ±jitter randomness to a duration.
-// with jitter = 0.2, d get + or - 20%
-func applyJitter(d time.Duration, jitter float64) time.Duration {
-	if jitter <= 0 {
-		return d
-	}
-	if jitter >= 1.0 {
-		jitter = 0.999
+		log.WithError(err).WithField("txid", txid).Warn("finalization left to background queue")
+		return false, nil
 	}
-
-	randomFactor := 2.0*rand.Float64() - 1.0 // [-1, +1] factor
-	jitterFactor := 1.0 + jitter*randomFactor
-	return time.Duration(float64(d) * jitterFactor)
+	return true, nil
 }
@@ -0,0 +1,304 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrTxNotFound = errors.New("tx not found")
+
+type TxStage string
+
+const (
+	TxStageQueued   TxStage = "queued"
+	TxStageExecuted TxStage = "executed"
+	TxStageSigned   TxStage = "signed"
+	// the tx was accepted by arkd and is being finalized
+	TxStageSubmitted TxStage = "submitted"
+	TxStageFinalized TxStage = "finalized"
+	TxStageFailed    TxStage = "failed"
+)
+
+// TxStatus is the progress of a SubmitTx request
+type TxStatus struct {
+	Txid  string
+	Stage TxStage
+	// Done is set once the status won't change anymore
+	Done bool
+	// Error is the reason of a failure
+	Error string
+	// Tx is the signed tx, available from the signed stage
+	Tx        *OffchainTx
+	UpdatedAt int64
+}
+
+const (
+	// how long the status of a completed job is kept in memory, the signed
+	// and submitted txs remain available from the submission cache
+	txJobRetention = time.Hour
+	// how often the completed jobs past their retention are forgotten
+	txJobPurgeInterval = 10 * time.Minute
+	// how often a watcher checks the background finalization of a tx
+	txWatchInterval = time.Second
+)
+
+// txJobs tracks in memory the progress of the SubmitTxAsync requests, the
+// synchronous SubmitTx requests only update the jobs queued for their tx
+type txJobs struct {
+	lock sync.Mutex
+	jobs map[string]*txJob
+}
+
+type txJob struct {
+	status TxStatus
+	// closed and replaced on every update to wake up the watchers
+	updated chan struct{}
+}
+
+func newTxJobs() *txJobs {
+	return &txJobs{jobs: make(map[string]*txJob)}
+}
+
+func (j *txJobs) get(txid string) (TxStatus, <-chan struct{}, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	job, ok := j.jobs[txid]
+	if !ok {
+		return TxStatus{}, nil, false
+	}
+	return job.status, job.updated, true
+}
+
+// queue registers a new job, it returns false if one is already running or
+// completed successfully for the txid
+func (j *txJobs) queue(txid string) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	job, ok := j.jobs[txid]
+	if ok && job.status.Stage != TxStageFailed {
+		return false
+	}
+	if !ok {
+		j.jobs[txid] = &txJob{updated: make(chan struct{})}
+	}
+	j.set(TxStatus{Txid: txid, Stage: TxStageQueued})
+	return true
+}
+
+func (j *txJobs) update(txid string, stage TxStage, tx *OffchainTx, done bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	status := TxStatus{Txid: txid, Stage: stage, Done: done, Tx: tx}
+	if job, ok := j.jobs[txid]; ok && tx == nil {
+		status.Tx = job.status.Tx
+	}
+	j.set(status)
+}
+
+func (j *txJobs) fail(txid string, err error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	status := TxStatus{Txid: txid, Stage: TxStageFailed, Done: true, Error: err.Error()}
+	if job, ok := j.jobs[txid]; ok && job.status.Stage == TxStageSubmitted {
+		// the tx was accepted by arkd, only its finalization failed
+		status = job.status
+		status.Done = true
+		status.Error = err.Error()
+	}
+	j.set(status)
+}
+
+func (j *txJobs) remove(txid string) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if job, ok := j.jobs[txid]; ok {
+		close(job.updated)
+		delete(j.jobs, txid)
+	}
+}
+
+// set updates the job of the tx if any, it must be called with the lock held
+func (j *txJobs) set(status TxStatus) {
+	job, ok := j.jobs[status.Txid]
+	if !ok {
+		return
+	}
+	status.UpdatedAt = time.Now().Unix()
+	job.status = status
+	close(job.updated)
+	job.updated = make(chan struct{})
+}
+
+// purge forgets the jobs completed before the given time
+func (j *txJobs) purge(before time.Time) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for txid, job := range j.jobs {
+		if job.status.Done && job.status.UpdatedAt < before.Unix() {
+			close(job.updated)
+			delete(j.jobs, txid)
+		}
+	}
+}
+
+// purgeTxJobs periodically forgets the jobs completed a while ago until the
+// service is closed
+func (s *service) purgeTxJobs() {
+	ticker := time.NewTicker(txJobPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.txJobs.purge(time.Now().Add(-txJobRetention))
+		}
+	}
+}
+
+// SubmitTxAsync starts processing the tx in background and returns its
+// txid, to be used with GetTxStatus and WatchTx
+func (s *service) SubmitTxAsync(_ context.Context, tx OffchainTx) (string, error) {
+	// fail early if we can't sign
+	signers, err := s.getKeyring()
+	if err != nil {
+		return "", err
+	}
+	signers.zero()
+
+	txid := tx.ArkTx.UnsignedTx.TxID()
+	if !s.txJobs.queue(txid) {
+		return txid, nil
+	}
+
+	go func() {
+		if _, err := s.SubmitTx(s.ctx, tx); err != nil {
+			log.WithError(err).WithField("txid", txid).Warn("async tx submission failed")
+		}
+	}()
+
+	return txid, nil
+}
+
+func (s *service) GetTxStatus(ctx context.Context, txid string) (*TxStatus, error) {
+	if status, _, ok := s.txJobs.get(txid); ok {
+		if status.Stage == TxStageSubmitted && !status.Done {
+			if err := s.refineFinalizationStatus(ctx, &status); err != nil {
+				return nil, err
+			}
+		}
+		return &status, nil
+	}
+
+	// the job may be gone from memory, the outcome of successful
+	// submissions is still in the cache
+	submission, err := s.getSubmission(ctx, SubmissionKindTx, txid)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, ErrTxNotFound
+	}
+
+	tx, err := decodeSubmittedTx(submission)
+	if err != nil {
+		return nil, err
+	}
+	status := &TxStatus{
+		Txid:      txid,
+		Stage:     TxStageSigned,
+		Done:      true,
+		Tx:        tx,
+		UpdatedAt: submission.CreatedAt,
+	}
+	if submission.Status == SubmissionStatusSubmitted {
+		status.Stage = TxStageSubmitted
+		status.Done = false
+		if err := s.refineFinalizationStatus(ctx, status); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// WatchTx sends the status of the tx every time it changes, the channel is
+// closed once the status is done or ctx is cancelled
+func (s *service) WatchTx(ctx context.Context, txid string) (<-chan TxStatus, error) {
+	status, err := s.GetTxStatus(ctx, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan TxStatus)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(txWatchInterval)
+		defer ticker.Stop()
+
+		last := *status
+		for {
+			select {
+			case ch <- last:
+			case <-ctx.Done():
+				return
+			}
+			if last.Done {
+				return
+			}
+
+			for {
+				// the background finalization doesn't notify, poll meanwhile
+				_, updated, _ := s.txJobs.get(txid)
+				select {
+				case <-ctx.Done():
+					return
+				case <-updated:
+				case <-ticker.C:
+				}
+
+				next, err := s.GetTxStatus(ctx, txid)
+				if err != nil {
+					log.WithError(err).WithField("txid", txid).Warn("failed to get tx status")
+					return
+				}
+				if next.Stage != last.Stage || next.Done != last.Done || next.Error != last.Error {
+					last = *next
+					break
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// refineFinalizationStatus looks up the finalization of a submitted tx
+func (s *service) refineFinalizationStatus(ctx context.Context, status *TxStatus) error {
+	finalization, err := s.finalizations.Get(ctx, status.Txid)
+	if err != nil {
+		if errors.Is(err, ErrFinalizationNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to read finalization: %w", err)
+	}
+
+	switch finalization.Status {
+	case FinalizationStatusFinalized:
+		status.Stage = TxStageFinalized
+		status.Done = true
+	case FinalizationStatusStuck:
+		status.Done = true
+		status.Error = fmt.Sprintf("%s: %s", ErrFinalizationStuck, finalization.LastError)
+	}
+	if finalization.UpdatedAt > status.UpdatedAt {
+		status.UpdatedAt = finalization.UpdatedAt
+	}
+	return nil
+}
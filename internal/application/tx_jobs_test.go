@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxJobs(t *testing.T) {
+	t.Run("queue once", func(t *testing.T) {
+		jobs := newTxJobs()
+		require.True(t, jobs.queue("txid"))
+		require.False(t, jobs.queue("txid"))
+
+		// a failed job can be submitted again
+		jobs.fail("txid", errors.New("boom"))
+		status, _, ok := jobs.get("txid")
+		require.True(t, ok)
+		require.Equal(t, TxStageFailed, status.Stage)
+		require.True(t, status.Done)
+		require.Equal(t, "boom", status.Error)
+		require.True(t, jobs.queue("txid"))
+	})
+
+	t.Run("only queued jobs", func(t *testing.T) {
+		// the synchronous submissions are not tracked
+		jobs := newTxJobs()
+		jobs.update("txid", TxStageExecuted, nil, false)
+		jobs.fail("txid", errors.New("boom"))
+		_, _, ok := jobs.get("txid")
+		require.False(t, ok)
+	})
+
+	t.Run("purge completed jobs", func(t *testing.T) {
+		jobs := newTxJobs()
+		require.True(t, jobs.queue("done"))
+		jobs.update("done", TxStageSigned, nil, true)
+		require.True(t, jobs.queue("running"))
+
+		jobs.purge(time.Now())
+		_, _, ok := jobs.get("done")
+		require.True(t, ok)
+
+		jobs.purge(time.Now().Add(time.Hour))
+		_, _, ok = jobs.get("done")
+		require.False(t, ok)
+		_, _, ok = jobs.get("running")
+		require.True(t, ok)
+	})
+
+	t.Run("fail after submission", func(t *testing.T) {
+		jobs := newTxJobs()
+		tx := &OffchainTx{}
+		require.True(t, jobs.queue("txid"))
+		jobs.update("txid", TxStageSubmitted, tx, false)
+		jobs.fail("txid", ErrFinalizationStuck)
+
+		status, _, _ := jobs.get("txid")
+		require.Equal(t, TxStageSubmitted, status.Stage)
+		require.True(t, status.Done)
+		require.Equal(t, ErrFinalizationStuck.Error(), status.Error)
+		require.Same(t, tx, status.Tx)
+	})
+
+	t.Run("notify updates", func(t *testing.T) {
+		jobs := newTxJobs()
+		require.True(t, jobs.queue("txid"))
+		jobs.update("txid", TxStageExecuted, nil, false)
+		_, updated, _ := jobs.get("txid")
+
+		jobs.update("txid", TxStageSigned, nil, true)
+		select {
+		case <-updated:
+		default:
+			t.Fatal("update not notified")
+		}
+
+		_, updated, _ = jobs.get("txid")
+		jobs.remove("txid")
+		select {
+		case <-updated:
+		default:
+			t.Fatal("removal not notified")
+		}
+		_, _, ok := jobs.get("txid")
+		require.False(t, ok)
+	})
+}
+
+func TestGetTxStatus(t *testing.T) {
+	newService := func(t *testing.T) (*service, *mockFinalizationStore) {
+		t.Helper()
+		store := newMockFinalizationStore()
+		return &service{
+			finalizations: newTestFinalizationQueue(t, &mockArkdClient{}, store),
+			submissions:   newMockSubmissionStore(),
+			txJobs:        newTxJobs(),
+		}, store
+	}
+
+	t.Run("unknown tx", func(t *testing.T) {
+		svc, _ := newService(t)
+		_, err := svc.GetTxStatus(t.Context(), "txid")
+		require.ErrorIs(t, err, ErrTxNotFound)
+	})
+
+	t.Run("finalized in background", func(t *testing.T) {
+		svc, store := newService(t)
+		require.True(t, svc.txJobs.queue("txid"))
+		svc.txJobs.update("txid", TxStageSubmitted, nil, false)
+
+		status, err := svc.GetTxStatus(t.Context(), "txid")
+		require.NoError(t, err)
+		require.Equal(t, TxStageSubmitted, status.Stage)
+		require.False(t, status.Done)
+
+		require.NoError(t, store.Upsert(t.Context(), Finalization{
+			Txid:   "txid",
+			Status: FinalizationStatusFinalized,
+		}))
+		status, err = svc.GetTxStatus(t.Context(), "txid")
+		require.NoError(t, err)
+		require.Equal(t, TxStageFinalized, status.Stage)
+		require.True(t, status.Done)
+	})
+
+	t.Run("from submission cache", func(t *testing.T) {
+		svc, _ := newService(t)
+		arkTx := newTestPsbt(t, 0)
+		require.NoError(t, svc.submissions.Add(t.Context(), Submission{
+			Id:     "txid",
+			Kind:   SubmissionKindTx,
+			Status: SubmissionStatusSigned,
+			Tx:     arkTx,
+		}))
+
+		status, err := svc.GetTxStatus(t.Context(), "txid")
+		require.NoError(t, err)
+		require.Equal(t, TxStageSigned, status.Stage)
+		require.True(t, status.Done)
+		gotArkTx, err := status.Tx.ArkTx.B64Encode()
+		require.NoError(t, err)
+		require.Equal(t, arkTx, gotArkTx)
+	})
+
+	t.Run("watch until done", func(t *testing.T) {
+		svc, _ := newService(t)
+		require.True(t, svc.txJobs.queue("txid"))
+		svc.txJobs.update("txid", TxStageExecuted, nil, false)
+
+		updates, err := svc.WatchTx(t.Context(), "txid")
+		require.NoError(t, err)
+		require.Equal(t, TxStageExecuted, (<-updates).Stage)
+
+		svc.txJobs.update("txid", TxStageSigned, nil, true)
+		select {
+		case status := <-updates:
+			require.Equal(t, TxStageSigned, status.Stage)
+			require.True(t, status.Done)
+		case <-time.After(time.Second):
+			t.Fatal("update not received")
+		}
+
+		_, ok := <-updates
+		require.False(t, ok)
+	})
+}
+
+type mockSubmissionStore struct {
+	submissions map[string]Submission
+}
+
+func newMockSubmissionStore() *mockSubmissionStore {
+	return &mockSubmissionStore{submissions: make(map[string]Submission)}
+}
+
+func (m *mockSubmissionStore) Add(_ context.Context, submission Submission) error {
+	m.submissions[string(submission.Kind)+":"+submission.Id] = submission
+	return nil
+}
+
+func (m *mockSubmissionStore) Get(_ context.Context, kind SubmissionKind, id string) (*Submission, error) {
+	submission, ok := m.submissions[string(kind)+":"+id]
+	if !ok {
+		return nil, ErrSubmissionNotFound
+	}
+	return &submission, nil
+}
+
+func (m *mockSubmissionStore) Close() {}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/ArkLabsHQ/introspector/pkg/arkade"
 	"github.com/arkade-os/arkd/pkg/ark-lib/extension"
@@ -388,6 +387,9 @@ func TestVerifyCheckpointSignatures(t *testing.T) {
 }
 
 type mockArkdClient struct {
+	info             *sdkclient.Info
+	infoErr          error
+	closed           bool
 	finalizeErrs     []error
 	finalizeCalls    int
 	finalizeTxids    []string
@@ -395,7 +397,13 @@ type mockArkdClient struct {
 }
 
 func (m *mockArkdClient) GetInfo(context.Context) (*sdkclient.Info, error) {
-	panic("unexpected call to GetInfo")
+	if m.infoErr != nil {
+		return nil, m.infoErr
+	}
+	if m.info == nil {
+		panic("unexpected call to GetInfo")
+	}
+	return m.info, nil
 }
 func (m *mockArkdClient) RegisterIntent(context.Context, string, string) (string, error) {
 	return "", fmt.Errorf("not implemented")
@@ -447,67 +455,4 @@ func (m *mockArkdClient) ModifyStreamTopics(context.Context, []string, []string)
 func (m *mockArkdClient) OverwriteStreamTopics(context.Context, []string) ([]string, []string, []string, error) {
 	return nil, nil, nil, fmt.Errorf("not implemented")
 }
-func (m *mockArkdClient) Close() {}
-
-func TestRetryFinalize(t *testing.T) {
-	originalCfg := finalizeRetryConfig
-	finalizeRetryConfig.MinAttempts = 3
-	finalizeRetryConfig.InitialDelay = 10 * time.Millisecond
-	finalizeRetryConfig.Jitter = 0
-	finalizeRetryConfig.Multiplier = 1
-	t.Cleanup(func() {
-		finalizeRetryConfig = originalCfg
-	})
-
-	t.Run("success after retries", func(t *testing.T) {
-		client := &mockArkdClient{
-			finalizeErrs: []error{
-				fmt.Errorf("retry 1"),
-				fmt.Errorf("retry 2"),
-				nil,
-			},
-		}
-		svc := &service{arkdClient: client}
-		checkpoints := []string{"checkpoint-a", "checkpoint-b"}
-		err := svc.retryFinalize(
-			t.Context(),
-			"txid-123",
-			checkpoints,
-		)
-		require.NoError(t, err)
-		require.Equal(t, 3, client.finalizeCalls)
-		require.Equal(t, []string{"txid-123", "txid-123", "txid-123"}, client.finalizeTxids)
-		require.Equal(t, [][]string{
-			{"checkpoint-a", "checkpoint-b"},
-			{"checkpoint-a", "checkpoint-b"},
-			{"checkpoint-a", "checkpoint-b"},
-		}, client.finalizePayloads)
-	})
-	t.Run("exhausts minimum retries", func(t *testing.T) {
-		client := &mockArkdClient{
-			finalizeErrs: []error{
-				fmt.Errorf("retry 1"),
-				fmt.Errorf("retry 2"),
-				fmt.Errorf("retry 3"),
-				fmt.Errorf("retry 4"),
-			},
-		}
-		svc := &service{arkdClient: client}
-		ctx, cancel := context.WithCancel(t.Context())
-		// simulates client hangup
-		cancel()
-		err := svc.retryFinalize(
-			ctx,
-			"txid-123",
-			[]string{"checkpoint-a"},
-		)
-		require.ErrorContains(t, err, "context canceled")
-		require.Equal(t, 3, client.finalizeCalls)
-		require.Equal(t, []string{"txid-123", "txid-123", "txid-123"}, client.finalizeTxids)
-		require.Equal(t, [][]string{
-			{"checkpoint-a"},
-			{"checkpoint-a"},
-			{"checkpoint-a"},
-		}, client.finalizePayloads)
-	})
-}
+func (m *mockArkdClient) Close() { m.closed = true }
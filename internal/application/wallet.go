@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+var (
+	ErrKeystoreNotInitialized     = errors.New("keystore not initialized")
+	ErrKeystoreAlreadyInitialized = errors.New("keystore already initialized")
+	ErrKeystoreLocked             = errors.New("keystore is locked")
+	ErrInvalidPassword            = errors.New("invalid password")
+	ErrSignerKeyNotFound          = errors.New("signer key not found")
+	ErrSignerKeyAlreadyExists     = errors.New("signer key already in keystore")
+	ErrCurrentSignerKey           = errors.New("cannot retire the current signer key")
+)
+
+// SignerKey describes a key held by the keystore.
+// The current key is the one advertised to new users, the others are
+// deprecated but keep signing for the VTXOs bound to them until SunsetAt.
+type SignerKey struct {
+	PublicKey *btcec.PublicKey
+	CreatedAt int64
+	// unix timestamp after which the key stops signing, 0 if not retired
+	SunsetAt int64
+	Current  bool
+}
+
+// IsActive returns false once the key is past its sunset date
+func (k SignerKey) IsActive(now time.Time) bool {
+	return k.SunsetAt <= 0 || now.Unix() < k.SunsetAt
+}
+
+// Keystore holds the signer secret keys encrypted at rest.
+// The public keys are available as soon as the keystore is initialized,
+// the secret keys only while it is unlocked.
+type Keystore interface {
+	IsInitialized() bool
+	IsLocked() bool
+	// SignerKeys returns the current key first, then the deprecated ones
+	// from the most recent to the oldest.
+	SignerKeys() ([]SignerKey, error)
+	// SecretKeys returns copies of the secret keys in the same order as
+	// SignerKeys, Lock doesn't wipe them: the caller zeroes them once done.
+	SecretKeys() ([]*btcec.PrivateKey, error)
+	// Create encrypts the given secret key with the password, a new key is
+	// generated if nil. The keystore is left unlocked.
+	Create(password string, secretKey *btcec.PrivateKey) error
+	// AddKey adds a new current key, generated if nil. The previous current
+	// key becomes deprecated but keeps signing. Requires the keystore to be
+	// unlocked.
+	AddKey(password string, secretKey *btcec.PrivateKey) error
+	// RetireKey sets the sunset date of a deprecated key, 0 means now.
+	RetireKey(pubkey *btcec.PublicKey, sunsetAt int64) error
+	Unlock(password string) error
+	Lock() error
+	ChangePassword(currentPassword, newPassword string) error
+}
+
+type WalletStatus struct {
+	Initialized     bool
+	Unlocked        bool
+	SignerPublicKey string
+}
+
+// WalletService exposes the keystore management operations to operators.
+type WalletService interface {
+	GetStatus(context.Context) (*WalletStatus, error)
+	Create(ctx context.Context, password string, secretKey *btcec.PrivateKey) (string, error)
+	Unlock(ctx context.Context, password string) error
+	Lock(context.Context) error
+	ChangePassword(ctx context.Context, currentPassword, newPassword string) error
+	ListSignerKeys(context.Context) ([]SignerKey, error)
+	AddSignerKey(ctx context.Context, password string, secretKey *btcec.PrivateKey) (string, error)
+	RetireSignerKey(ctx context.Context, pubkey *btcec.PublicKey, sunsetAt int64) error
+}
+
+type walletService struct {
+	keystore Keystore
+}
+
+func NewWalletService(keystore Keystore) WalletService {
+	return &walletService{keystore}
+}
+
+func (w *walletService) GetStatus(_ context.Context) (*WalletStatus, error) {
+	status := &WalletStatus{
+		Initialized: w.keystore.IsInitialized(),
+	}
+	if !status.Initialized {
+		return status, nil
+	}
+
+	pubkey, err := w.currentPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	status.Unlocked = !w.keystore.IsLocked()
+	status.SignerPublicKey = pubkey
+	return status, nil
+}
+
+func (w *walletService) Create(
+	_ context.Context, password string, secretKey *btcec.PrivateKey,
+) (string, error) {
+	if err := w.keystore.Create(password, secretKey); err != nil {
+		return "", err
+	}
+	return w.currentPublicKey()
+}
+
+func (w *walletService) Unlock(_ context.Context, password string) error {
+	return w.keystore.Unlock(password)
+}
+
+func (w *walletService) Lock(_ context.Context) error {
+	return w.keystore.Lock()
+}
+
+func (w *walletService) ChangePassword(_ context.Context, currentPassword, newPassword string) error {
+	return w.keystore.ChangePassword(currentPassword, newPassword)
+}
+
+func (w *walletService) ListSignerKeys(_ context.Context) ([]SignerKey, error) {
+	return w.keystore.SignerKeys()
+}
+
+func (w *walletService) AddSignerKey(
+	_ context.Context, password string, secretKey *btcec.PrivateKey,
+) (string, error) {
+	if err := w.keystore.AddKey(password, secretKey); err != nil {
+		return "", err
+	}
+	return w.currentPublicKey()
+}
+
+func (w *walletService) RetireSignerKey(
+	_ context.Context, pubkey *btcec.PublicKey, sunsetAt int64,
+) error {
+	return w.keystore.RetireKey(pubkey, sunsetAt)
+}
+
+func (w *walletService) currentPublicKey() (string, error) {
+	signerKeys, err := w.keystore.SignerKeys()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(signerKeys[0].PublicKey.SerializeCompressed()), nil
+}
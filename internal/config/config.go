@@ -4,23 +4,87 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/ArkLabsHQ/introspector/internal/application"
+	badgerdb "github.com/ArkLabsHQ/introspector/internal/infrastructure/db/badger"
+	"github.com/ArkLabsHQ/introspector/internal/infrastructure/keystore"
+	"github.com/ArkLabsHQ/introspector/internal/infrastructure/metrics"
+	"github.com/ArkLabsHQ/introspector/internal/infrastructure/tracing"
 	arklib "github.com/arkade-os/arkd/pkg/ark-lib"
+	"github.com/arkade-os/go-sdk/client"
+	grpcclient "github.com/arkade-os/go-sdk/client/grpc"
 	"github.com/btcsuite/btcd/btcec/v2"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
+	"github.com/spf13/pflag"
 )
 
+const envPrefix = "INTROSPECTOR"
+
 const (
-	SecretKey       = "SECRET_KEY"
-	Datadir         = "DATADIR"
-	Port            = "PORT"
-	NoTLS           = "NO_TLS"
-	TLSExtraIPs     = "TLS_EXTRA_IPS"
-	TLSExtraDomains = "TLS_EXTRA_DOMAINS"
-	LogLevel        = "LOG_LEVEL"
-	ArkdURL         = "ARKD_URL"
+	// toml or yaml config file, introspector.toml, .yaml or .yml in DATADIR by default
+	ConfigFile       = "CONFIG"
+	SecretKey        = "SECRET_KEY"
+	UnlockerPassword = "UNLOCKER_PASSWORD"
+	Datadir          = "DATADIR"
+	Port             = "PORT"
+	NoTLS            = "NO_TLS"
+	TLSExtraIPs      = "TLS_EXTRA_IPS"
+	TLSExtraDomains  = "TLS_EXTRA_DOMAINS"
+	// named log level, the logrus numbers are still accepted
+	LogLevel = "LOG_LEVEL"
+	// text or json
+	LogFormat       = "LOG_FORMAT"
+	NoMacaroons     = "NO_MACAROONS"
+	RestrictSigning = "RESTRICT_SIGNING"
+	// port of the prometheus metrics endpoint, 0 to disable it
+	MetricsPort = "METRICS_PORT"
+	// listener of the wallet and admin services, they're served on PORT if 0,
+	// which requires macaroons
+	AdminPort = "ADMIN_PORT"
+	AdminHost = "ADMIN_HOST"
+	// host:port of the OTLP gRPC collector the traces are exported to, empty to disable it
+	OtlpEndpoint = "OTLP_ENDPOINT"
+	OtlpInsecure = "OTLP_INSECURE"
+	// comma-separated urls of the served arkd instances, the first is the default one
+	ArkdURL = "ARKD_URL"
+	// refresh of the info of the served arkd instances
+	ArkdRefreshInterval = "ARKD_REFRESH_INTERVAL"
+	ArkdMaxRetryDelay   = "ARKD_MAX_RETRY_DELAY"
+	// if enabled, refuse to sign a spend of an outpoint already signed for a different txid
+	EquivocationProtection = "EQUIVOCATION_PROTECTION"
+	// backoff of the finalization of the txs submitted to arkd
+	FinalizeMinAttempts  = "FINALIZE_MIN_ATTEMPTS"
+	FinalizeMaxAttempts  = "FINALIZE_MAX_ATTEMPTS"
+	FinalizeInitialDelay = "FINALIZE_INITIAL_DELAY"
+	FinalizeMaxDelay     = "FINALIZE_MAX_DELAY"
+	FinalizeMultiplier   = "FINALIZE_MULTIPLIER"
+	FinalizeJitter       = "FINALIZE_JITTER"
+	// built-in solver refreshing the registered delegate vtxos in batches
+	DelegateSolver         = "DELEGATE_SOLVER"
+	DelegateRefreshWindow  = "DELEGATE_REFRESH_WINDOW"
+	DelegateSessionTimeout = "DELEGATE_SESSION_TIMEOUT"
+	DelegateMaxAttempts    = "DELEGATE_MAX_ATTEMPTS"
+	DelegateRetryDelay     = "DELEGATE_RETRY_DELAY"
+	// cosign the vtxo trees of the intents listing a key of the introspector
+	TreeCosigner        = "TREE_COSIGNER"
+	TreeCosignerTimeout = "TREE_COSIGNER_TIMEOUT"
+	// pending finalizations above which the introspector is not ready, 0 for unlimited
+	HealthMaxPendingFinalizations = "HEALTH_MAX_PENDING_FINALIZATIONS"
+	// json signing policy loaded at startup and by the ReloadPolicy admin rpc
+	PolicyFile = "POLICY_FILE"
+	// size in bytes of the grpc messages and of the rest request bodies
+	MaxMsgSize = "MAX_MSG_SIZE"
+	// per request limits, 0 for unlimited
+	MaxCheckpoints = "MAX_CHECKPOINTS"
+	MaxForfeits    = "MAX_FORFEITS"
+	MaxTreeNodes   = "MAX_TREE_NODES"
+	MaxScriptWork  = "MAX_SCRIPT_WORK"
+	// scripts of the registry, 0 for unlimited
+	MaxRegisteredScripts = "MAX_REGISTERED_SCRIPTS"
+	// requests per second and burst allowed to a client, 0 to disable the rate limiting
+	RateLimit = "RATE_LIMIT"
+	RateBurst = "RATE_BURST"
 )
 
 var (
@@ -30,57 +94,587 @@ var (
 	defaultTLSExtraIPs     = []string{}
 	defaultTLSExtraDomains = []string{}
 	defaultLogLevel        = log.DebugLevel
+	defaultLogFormat       = "text"
+	defaultMetricsPort     = uint32(0)
+	defaultAdminPort       = uint32(7074)
+	defaultAdminHost       = "127.0.0.1"
+	defaultNoMacaroons     = false
+	defaultRestrictSigning = false
+
+	defaultEquivocationProtection = false
+
+	defaultMaxMsgSize = 4 * 1024 * 1024
+	defaultRateLimit  = float64(0)
+	defaultRateBurst  = 20
 )
 
 type Config struct {
-	SecretKey       *btcec.PrivateKey
-	Datadir         string
-	Port            uint32
-	NoTLS           bool
-	TLSExtraIPs     []string
-	TLSExtraDomains []string
-	ArkdURL         string
-}
-
-func LoadConfig() (*Config, error) {
-	viper.SetEnvPrefix("INTROSPECTOR")
-	viper.AutomaticEnv()
+	// ConfigFile is the config file that was read, empty if none
+	ConfigFile string
+	LogFormat  string
+	// SecretKey is optional, if set it is imported in the keystore at first startup
+	SecretKey *btcec.PrivateKey
+	// UnlockerPassword is optional, if set the keystore is created (if needed)
+	// and unlocked at startup
+	UnlockerPassword string
+	Datadir          string
+	Port             uint32
+	NoTLS            bool
+	TLSExtraIPs      []string
+	TLSExtraDomains  []string
+	NoMacaroons      bool
+	RestrictSigning  bool
+	MetricsPort      uint32
+	AdminPort        uint32
+	AdminHost        string
+	TracingConfig    tracing.Config
+	ArkdURLs         []string
+	// EquivocationProtection refuses conflicting spends of journaled outpoints
+	EquivocationProtection bool
+	ArkdWatcherConfig      application.ArkdWatcherConfig
+	FinalizeRetryConfig    application.FinalizeRetryConfig
+	DelegateSolverConfig   application.DelegateSolverConfig
+	TreeCosignerConfig     application.TreeCosignerConfig
+	HealthConfig           application.HealthConfig
+	// PolicyFile is optional, signing starts with an empty policy if not set
+	PolicyFile    string
+	MaxMsgSize    int
+	RequestLimits application.RequestLimits
+	// RateLimit is in requests per second per client, 0 if disabled
+	RateLimit float64
+	RateBurst int
 
-	viper.SetDefault(Datadir, defaultDatadir)
-	viper.SetDefault(Port, defaultPort)
-	viper.SetDefault(NoTLS, defaultNoTLS)
-	viper.SetDefault(TLSExtraIPs, defaultTLSExtraIPs)
-	viper.SetDefault(TLSExtraDomains, defaultTLSExtraDomains)
-	viper.SetDefault(LogLevel, defaultLogLevel)
+	keystore      application.Keystore
+	metrics       *metrics.Prometheus
+	arkServers    *application.ArkServers
+	finalizations application.FinalizationQueue
+	intents       application.IntentStore
+	journal       application.SigningJournal
+	guard         *application.SigningGuard
+}
 
-	secretKeyHex := viper.GetString(SecretKey)
-	secretKeyBytes, err := hex.DecodeString(secretKeyHex)
+// LoadConfig reads the options from the flags, if registered with
+// RegisterFlags, the INTROSPECTOR_* env vars and the config file, in that
+// order of precedence. flags may be nil.
+func LoadConfig(flags *pflag.FlagSet) (*Config, error) {
+	v, err := newViper(flags)
 	if err != nil {
-		return nil, fmt.Errorf("invalid secret key: %w", err)
+		return nil, err
 	}
-	secretKey, _ := btcec.PrivKeyFromBytes(secretKeyBytes)
-	if secretKey == nil {
-		return nil, fmt.Errorf("invalid secret key")
+	l := &loader{v: v}
+
+	var secretKey *btcec.PrivateKey
+	if secretKeyHex := l.string(SecretKey); secretKeyHex != "" {
+		secretKeyBytes, err := hex.DecodeString(secretKeyHex)
+		if err != nil {
+			l.fail(SecretKey, err)
+		} else if secretKey, _ = btcec.PrivKeyFromBytes(secretKeyBytes); secretKey == nil {
+			l.fail(SecretKey, fmt.Errorf("not a private key"))
+		}
 	}
 
-	logLevel := viper.GetInt(LogLevel)
-	log.SetLevel(log.Level(logLevel))
+	log.SetLevel(l.logLevel())
+	log.SetFormatter(l.logFormatter())
 
 	cfg := &Config{
-		SecretKey:       secretKey,
-		Datadir:         viper.GetString(Datadir),
-		Port:            viper.GetUint32(Port),
-		NoTLS:           viper.GetBool(NoTLS),
-		TLSExtraIPs:     viper.GetStringSlice(TLSExtraIPs),
-		TLSExtraDomains: viper.GetStringSlice(TLSExtraDomains),
-		ArkdURL:         viper.GetString(ArkdURL),
+		ConfigFile:       l.string(ConfigFile),
+		LogFormat:        l.string(LogFormat),
+		SecretKey:        secretKey,
+		UnlockerPassword: l.string(UnlockerPassword),
+		Datadir:          l.string(Datadir),
+		Port:             l.uint32(Port),
+		NoTLS:            l.bool(NoTLS),
+		TLSExtraIPs:      l.strings(TLSExtraIPs),
+		TLSExtraDomains:  l.strings(TLSExtraDomains),
+		NoMacaroons:      l.bool(NoMacaroons),
+		RestrictSigning:  l.bool(RestrictSigning),
+		MetricsPort:      l.uint32(MetricsPort),
+		AdminPort:        l.uint32(AdminPort),
+		AdminHost:        l.string(AdminHost),
+		TracingConfig: tracing.Config{
+			Endpoint: l.string(OtlpEndpoint),
+			Insecure: l.bool(OtlpInsecure),
+		},
+		ArkdURLs: l.strings(ArkdURL),
+
+		EquivocationProtection: l.bool(EquivocationProtection),
+		ArkdWatcherConfig: application.ArkdWatcherConfig{
+			RefreshInterval: l.duration(ArkdRefreshInterval),
+			MaxRetryDelay:   l.duration(ArkdMaxRetryDelay),
+		},
+		FinalizeRetryConfig: application.FinalizeRetryConfig{
+			MinAttempts:  l.int(FinalizeMinAttempts),
+			MaxAttempts:  l.int(FinalizeMaxAttempts),
+			InitialDelay: l.duration(FinalizeInitialDelay),
+			MaxDelay:     l.duration(FinalizeMaxDelay),
+			Multiplier:   l.float64(FinalizeMultiplier),
+			Jitter:       l.float64(FinalizeJitter),
+		},
+		DelegateSolverConfig: application.DelegateSolverConfig{
+			Enabled:        l.bool(DelegateSolver),
+			RefreshWindow:  l.duration(DelegateRefreshWindow),
+			SessionTimeout: l.duration(DelegateSessionTimeout),
+			MaxAttempts:    l.int(DelegateMaxAttempts),
+			RetryDelay:     l.duration(DelegateRetryDelay),
+		},
+		TreeCosignerConfig: application.TreeCosignerConfig{
+			Enabled:        l.bool(TreeCosigner),
+			SessionTimeout: l.duration(TreeCosignerTimeout),
+		},
+		HealthConfig: application.HealthConfig{
+			MaxPendingFinalizations: l.int(HealthMaxPendingFinalizations),
+		},
+		PolicyFile: l.string(PolicyFile),
+		MaxMsgSize: l.int(MaxMsgSize),
+		RequestLimits: application.RequestLimits{
+			MaxCheckpoints:       l.int(MaxCheckpoints),
+			MaxForfeits:          l.int(MaxForfeits),
+			MaxTreeNodes:         l.int(MaxTreeNodes),
+			MaxScriptWork:        l.int(MaxScriptWork),
+			MaxRegisteredScripts: l.int(MaxRegisteredScripts),
+		},
+		RateLimit: l.float64(RateLimit),
+		RateBurst: l.int(RateBurst),
+	}
+	if err := l.err(); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.ArkdURLs) == 0 {
+		return nil, fmt.Errorf("missing %s", describe(ArkdURL))
+	}
+	if cfg.SecretKey != nil && cfg.UnlockerPassword == "" {
+		return nil, fmt.Errorf(
+			"importing the secret key requires the %s", describe(UnlockerPassword),
+		)
+	}
+	if cfg.ArkdWatcherConfig.RefreshInterval <= 0 || cfg.ArkdWatcherConfig.MaxRetryDelay <= 0 {
+		return nil, fmt.Errorf(
+			"%s and %s must be positive",
+			describe(ArkdRefreshInterval), describe(ArkdMaxRetryDelay),
+		)
+	}
+	if err := validateFinalizeRetryConfig(cfg.FinalizeRetryConfig); err != nil {
+		return nil, err
+	}
+	if err := validateDelegateSolverConfig(cfg.DelegateSolverConfig); err != nil {
+		return nil, err
+	}
+	if cfg.TreeCosignerConfig.Enabled && cfg.TreeCosignerConfig.SessionTimeout <= 0 {
+		return nil, fmt.Errorf("%s must be positive", describe(TreeCosignerTimeout))
+	}
+	if cfg.HealthConfig.MaxPendingFinalizations < 0 {
+		return nil, fmt.Errorf("%s must not be negative", describe(HealthMaxPendingFinalizations))
+	}
+	if cfg.MaxMsgSize <= 0 {
+		return nil, fmt.Errorf("%s must be positive", describe(MaxMsgSize))
+	}
+	if err := validateRequestLimits(cfg.RequestLimits); err != nil {
+		return nil, err
 	}
-	if cfg.ArkdURL == "" {
-		return nil, fmt.Errorf("missing arkd url")
+	if cfg.RateLimit < 0 {
+		return nil, fmt.Errorf("%s must not be negative", describe(RateLimit))
+	}
+	if cfg.RateLimit > 0 && cfg.RateBurst <= 0 {
+		return nil, fmt.Errorf("%s must be positive", describe(RateBurst))
 	}
 	return cfg, nil
 }
 
+// Dump returns the effective value of every option by env var name, the
+// secrets are redacted.
+func (c *Config) Dump() map[string]string {
+	redacted := func(set bool) string {
+		if set {
+			return "<redacted>"
+		}
+		return ""
+	}
+	options := map[string]string{
+		SecretKey:                     redacted(c.SecretKey != nil),
+		UnlockerPassword:              redacted(c.UnlockerPassword != ""),
+		Datadir:                       c.Datadir,
+		Port:                          fmt.Sprint(c.Port),
+		NoTLS:                         fmt.Sprint(c.NoTLS),
+		TLSExtraIPs:                   strings.Join(c.TLSExtraIPs, ","),
+		TLSExtraDomains:               strings.Join(c.TLSExtraDomains, ","),
+		LogLevel:                      log.GetLevel().String(),
+		LogFormat:                     c.LogFormat,
+		ConfigFile:                    c.ConfigFile,
+		NoMacaroons:                   fmt.Sprint(c.NoMacaroons),
+		RestrictSigning:               fmt.Sprint(c.RestrictSigning),
+		MetricsPort:                   fmt.Sprint(c.MetricsPort),
+		AdminPort:                     fmt.Sprint(c.AdminPort),
+		AdminHost:                     c.AdminHost,
+		OtlpEndpoint:                  c.TracingConfig.Endpoint,
+		OtlpInsecure:                  fmt.Sprint(c.TracingConfig.Insecure),
+		ArkdURL:                       strings.Join(c.ArkdURLs, ","),
+		ArkdRefreshInterval:           c.ArkdWatcherConfig.RefreshInterval.String(),
+		ArkdMaxRetryDelay:             c.ArkdWatcherConfig.MaxRetryDelay.String(),
+		EquivocationProtection:        fmt.Sprint(c.EquivocationProtection),
+		FinalizeMinAttempts:           fmt.Sprint(c.FinalizeRetryConfig.MinAttempts),
+		FinalizeMaxAttempts:           fmt.Sprint(c.FinalizeRetryConfig.MaxAttempts),
+		FinalizeInitialDelay:          c.FinalizeRetryConfig.InitialDelay.String(),
+		FinalizeMaxDelay:              c.FinalizeRetryConfig.MaxDelay.String(),
+		FinalizeMultiplier:            fmt.Sprint(c.FinalizeRetryConfig.Multiplier),
+		FinalizeJitter:                fmt.Sprint(c.FinalizeRetryConfig.Jitter),
+		DelegateSolver:                fmt.Sprint(c.DelegateSolverConfig.Enabled),
+		DelegateRefreshWindow:         c.DelegateSolverConfig.RefreshWindow.String(),
+		DelegateSessionTimeout:        c.DelegateSolverConfig.SessionTimeout.String(),
+		DelegateMaxAttempts:           fmt.Sprint(c.DelegateSolverConfig.MaxAttempts),
+		DelegateRetryDelay:            c.DelegateSolverConfig.RetryDelay.String(),
+		TreeCosigner:                  fmt.Sprint(c.TreeCosignerConfig.Enabled),
+		TreeCosignerTimeout:           c.TreeCosignerConfig.SessionTimeout.String(),
+		HealthMaxPendingFinalizations: fmt.Sprint(c.HealthConfig.MaxPendingFinalizations),
+		PolicyFile:                    c.PolicyFile,
+		MaxMsgSize:                    fmt.Sprint(c.MaxMsgSize),
+		MaxCheckpoints:                fmt.Sprint(c.RequestLimits.MaxCheckpoints),
+		MaxForfeits:                   fmt.Sprint(c.RequestLimits.MaxForfeits),
+		MaxTreeNodes:                  fmt.Sprint(c.RequestLimits.MaxTreeNodes),
+		MaxScriptWork:                 fmt.Sprint(c.RequestLimits.MaxScriptWork),
+		MaxRegisteredScripts:          fmt.Sprint(c.RequestLimits.MaxRegisteredScripts),
+		RateLimit:                     fmt.Sprint(c.RateLimit),
+		RateBurst:                     fmt.Sprint(c.RateBurst),
+	}
+
+	dump := make(map[string]string, len(options))
+	for option, value := range options {
+		dump[envPrefix+"_"+option] = value
+	}
+	return dump
+}
+
 func (c *Config) AppService(ctx context.Context) (application.Service, error) {
-	return application.New(ctx, c.SecretKey, c.ArkdURL)
+	keystore, err := c.getKeystore()
+	if err != nil {
+		return nil, err
+	}
+	arkServers, err := c.getArkServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	finalizations, err := c.getFinalizationQueue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := c.getSigningJournal()
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := badgerdb.NewSubmissionStore(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	intents, err := c.getIntentStore()
+	if err != nil {
+		return nil, err
+	}
+	delegates, err := badgerdb.NewDelegateStore(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	scripts, err := badgerdb.NewScriptRegistry(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := c.getSigningGuard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return application.New(
+		keystore, arkServers, journal, finalizations, submissions, intents,
+		delegates, scripts, c.DelegateSolverConfig, c.TreeCosignerConfig,
+		c.EquivocationProtection, c.RequestLimits, guard, c.Metrics(),
+	)
+}
+
+func (c *Config) AdminService(ctx context.Context) (application.AdminService, error) {
+	keystore, err := c.getKeystore()
+	if err != nil {
+		return nil, err
+	}
+	arkServers, err := c.getArkServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := c.getSigningJournal()
+	if err != nil {
+		return nil, err
+	}
+	finalizations, err := c.getFinalizationQueue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	intents, err := c.getIntentStore()
+	if err != nil {
+		return nil, err
+	}
+	health, err := c.HealthService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := c.getSigningGuard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewAdminService(
+		keystore, arkServers, journal, finalizations, intents, guard, health,
+	), nil
+}
+
+func (c *Config) HealthService(ctx context.Context) (application.HealthService, error) {
+	keystore, err := c.getKeystore()
+	if err != nil {
+		return nil, err
+	}
+	arkServers, err := c.getArkServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	finalizations, err := c.getFinalizationQueue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewHealthService(
+		keystore, arkServers, finalizations, c.HealthConfig,
+	), nil
+}
+
+// Metrics are collected even if the metrics endpoint is disabled.
+func (c *Config) Metrics() *metrics.Prometheus {
+	if c.metrics == nil {
+		c.metrics = metrics.NewPrometheus()
+	}
+	return c.metrics
+}
+
+func (c *Config) WalletService() (application.WalletService, error) {
+	keystore, err := c.getKeystore()
+	if err != nil {
+		return nil, err
+	}
+	return application.NewWalletService(keystore), nil
+}
+
+// getKeystore opens the keystore in the datadir the first time it's called.
+// If the unlocker password is set, the keystore is created if missing (with
+// the configured secret key, or a new one) and unlocked, otherwise it's left
+// locked until an operator unlocks it.
+func (c *Config) getKeystore() (application.Keystore, error) {
+	if c.keystore != nil {
+		return c.keystore, nil
+	}
+
+	ks, err := keystore.New(c.Datadir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keystore: %w", err)
+	}
+
+	if c.UnlockerPassword != "" {
+		if !ks.IsInitialized() {
+			if err := ks.Create(c.UnlockerPassword, c.SecretKey); err != nil {
+				return nil, fmt.Errorf("failed to create keystore: %w", err)
+			}
+			log.Info("keystore created")
+		}
+		if err := ks.Unlock(c.UnlockerPassword); err != nil {
+			return nil, fmt.Errorf("failed to unlock keystore: %w", err)
+		}
+	}
+
+	// the configured secret key may have been rotated since the keystore
+	// was created, it only has to be one of the stored keys
+	if c.SecretKey != nil {
+		signerKeys, err := ks.SignerKeys()
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, key := range signerKeys {
+			if key.PublicKey.IsEqual(c.SecretKey.PubKey()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("secret key does not match any of the keys stored in keystore")
+		}
+	}
+
+	if ks.IsLocked() {
+		log.Info("keystore is locked, signing is disabled until unlocked")
+	}
+
+	c.keystore = ks
+	return ks, nil
+}
+
+// getArkServers connects to the configured arkd instances the first time
+// it's called, they're shared by the app service and the finalization queue.
+func (c *Config) getArkServers(ctx context.Context) (*application.ArkServers, error) {
+	if c.arkServers != nil {
+		return c.arkServers, nil
+	}
+
+	keys, err := badgerdb.NewArkdKeyStore(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	arkServers, err := application.NewArkServers(
+		ctx, c.ArkdURLs, func(url string) (client.TransportClient, error) {
+			arkdClient, err := grpcclient.NewClient(url)
+			if err != nil {
+				return nil, err
+			}
+			arkdClient = tracing.InstrumentArkdClient(url, arkdClient)
+			return c.Metrics().InstrumentArkdClient(url, arkdClient), nil
+		},
+		keys, c.ArkdWatcherConfig,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.arkServers = arkServers
+	return arkServers, nil
+}
+
+// getFinalizationQueue opens the queue the first time it's called, resuming
+// the finalizations left pending by a previous run.
+func (c *Config) getFinalizationQueue(ctx context.Context) (application.FinalizationQueue, error) {
+	if c.finalizations != nil {
+		return c.finalizations, nil
+	}
+
+	arkServers, err := c.getArkServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := c.getSigningJournal()
+	if err != nil {
+		return nil, err
+	}
+	store, err := badgerdb.NewFinalizationStore(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	finalizations, err := application.NewFinalizationQueue(
+		ctx, arkServers, store, journal, c.FinalizeRetryConfig, c.Metrics(),
+	)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	c.finalizations = finalizations
+	return finalizations, nil
+}
+
+// getIntentStore opens the intent registry the first time it's called, it's
+// shared by the app service and the admin one.
+func (c *Config) getIntentStore() (application.IntentStore, error) {
+	if c.intents != nil {
+		return c.intents, nil
+	}
+
+	intents, err := badgerdb.NewIntentStore(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+
+	c.intents = intents
+	return intents, nil
+}
+
+// getSigningJournal opens the journal the first time it's called, it's
+// shared by the app service and the admin one.
+func (c *Config) getSigningJournal() (application.SigningJournal, error) {
+	if c.journal != nil {
+		return c.journal, nil
+	}
+
+	journal, err := badgerdb.NewSigningJournal(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+
+	c.journal = journal
+	return journal, nil
+}
+
+// getSigningGuard returns the signing state shared by the app service and the
+// admin one, signing starts resumed with the policy of the policy file if any
+// and the spendings of the policy window persisted by the previous runs.
+func (c *Config) getSigningGuard(ctx context.Context) (*application.SigningGuard, error) {
+	if c.guard != nil {
+		return c.guard, nil
+	}
+
+	guard := application.NewSigningGuard()
+	if c.PolicyFile != "" {
+		if err := guard.LoadPolicyFile(c.PolicyFile); err != nil {
+			return nil, fmt.Errorf("failed to load policy file: %w", err)
+		}
+	}
+	spendings, err := badgerdb.NewSpendingStore(c.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	if err := guard.LoadSpendings(ctx, spendings); err != nil {
+		spendings.Close()
+		return nil, err
+	}
+
+	c.guard = guard
+	return guard, nil
+}
+
+func validateFinalizeRetryConfig(cfg application.FinalizeRetryConfig) error {
+	if cfg.MinAttempts < 0 || cfg.MaxAttempts < 0 {
+		return fmt.Errorf("finalize attempts must not be negative")
+	}
+	if cfg.MaxAttempts > 0 && cfg.MaxAttempts < cfg.MinAttempts {
+		return fmt.Errorf("finalize max attempts must be greater than min attempts")
+	}
+	if cfg.InitialDelay <= 0 || cfg.MaxDelay < cfg.InitialDelay {
+		return fmt.Errorf("invalid finalize delays")
+	}
+	if cfg.Multiplier < 1 {
+		return fmt.Errorf("finalize multiplier must be at least 1")
+	}
+	if cfg.Jitter < 0 || cfg.Jitter >= 1 {
+		return fmt.Errorf("finalize jitter must be in [0, 1)")
+	}
+	return nil
+}
+
+func validateDelegateSolverConfig(cfg application.DelegateSolverConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.RefreshWindow < 0 {
+		return fmt.Errorf("delegate refresh window must not be negative")
+	}
+	if cfg.SessionTimeout <= 0 || cfg.RetryDelay <= 0 {
+		return fmt.Errorf("invalid delegate solver delays")
+	}
+	if cfg.MaxAttempts <= 0 {
+		return fmt.Errorf("delegate max attempts must be positive")
+	}
+	return nil
+}
+
+func validateRequestLimits(limits application.RequestLimits) error {
+	for env, limit := range map[string]int{
+		MaxCheckpoints:       limits.MaxCheckpoints,
+		MaxForfeits:          limits.MaxForfeits,
+		MaxTreeNodes:         limits.MaxTreeNodes,
+		MaxScriptWork:        limits.MaxScriptWork,
+		MaxRegisteredScripts: limits.MaxRegisteredScripts,
+	} {
+		if limit < 0 {
+			return fmt.Errorf("%s must not be negative", describe(env))
+		}
+	}
+	return nil
 }
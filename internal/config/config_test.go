@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	defer log.SetLevel(log.GetLevel())
+	defer log.SetFormatter(log.StandardLogger().Formatter)
+
+	newFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("introspector", pflag.ContinueOnError)
+		RegisterFlags(flags)
+		require.NoError(t, flags.Parse(args))
+		return flags
+	}
+
+	t.Run("precedence", func(t *testing.T) {
+		datadir := t.TempDir()
+		t.Setenv("INTROSPECTOR_DATADIR", datadir)
+		require.NoError(t, os.WriteFile(filepath.Join(datadir, "introspector.toml"), []byte(`
+port = 8000
+
+[log]
+level = "warn"
+format = "json"
+
+[arkd]
+urls = ["http://arkd-1:7070", "http://arkd-2:7070"]
+refresh_interval = "5m"
+
+[limits]
+max_msg_size = 1000
+max_checkpoints = 10
+rate_limit = 2.5
+`), 0o600))
+		t.Setenv("INTROSPECTOR_MAX_MSG_SIZE", "2000")
+		t.Setenv("INTROSPECTOR_MAX_CHECKPOINTS", "20")
+
+		cfg, err := LoadConfig(newFlags("--max-checkpoints", "30"))
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(datadir, "introspector.toml"), cfg.ConfigFile)
+		require.Equal(t, uint32(8000), cfg.Port)
+		require.Equal(t, log.WarnLevel, log.GetLevel())
+		require.Equal(t, "json", cfg.LogFormat)
+		require.Equal(t, []string{"http://arkd-1:7070", "http://arkd-2:7070"}, cfg.ArkdURLs)
+		require.Equal(t, 5*time.Minute, cfg.ArkdWatcherConfig.RefreshInterval)
+		require.Equal(t, 2.5, cfg.RateLimit)
+		// the env var overrides the file and the flag overrides both
+		require.Equal(t, 2000, cfg.MaxMsgSize)
+		require.Equal(t, 30, cfg.RequestLimits.MaxCheckpoints)
+		// defaults
+		require.Equal(t, defaultRateBurst, cfg.RateBurst)
+		require.Equal(t, "warning", cfg.Dump()["INTROSPECTOR_LOG_LEVEL"])
+	})
+
+	t.Run("env only", func(t *testing.T) {
+		t.Setenv("INTROSPECTOR_DATADIR", t.TempDir())
+		t.Setenv("INTROSPECTOR_ARKD_URL", "http://arkd-1:7070, http://arkd-2:7070")
+		t.Setenv("INTROSPECTOR_LOG_LEVEL", "4")
+		t.Setenv("INTROSPECTOR_NO_TLS", "true")
+
+		cfg, err := LoadConfig(nil)
+		require.NoError(t, err)
+		require.Empty(t, cfg.ConfigFile)
+		require.Equal(t, []string{"http://arkd-1:7070", "http://arkd-2:7070"}, cfg.ArkdURLs)
+		require.Equal(t, log.InfoLevel, log.GetLevel())
+		require.True(t, cfg.NoTLS)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		datadir := t.TempDir()
+		t.Setenv("INTROSPECTOR_DATADIR", datadir)
+		t.Setenv("INTROSPECTOR_ARKD_URL", "http://arkd:7070")
+
+		path := filepath.Join(datadir, "custom.yaml")
+		_, err := LoadConfig(newFlags("--config", path))
+		require.ErrorContains(t, err, "failed to read config file")
+
+		require.NoError(t, os.WriteFile(path, []byte("limits:\n  max_msg_sizes: 10\n"), 0o600))
+		_, err = LoadConfig(newFlags("--config", path))
+		require.ErrorContains(t, err, "unknown option limits.max_msg_sizes")
+
+		require.NoError(t, os.WriteFile(path, []byte("log:\n  level: verbose\n"), 0o600))
+		t.Setenv("INTROSPECTOR_PORT", "http")
+		_, err = LoadConfig(newFlags("--config", path))
+		require.ErrorContains(t, err, "invalid log.level (--log-level, INTROSPECTOR_LOG_LEVEL)")
+		require.ErrorContains(t, err, "invalid port (--port, INTROSPECTOR_PORT)")
+		t.Setenv("INTROSPECTOR_PORT", "")
+
+		_, err = LoadConfig(newFlags("--config", filepath.Join(datadir, "introspector.json")))
+		require.ErrorContains(t, err, "must have one of the extensions toml, yaml, yml")
+
+		_, err = LoadConfig(newFlags("--rate-limit", "-1"))
+		require.ErrorContains(
+			t, err, "limits.rate_limit (--rate-limit, INTROSPECTOR_RATE_LIMIT) must not be negative",
+		)
+	})
+}
@@ -0,0 +1,400 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const configFileName = "introspector"
+
+var configFileExtensions = []string{"toml", "yaml", "yml"}
+
+// option is a setting read from its command line flag, its env var, the config
+// file and its default, in that order
+type option struct {
+	// env is the name of the env var without the prefix, the flag is named
+	// after it, eg. MAX_MSG_SIZE is set with --max-msg-size
+	env string
+	// key is the path of the option in the config file
+	key string
+	// defaultValue also sets the type of the flag
+	defaultValue any
+	usage        string
+	// the secrets are not exposed as flags, they would leak in the process list
+	secret bool
+}
+
+var options = []option{
+	{env: ConfigFile, key: "config", defaultValue: "",
+		usage: "path of the toml or yaml config file, by default introspector.toml in the datadir"},
+	{env: Datadir, key: "datadir", defaultValue: defaultDatadir,
+		usage: "directory of the databases, the tls files and the macaroons"},
+	{env: Port, key: "port", defaultValue: defaultPort,
+		usage: "port of the grpc and rest services"},
+	{env: SecretKey, key: "secret_key", defaultValue: "", secret: true},
+	{env: UnlockerPassword, key: "unlocker_password", defaultValue: "", secret: true},
+
+	{env: LogLevel, key: "log.level", defaultValue: defaultLogLevel.String(),
+		usage: "one of panic, fatal, error, warn, info, debug or trace"},
+	{env: LogFormat, key: "log.format", defaultValue: defaultLogFormat,
+		usage: "text or json"},
+
+	{env: NoTLS, key: "tls.disabled", defaultValue: defaultNoTLS,
+		usage: "serve in plain text"},
+	{env: TLSExtraIPs, key: "tls.extra_ips", defaultValue: defaultTLSExtraIPs,
+		usage: "extra ips of the generated tls certificate"},
+	{env: TLSExtraDomains, key: "tls.extra_domains", defaultValue: defaultTLSExtraDomains,
+		usage: "extra domains of the generated tls certificate"},
+
+	{env: NoMacaroons, key: "auth.no_macaroons", defaultValue: defaultNoMacaroons,
+		usage: "serve every rpc without authentication, requires the admin listener"},
+	{env: RestrictSigning, key: "auth.restrict_signing", defaultValue: defaultRestrictSigning,
+		usage: "require the signer macaroon for the signing rpcs"},
+
+	{env: AdminPort, key: "admin.port", defaultValue: defaultAdminPort,
+		usage: "port of the wallet and admin services, they're served on the main port if 0"},
+	{env: AdminHost, key: "admin.host", defaultValue: defaultAdminHost,
+		usage: "host of the wallet and admin services listener"},
+
+	{env: ArkdURL, key: "arkd.urls", defaultValue: []string{},
+		usage: "urls of the served arkd instances, the first is the default one"},
+	{env: ArkdRefreshInterval, key: "arkd.refresh_interval",
+		defaultValue: application.DefaultArkdWatcherConfig.RefreshInterval,
+		usage:        "refresh of the info of the arkd instances"},
+	{env: ArkdMaxRetryDelay, key: "arkd.max_retry_delay",
+		defaultValue: application.DefaultArkdWatcherConfig.MaxRetryDelay,
+		usage:        "max delay between the retries of a failed fetch of the arkd info"},
+
+	{env: PolicyFile, key: "policy.file", defaultValue: "",
+		usage: "json signing policy loaded at startup and by the ReloadPolicy rpc"},
+	{env: EquivocationProtection, key: "policy.equivocation_protection",
+		defaultValue: defaultEquivocationProtection,
+		usage:        "refuse to sign a spend of an outpoint already signed for a different txid"},
+
+	{env: FinalizeMinAttempts, key: "finalize.min_attempts",
+		defaultValue: application.DefaultFinalizeRetryConfig.MinAttempts,
+		usage:        "finalization attempts made before giving up on a cancelled request"},
+	{env: FinalizeMaxAttempts, key: "finalize.max_attempts",
+		defaultValue: application.DefaultFinalizeRetryConfig.MaxAttempts,
+		usage:        "finalization attempts of a run before marking it stuck, 0 for unlimited"},
+	{env: FinalizeInitialDelay, key: "finalize.initial_delay",
+		defaultValue: application.DefaultFinalizeRetryConfig.InitialDelay,
+		usage:        "delay before the first finalization retry"},
+	{env: FinalizeMaxDelay, key: "finalize.max_delay",
+		defaultValue: application.DefaultFinalizeRetryConfig.MaxDelay,
+		usage:        "max delay between the finalization retries"},
+	{env: FinalizeMultiplier, key: "finalize.multiplier",
+		defaultValue: application.DefaultFinalizeRetryConfig.Multiplier,
+		usage:        "growth of the delay between the finalization retries"},
+	{env: FinalizeJitter, key: "finalize.jitter",
+		defaultValue: application.DefaultFinalizeRetryConfig.Jitter,
+		usage:        "randomness of the finalization retry delays, 0.2 means ±20%"},
+
+	{env: DelegateSolver, key: "delegate_solver.enabled",
+		defaultValue: application.DefaultDelegateSolverConfig.Enabled,
+		usage:        "refresh the registered delegate vtxos in batches"},
+	{env: DelegateRefreshWindow, key: "delegate_solver.refresh_window",
+		defaultValue: application.DefaultDelegateSolverConfig.RefreshWindow,
+		usage:        "delay before the expiry at which a delegate vtxo is refreshed"},
+	{env: DelegateSessionTimeout, key: "delegate_solver.session_timeout",
+		defaultValue: application.DefaultDelegateSolverConfig.SessionTimeout,
+		usage:        "timeout of a batch session of the delegate solver"},
+	{env: DelegateMaxAttempts, key: "delegate_solver.max_attempts",
+		defaultValue: application.DefaultDelegateSolverConfig.MaxAttempts,
+		usage:        "batch sessions attempted to refresh a delegate vtxo"},
+	{env: DelegateRetryDelay, key: "delegate_solver.retry_delay",
+		defaultValue: application.DefaultDelegateSolverConfig.RetryDelay,
+		usage:        "delay between the batch sessions attempted to refresh a delegate vtxo"},
+
+	{env: TreeCosigner, key: "tree_cosigner.enabled",
+		defaultValue: application.DefaultTreeCosignerConfig.Enabled,
+		usage:        "cosign the vtxo trees of the intents listing a key of the introspector"},
+	{env: TreeCosignerTimeout, key: "tree_cosigner.timeout",
+		defaultValue: application.DefaultTreeCosignerConfig.SessionTimeout,
+		usage:        "timeout of a tree signing session"},
+
+	{env: HealthMaxPendingFinalizations, key: "health.max_pending_finalizations",
+		defaultValue: application.DefaultHealthConfig.MaxPendingFinalizations,
+		usage:        "pending finalizations above which the introspector is not ready, 0 for unlimited"},
+
+	{env: MaxMsgSize, key: "limits.max_msg_size", defaultValue: defaultMaxMsgSize,
+		usage: "size in bytes of the grpc messages and of the rest request bodies"},
+	{env: MaxCheckpoints, key: "limits.max_checkpoints",
+		defaultValue: application.DefaultRequestLimits.MaxCheckpoints,
+		usage:        "checkpoint txs of a request, 0 for unlimited"},
+	{env: MaxForfeits, key: "limits.max_forfeits",
+		defaultValue: application.DefaultRequestLimits.MaxForfeits,
+		usage:        "forfeit txs of a request, 0 for unlimited"},
+	{env: MaxTreeNodes, key: "limits.max_tree_nodes",
+		defaultValue: application.DefaultRequestLimits.MaxTreeNodes,
+		usage:        "nodes of a connector or vtxo tree of a request, 0 for unlimited"},
+	{env: MaxScriptWork, key: "limits.max_script_work",
+		defaultValue: application.DefaultRequestLimits.MaxScriptWork,
+		usage:        "bytes of scripts and witnesses of the introspector packet of a request, 0 for unlimited"},
+	{env: MaxRegisteredScripts, key: "limits.max_registered_scripts",
+		defaultValue: application.DefaultRequestLimits.MaxRegisteredScripts,
+		usage:        "scripts of the registry, the registrations are refused once full, 0 for unlimited"},
+	{env: RateLimit, key: "limits.rate_limit", defaultValue: defaultRateLimit,
+		usage: "requests per second allowed to a client, 0 to disable the rate limiting"},
+	{env: RateBurst, key: "limits.rate_burst", defaultValue: defaultRateBurst,
+		usage: "requests a client can burst above the rate limit"},
+
+	{env: MetricsPort, key: "observability.metrics_port", defaultValue: defaultMetricsPort,
+		usage: "port of the prometheus metrics endpoint, 0 to disable it"},
+	{env: OtlpEndpoint, key: "observability.otlp_endpoint", defaultValue: "",
+		usage: "host:port of the otlp grpc collector the traces are exported to"},
+	{env: OtlpInsecure, key: "observability.otlp_insecure", defaultValue: false,
+		usage: "export the traces in plain text"},
+}
+
+func lookupOption(env string) option {
+	for _, opt := range options {
+		if opt.env == env {
+			return opt
+		}
+	}
+	panic(fmt.Sprintf("unknown option %s", env))
+}
+
+func flagName(env string) string {
+	return strings.ToLower(strings.ReplaceAll(env, "_", "-"))
+}
+
+// describe names the option in the errors with all the ways to set it
+func describe(env string) string {
+	opt := lookupOption(env)
+	if opt.secret {
+		return fmt.Sprintf("%s (%s_%s)", opt.key, envPrefix, env)
+	}
+	return fmt.Sprintf("%s (--%s, %s_%s)", opt.key, flagName(env), envPrefix, env)
+}
+
+// RegisterFlags adds a flag for every option but the secrets, they override
+// the env vars and the config file
+func RegisterFlags(flags *pflag.FlagSet) {
+	for _, opt := range options {
+		if opt.secret {
+			continue
+		}
+		name := flagName(opt.env)
+		switch value := opt.defaultValue.(type) {
+		case bool:
+			flags.Bool(name, value, opt.usage)
+		case int:
+			flags.Int(name, value, opt.usage)
+		case uint32:
+			flags.Uint32(name, value, opt.usage)
+		case float64:
+			flags.Float64(name, value, opt.usage)
+		case time.Duration:
+			flags.Duration(name, value, opt.usage)
+		case []string:
+			flags.StringSlice(name, value, opt.usage)
+		default:
+			flags.String(name, fmt.Sprint(value), opt.usage)
+		}
+	}
+}
+
+// newViper binds every option to its flag, if registered in flags, and to its
+// env var, and reads the config file
+func newViper(flags *pflag.FlagSet) (*viper.Viper, error) {
+	v := viper.New()
+	for _, opt := range options {
+		v.SetDefault(opt.key, opt.defaultValue)
+		if err := v.BindEnv(opt.key, envPrefix+"_"+opt.env); err != nil {
+			return nil, err
+		}
+		if flags == nil {
+			continue
+		}
+		if flag := flags.Lookup(flagName(opt.env)); flag != nil {
+			if err := v.BindPFlag(opt.key, flag); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := readConfigFile(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// readConfigFile reads the config file if set or found in the datadir, the
+// unknown options are rejected so that a typo doesn't silently drop a setting
+func readConfigFile(v *viper.Viper) error {
+	path := v.GetString(lookupOption(ConfigFile).key)
+	if path == "" {
+		for _, ext := range configFileExtensions {
+			candidate := filepath.Join(
+				v.GetString(lookupOption(Datadir).key), configFileName+"."+ext,
+			)
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+		if path == "" {
+			return nil
+		}
+	}
+
+	if ext := strings.TrimPrefix(filepath.Ext(path), "."); !slices.Contains(
+		configFileExtensions, ext,
+	) {
+		return fmt.Errorf(
+			"config file %s must have one of the extensions %s",
+			path, strings.Join(configFileExtensions, ", "),
+		)
+	}
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	known := make(map[string]struct{}, len(options))
+	for _, opt := range options {
+		known[opt.key] = struct{}{}
+	}
+	for _, key := range v.AllKeys() {
+		if _, ok := known[key]; !ok {
+			return fmt.Errorf("unknown option %s in config file %s", key, path)
+		}
+	}
+	// the config file is reported by the config dump
+	v.Set(lookupOption(ConfigFile).key, path)
+	return nil
+}
+
+// loader reads the options from viper, collecting the invalid ones so that
+// they're reported all at once
+type loader struct {
+	v    *viper.Viper
+	errs []error
+}
+
+func (l *loader) get(env string) any {
+	return l.v.Get(lookupOption(env).key)
+}
+
+func (l *loader) fail(env string, err error) {
+	l.errs = append(l.errs, fmt.Errorf("invalid %s: %w", describe(env), err))
+}
+
+func (l *loader) err() error {
+	return errors.Join(l.errs...)
+}
+
+func (l *loader) string(env string) string {
+	value, err := cast.ToStringE(l.get(env))
+	if err != nil {
+		l.fail(env, err)
+	}
+	return value
+}
+
+func (l *loader) bool(env string) bool {
+	value, err := cast.ToBoolE(l.get(env))
+	if err != nil {
+		l.fail(env, err)
+	}
+	return value
+}
+
+func (l *loader) int(env string) int {
+	value, err := cast.ToIntE(l.get(env))
+	if err != nil {
+		l.fail(env, err)
+	}
+	return value
+}
+
+func (l *loader) uint32(env string) uint32 {
+	value, err := cast.ToUint32E(l.get(env))
+	if err != nil {
+		l.fail(env, err)
+	}
+	return value
+}
+
+func (l *loader) float64(env string) float64 {
+	value, err := cast.ToFloat64E(l.get(env))
+	if err != nil {
+		l.fail(env, err)
+	}
+	return value
+}
+
+func (l *loader) duration(env string) time.Duration {
+	value, err := cast.ToDurationE(l.get(env))
+	if err != nil {
+		l.fail(env, err)
+	}
+	return value
+}
+
+// strings accepts a list or a comma-separated string, the env vars being
+// strings
+func (l *loader) strings(env string) []string {
+	value := l.get(env)
+	if str, ok := value.(string); ok {
+		value = strings.Split(str, ",")
+	}
+	list, err := cast.ToStringSliceE(value)
+	if err != nil {
+		l.fail(env, err)
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// logLevel accepts the level names and, for backward compatibility, their
+// logrus numbers
+func (l *loader) logLevel() log.Level {
+	value := l.string(LogLevel)
+	if number, err := cast.ToUint32E(value); err == nil {
+		if number > uint32(log.TraceLevel) {
+			l.fail(LogLevel, fmt.Errorf("unknown level %s", value))
+			return defaultLogLevel
+		}
+		return log.Level(number)
+	}
+	level, err := log.ParseLevel(value)
+	if err != nil {
+		l.fail(LogLevel, fmt.Errorf(
+			"unknown level %s, expected one of panic, fatal, error, warn, info, debug or trace", value,
+		))
+		return defaultLogLevel
+	}
+	return level
+}
+
+func (l *loader) logFormatter() log.Formatter {
+	switch format := l.string(LogFormat); format {
+	case "text":
+		return &log.TextFormatter{}
+	case "json":
+		return &log.JSONFormatter{}
+	default:
+		l.fail(LogFormat, fmt.Errorf("unknown format %s, expected text or json", format))
+		return &log.TextFormatter{}
+	}
+}
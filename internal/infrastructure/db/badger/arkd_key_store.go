@@ -0,0 +1,55 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const arkdKeyStoreDir = "arkd"
+
+type arkdKeys struct {
+	URL     string
+	PubKeys []string
+}
+
+type arkdKeyStore struct {
+	db *store
+}
+
+// NewArkdKeyStore opens the store in the given datadir, in memory if empty
+func NewArkdKeyStore(datadir string) (application.ArkdKeyStore, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, arkdKeyStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open arkd key store: %w", err)
+	}
+	return &arkdKeyStore{db}, nil
+}
+
+func (s *arkdKeyStore) Get(_ context.Context, url string) ([]string, error) {
+	var keys arkdKeys
+	if err := s.db.Get(url, &keys); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return keys.PubKeys, nil
+}
+
+func (s *arkdKeyStore) Set(_ context.Context, url string, pubkeys []string) error {
+	return s.db.Upsert(url, arkdKeys{URL: url, PubKeys: pubkeys})
+}
+
+func (s *arkdKeyStore) Close() {
+	// nolint:all
+	s.db.Close()
+}
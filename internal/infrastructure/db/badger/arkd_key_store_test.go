@@ -0,0 +1,34 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArkdKeyStore(t *testing.T) {
+	ctx := context.Background()
+	datadir := t.TempDir()
+
+	keys, err := NewArkdKeyStore(datadir)
+	require.NoError(t, err)
+
+	got, err := keys.Get(ctx, "arkd:7070")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, keys.Set(ctx, "arkd:7070", []string{"old"}))
+	require.NoError(t, keys.Set(ctx, "arkd:7070", []string{"new", "old"}))
+	require.NoError(t, keys.Set(ctx, "other:7070", []string{"other"}))
+	keys.Close()
+
+	// the keys survive a restart
+	keys, err = NewArkdKeyStore(datadir)
+	require.NoError(t, err)
+	defer keys.Close()
+
+	got, err = keys.Get(ctx, "arkd:7070")
+	require.NoError(t, err)
+	require.Equal(t, []string{"new", "old"}, got)
+}
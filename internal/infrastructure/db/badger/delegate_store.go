@@ -0,0 +1,118 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const delegateStoreDir = "delegates"
+
+type delegate struct {
+	Outpoint       string
+	Amount         int64
+	Proof          string
+	Message        string
+	ArkdPubKey     string
+	RefreshAt      int64
+	Status         string `badgerhold:"index"`
+	Attempts       int
+	LastError      string
+	CommitmentTxid string
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+type delegateStore struct {
+	db *store
+}
+
+// NewDelegateStore opens the store in the given datadir, in memory if empty
+func NewDelegateStore(datadir string) (application.DelegateStore, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, delegateStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open delegate store: %w", err)
+	}
+	return &delegateStore{db}, nil
+}
+
+func (s *delegateStore) Upsert(_ context.Context, d application.Delegate) error {
+	return s.db.Upsert(d.Outpoint, delegate{
+		Outpoint:       d.Outpoint,
+		Amount:         d.Amount,
+		Proof:          d.Proof,
+		Message:        d.Message,
+		ArkdPubKey:     d.ArkdPubKey,
+		RefreshAt:      d.RefreshAt,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		CommitmentTxid: d.CommitmentTxid,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	})
+}
+
+func (s *delegateStore) Get(_ context.Context, outpoint string) (*application.Delegate, error) {
+	var d delegate
+	if err := s.db.Get(outpoint, &d); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, application.ErrDelegateNotFound
+		}
+		return nil, err
+	}
+	result := fromDelegate(d)
+	return &result, nil
+}
+
+func (s *delegateStore) GetByStatus(
+	_ context.Context, statuses ...application.DelegateStatus,
+) ([]application.Delegate, error) {
+	values := make([]any, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+
+	var delegates []delegate
+	if err := s.db.Find(
+		&delegates, badgerhold.Where("Status").In(values...).Index("Status"),
+	); err != nil {
+		return nil, err
+	}
+
+	result := make([]application.Delegate, 0, len(delegates))
+	for _, d := range delegates {
+		result = append(result, fromDelegate(d))
+	}
+	return result, nil
+}
+
+func (s *delegateStore) Close() {
+	// nolint:all
+	s.db.Close()
+}
+
+func fromDelegate(d delegate) application.Delegate {
+	return application.Delegate{
+		Outpoint:       d.Outpoint,
+		Amount:         d.Amount,
+		Proof:          d.Proof,
+		Message:        d.Message,
+		ArkdPubKey:     d.ArkdPubKey,
+		RefreshAt:      d.RefreshAt,
+		Status:         application.DelegateStatus(d.Status),
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		CommitmentTxid: d.CommitmentTxid,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegateStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewDelegateStore("")
+	require.NoError(t, err)
+	defer store.Close()
+
+	_, err = store.Get(ctx, "txid:0")
+	require.ErrorIs(t, err, application.ErrDelegateNotFound)
+
+	pending := application.Delegate{
+		Outpoint:   "txid:0",
+		Amount:     10000,
+		Proof:      "proof",
+		Message:    "message",
+		ArkdPubKey: "arkd",
+		RefreshAt:  100,
+		Status:     application.DelegateStatusPending,
+		CreatedAt:  1,
+		UpdatedAt:  1,
+	}
+	settled := application.Delegate{
+		Outpoint:       "txid:1",
+		Amount:         20000,
+		Status:         application.DelegateStatusSettled,
+		Attempts:       1,
+		CommitmentTxid: "commitment",
+		CreatedAt:      2,
+		UpdatedAt:      3,
+	}
+	require.NoError(t, store.Upsert(ctx, pending))
+	require.NoError(t, store.Upsert(ctx, settled))
+
+	got, err := store.Get(ctx, "txid:0")
+	require.NoError(t, err)
+	require.Equal(t, pending, *got)
+
+	list, err := store.GetByStatus(ctx, application.DelegateStatusPending)
+	require.NoError(t, err)
+	require.Equal(t, []application.Delegate{pending}, list)
+
+	pending.Status = application.DelegateStatusRunning
+	pending.Attempts = 1
+	require.NoError(t, store.Upsert(ctx, pending))
+
+	list, err = store.GetByStatus(ctx, application.DelegateStatusPending)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	list, err = store.GetByStatus(
+		ctx, application.DelegateStatusRunning, application.DelegateStatusSettled,
+	)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+}
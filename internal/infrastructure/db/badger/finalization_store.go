@@ -0,0 +1,106 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const finalizationStoreDir = "finalizations"
+
+type finalization struct {
+	Txid        string
+	ArkdPubKey  string
+	Checkpoints []string
+	Status      string `badgerhold:"index"`
+	Attempts    int
+	LastError   string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+type finalizationStore struct {
+	db *store
+}
+
+// NewFinalizationStore opens the store in the given datadir, in memory if empty
+func NewFinalizationStore(datadir string) (application.FinalizationStore, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, finalizationStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open finalization store: %w", err)
+	}
+	return &finalizationStore{db}, nil
+}
+
+func (s *finalizationStore) Upsert(_ context.Context, f application.Finalization) error {
+	return s.db.Upsert(f.Txid, finalization{
+		Txid:        f.Txid,
+		ArkdPubKey:  f.ArkdPubKey,
+		Checkpoints: f.Checkpoints,
+		Status:      string(f.Status),
+		Attempts:    f.Attempts,
+		LastError:   f.LastError,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+	})
+}
+
+func (s *finalizationStore) Get(_ context.Context, txid string) (*application.Finalization, error) {
+	var f finalization
+	if err := s.db.Get(txid, &f); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, application.ErrFinalizationNotFound
+		}
+		return nil, err
+	}
+	result := fromFinalization(f)
+	return &result, nil
+}
+
+func (s *finalizationStore) GetByStatus(
+	_ context.Context, statuses ...application.FinalizationStatus,
+) ([]application.Finalization, error) {
+	values := make([]any, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+
+	var finalizations []finalization
+	if err := s.db.Find(
+		&finalizations, badgerhold.Where("Status").In(values...).Index("Status"),
+	); err != nil {
+		return nil, err
+	}
+
+	result := make([]application.Finalization, 0, len(finalizations))
+	for _, f := range finalizations {
+		result = append(result, fromFinalization(f))
+	}
+	return result, nil
+}
+
+func (s *finalizationStore) Close() {
+	// nolint:all
+	s.db.Close()
+}
+
+func fromFinalization(f finalization) application.Finalization {
+	return application.Finalization{
+		Txid:        f.Txid,
+		ArkdPubKey:  f.ArkdPubKey,
+		Checkpoints: f.Checkpoints,
+		Status:      application.FinalizationStatus(f.Status),
+		Attempts:    f.Attempts,
+		LastError:   f.LastError,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+	}
+}
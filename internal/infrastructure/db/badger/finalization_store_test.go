@@ -0,0 +1,63 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalizationStore(t *testing.T) {
+	ctx := context.Background()
+	datadir := t.TempDir()
+
+	store, err := NewFinalizationStore(datadir)
+	require.NoError(t, err)
+
+	_, err = store.Get(ctx, "tx1")
+	require.ErrorIs(t, err, application.ErrFinalizationNotFound)
+
+	pending := application.Finalization{
+		Txid:        "tx1",
+		ArkdPubKey:  "arkd",
+		Checkpoints: []string{"checkpoint-a", "checkpoint-b"},
+		Status:      application.FinalizationStatusPending,
+		Attempts:    2,
+		LastError:   "arkd unavailable",
+		CreatedAt:   1,
+		UpdatedAt:   2,
+	}
+	stuck := application.Finalization{
+		Txid:        "tx2",
+		Checkpoints: []string{"checkpoint-c"},
+		Status:      application.FinalizationStatusStuck,
+		CreatedAt:   3,
+		UpdatedAt:   3,
+	}
+	require.NoError(t, store.Upsert(ctx, pending))
+	require.NoError(t, store.Upsert(ctx, stuck))
+
+	got, err := store.Get(ctx, "tx1")
+	require.NoError(t, err)
+	require.Equal(t, pending, *got)
+
+	pending.Status = application.FinalizationStatusFinalized
+	require.NoError(t, store.Upsert(ctx, pending))
+	store.Close()
+
+	// entries survive a restart
+	store, err = NewFinalizationStore(datadir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	list, err := store.GetByStatus(
+		ctx, application.FinalizationStatusPending, application.FinalizationStatusStuck,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []application.Finalization{stuck}, list)
+
+	list, err = store.GetByStatus(ctx, application.FinalizationStatusFinalized)
+	require.NoError(t, err)
+	require.Equal(t, []application.Finalization{pending}, list)
+}
@@ -0,0 +1,127 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const intentStoreDir = "intents"
+
+type intentRecord struct {
+	ProofTxid   string
+	Inputs      []string
+	Outputs     []intentOutput
+	MessageHash string
+	ArkdPubKey  string
+	ValidAt     int64
+	ExpireAt    int64 `badgerhold:"index"`
+	CreatedAt   int64
+}
+
+type intentOutput struct {
+	Amount   int64
+	PkScript string
+	Onchain  bool
+}
+
+type intentStore struct {
+	db *store
+}
+
+// NewIntentStore opens the store in the given datadir, in memory if empty
+func NewIntentStore(datadir string) (application.IntentStore, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, intentStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open intent store: %w", err)
+	}
+	return &intentStore{db}, nil
+}
+
+func (s *intentStore) Add(_ context.Context, record application.IntentRecord) error {
+	outputs := make([]intentOutput, 0, len(record.Outputs))
+	for _, output := range record.Outputs {
+		outputs = append(outputs, intentOutput(output))
+	}
+	return s.db.Upsert(record.ProofTxid, intentRecord{
+		ProofTxid:   record.ProofTxid,
+		Inputs:      record.Inputs,
+		Outputs:     outputs,
+		MessageHash: record.MessageHash,
+		ArkdPubKey:  record.ArkdPubKey,
+		ValidAt:     record.ValidAt,
+		ExpireAt:    record.ExpireAt,
+		CreatedAt:   record.CreatedAt,
+	})
+}
+
+func (s *intentStore) Get(_ context.Context, proofTxid string) (*application.IntentRecord, error) {
+	var record intentRecord
+	if err := s.db.Get(proofTxid, &record); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, application.ErrIntentNotFound
+		}
+		return nil, err
+	}
+	result := fromIntentRecord(record)
+	return &result, nil
+}
+
+func (s *intentStore) List(_ context.Context) ([]application.IntentRecord, error) {
+	var records []intentRecord
+	if err := s.db.Find(&records, nil); err != nil {
+		return nil, err
+	}
+
+	result := make([]application.IntentRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, fromIntentRecord(record))
+	}
+	return result, nil
+}
+
+func (s *intentStore) DeleteExpired(_ context.Context, before int64) (int, error) {
+	// the records written before the default ttl may have ExpireAt set to 0
+	query := badgerhold.Where("ExpireAt").Gt(int64(0)).And("ExpireAt").Lt(before).Index("ExpireAt")
+	count, err := s.db.Count(&intentRecord{}, query)
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	if err := s.db.DeleteMatching(&intentRecord{}, query); err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (s *intentStore) Close() {
+	// nolint:all
+	s.db.Close()
+}
+
+func fromIntentRecord(record intentRecord) application.IntentRecord {
+	outputs := make([]application.IntentOutput, 0, len(record.Outputs))
+	for _, output := range record.Outputs {
+		outputs = append(outputs, application.IntentOutput(output))
+	}
+	return application.IntentRecord{
+		ProofTxid:   record.ProofTxid,
+		Inputs:      record.Inputs,
+		Outputs:     outputs,
+		MessageHash: record.MessageHash,
+		ArkdPubKey:  record.ArkdPubKey,
+		ValidAt:     record.ValidAt,
+		ExpireAt:    record.ExpireAt,
+		CreatedAt:   record.CreatedAt,
+	}
+}
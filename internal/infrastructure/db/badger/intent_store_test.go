@@ -0,0 +1,64 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewIntentStore("")
+	require.NoError(t, err)
+	defer store.Close()
+
+	_, err = store.Get(ctx, "txid")
+	require.ErrorIs(t, err, application.ErrIntentNotFound)
+
+	expiring := application.IntentRecord{
+		ProofTxid: "expiring",
+		Inputs:    []string{"txid:0"},
+		Outputs: []application.IntentOutput{
+			{Amount: 1_000, PkScript: "51", Onchain: true},
+			{Amount: 2_000, PkScript: "52"},
+		},
+		MessageHash: "hash",
+		ArkdPubKey:  "arkd",
+		ValidAt:     1,
+		ExpireAt:    10,
+		CreatedAt:   1,
+	}
+	neverExpiring := application.IntentRecord{
+		ProofTxid:   "never-expiring",
+		Inputs:      []string{"txid:1"},
+		Outputs:     []application.IntentOutput{{Amount: 1_000, PkScript: "51"}},
+		MessageHash: "hash",
+		CreatedAt:   1,
+	}
+	require.NoError(t, store.Add(ctx, expiring))
+	require.NoError(t, store.Add(ctx, neverExpiring))
+
+	got, err := store.Get(ctx, "expiring")
+	require.NoError(t, err)
+	require.Equal(t, expiring, *got)
+
+	records, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	count, err := store.DeleteExpired(ctx, 10)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = store.DeleteExpired(ctx, 11)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = store.Get(ctx, "expiring")
+	require.ErrorIs(t, err, application.ErrIntentNotFound)
+	_, err = store.Get(ctx, "never-expiring")
+	require.NoError(t, err)
+}
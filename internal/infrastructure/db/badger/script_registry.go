@@ -0,0 +1,101 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const scriptRegistryDir = "scripts"
+
+type scriptArgument struct {
+	Name string
+	Type string
+}
+
+type registeredScript struct {
+	Hash         string
+	TemplateHash string
+	Script       []byte
+	Name         string
+	Version      string
+	Abi          []scriptArgument
+	CreatedAt    int64
+}
+
+type scriptRegistry struct {
+	db *store
+}
+
+// NewScriptRegistry opens the registry in the given datadir, in memory if empty
+func NewScriptRegistry(datadir string) (application.ScriptRegistry, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, scriptRegistryDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open script registry: %w", err)
+	}
+	return &scriptRegistry{db}, nil
+}
+
+func (s *scriptRegistry) Add(
+	ctx context.Context, script application.RegisteredScript,
+) (*application.RegisteredScript, error) {
+	abi := make([]scriptArgument, 0, len(script.Abi))
+	for _, arg := range script.Abi {
+		abi = append(abi, scriptArgument{Name: arg.Name, Type: arg.Type})
+	}
+
+	if err := s.db.Insert(script.Hash, registeredScript{
+		Hash:         script.Hash,
+		TemplateHash: script.TemplateHash,
+		Script:       script.Script,
+		Name:         script.Name,
+		Version:      script.Version,
+		Abi:          abi,
+		CreatedAt:    script.CreatedAt,
+	}); err != nil && !errors.Is(err, badgerhold.ErrKeyExists) {
+		return nil, err
+	}
+	return s.Get(ctx, script.Hash)
+}
+
+func (s *scriptRegistry) Get(_ context.Context, hash string) (*application.RegisteredScript, error) {
+	var script registeredScript
+	if err := s.db.Get(hash, &script); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, application.ErrScriptNotFound
+		}
+		return nil, err
+	}
+
+	abi := make([]application.ScriptArgument, 0, len(script.Abi))
+	for _, arg := range script.Abi {
+		abi = append(abi, application.ScriptArgument{Name: arg.Name, Type: arg.Type})
+	}
+	return &application.RegisteredScript{
+		Hash:         script.Hash,
+		TemplateHash: script.TemplateHash,
+		Script:       script.Script,
+		Name:         script.Name,
+		Version:      script.Version,
+		Abi:          abi,
+		CreatedAt:    script.CreatedAt,
+	}, nil
+}
+
+func (s *scriptRegistry) Count(_ context.Context) (int, error) {
+	count, err := s.db.Count(&registeredScript{}, nil)
+	return int(count), err
+}
+
+func (s *scriptRegistry) Close() {
+	// nolint:all
+	s.db.Close()
+}
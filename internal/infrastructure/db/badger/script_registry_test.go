@@ -0,0 +1,55 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	registry, err := NewScriptRegistry("")
+	require.NoError(t, err)
+	defer registry.Close()
+
+	_, err = registry.Get(ctx, "hash")
+	require.ErrorIs(t, err, application.ErrScriptNotFound)
+	count, err := registry.Count(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	script := application.RegisteredScript{
+		Hash:         "hash",
+		TemplateHash: "template",
+		Script:       []byte{0x51},
+		Name:         "vault",
+		Version:      "1.0.0",
+		Abi:          []application.ScriptArgument{{Name: "sig", Type: "signature"}},
+		CreatedAt:    1,
+	}
+	added, err := registry.Add(ctx, script)
+	require.NoError(t, err)
+	require.Equal(t, script, *added)
+
+	got, err := registry.Get(ctx, "hash")
+	require.NoError(t, err)
+	require.Equal(t, script, *got)
+
+	// registering the same hash again keeps the first registration
+	added, err = registry.Add(ctx, application.RegisteredScript{
+		Hash:      "hash",
+		Script:    []byte{0x51},
+		Name:      "other",
+		Abi:       []application.ScriptArgument{},
+		CreatedAt: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, script, *added)
+
+	count, err = registry.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
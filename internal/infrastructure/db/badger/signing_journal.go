@@ -0,0 +1,139 @@
+package badgerdb
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const journalStoreDir = "journal"
+
+type signature struct {
+	Outpoint   string `badgerhold:"index"`
+	Txid       string `badgerhold:"index"`
+	InputIndex int
+	Kind       string
+	ScriptHash string
+	SigHash    uint32
+	Failed     bool
+	CreatedAt  int64
+}
+
+type signingJournal struct {
+	db *store
+}
+
+// NewSigningJournal opens the journal in the given datadir, in memory if empty
+func NewSigningJournal(datadir string) (application.SigningJournal, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, journalStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open signing journal: %w", err)
+	}
+	return &signingJournal{db}, nil
+}
+
+func (j *signingJournal) Add(_ context.Context, signatures ...application.Signature) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+	return j.db.Badger().Update(func(tx *badger.Txn) error {
+		for _, sig := range signatures {
+			if err := j.db.TxUpsert(tx, signatureKey(sig.Txid, sig.InputIndex), toSignature(sig)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (j *signingJournal) GetByOutpoint(
+	_ context.Context, outpoint wire.OutPoint,
+) ([]application.Signature, error) {
+	var signatures []signature
+	if err := j.db.Find(
+		&signatures, badgerhold.Where("Outpoint").Eq(outpoint.String()).Index("Outpoint"),
+	); err != nil {
+		return nil, err
+	}
+	return fromSignatures(signatures)
+}
+
+func (j *signingJournal) ListRecent(_ context.Context, limit int) ([]application.Signature, error) {
+	var signatures []signature
+	if err := j.db.Find(
+		&signatures, (&badgerhold.Query{}).SortBy("CreatedAt").Reverse().Limit(limit),
+	); err != nil {
+		return nil, err
+	}
+	return fromSignatures(signatures)
+}
+
+func (j *signingJournal) MarkFailed(_ context.Context, txids ...string) error {
+	return j.db.Badger().Update(func(tx *badger.Txn) error {
+		for _, txid := range txids {
+			if err := j.db.TxUpdateMatching(
+				tx, &signature{}, badgerhold.Where("Txid").Eq(txid).Index("Txid"),
+				func(record any) error {
+					record.(*signature).Failed = true
+					return nil
+				},
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (j *signingJournal) Close() {
+	// nolint:all
+	j.db.Close()
+}
+
+func signatureKey(txid string, inputIndex int) string {
+	return fmt.Sprintf("%s:%d", txid, inputIndex)
+}
+
+func toSignature(sig application.Signature) signature {
+	return signature{
+		Outpoint:   sig.Outpoint.String(),
+		Txid:       sig.Txid,
+		InputIndex: sig.InputIndex,
+		Kind:       string(sig.Kind),
+		ScriptHash: sig.ScriptHash,
+		SigHash:    uint32(sig.SigHash),
+		Failed:     sig.Failed,
+		CreatedAt:  sig.CreatedAt,
+	}
+}
+
+func fromSignatures(signatures []signature) ([]application.Signature, error) {
+	result := make([]application.Signature, 0, len(signatures))
+	for _, sig := range signatures {
+		outpoint, err := wire.NewOutPointFromString(sig.Outpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outpoint %s: %w", sig.Outpoint, err)
+		}
+		result = append(result, application.Signature{
+			Outpoint:   *outpoint,
+			Txid:       sig.Txid,
+			InputIndex: sig.InputIndex,
+			Kind:       application.SignatureKind(sig.Kind),
+			ScriptHash: sig.ScriptHash,
+			SigHash:    txscript.SigHashType(sig.SigHash),
+			Failed:     sig.Failed,
+			CreatedAt:  sig.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningJournal(t *testing.T) {
+	ctx := context.Background()
+
+	journal, err := NewSigningJournal(t.TempDir())
+	require.NoError(t, err)
+	defer journal.Close()
+
+	outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	otherOutpoint := wire.OutPoint{Hash: chainhash.Hash{2}, Index: 0}
+
+	signatures := []application.Signature{
+		{
+			Outpoint:   outpoint,
+			Txid:       "tx1",
+			InputIndex: 0,
+			Kind:       application.SignatureKindCheckpointTx,
+			ScriptHash: "aa",
+			SigHash:    txscript.SigHashDefault,
+			CreatedAt:  1,
+		},
+		{
+			Outpoint:   otherOutpoint,
+			Txid:       "tx2",
+			InputIndex: 1,
+			Kind:       application.SignatureKindArkTx,
+			ScriptHash: "bb",
+			SigHash:    txscript.SigHashAll,
+			CreatedAt:  2,
+		},
+	}
+	require.NoError(t, journal.Add(ctx, signatures...))
+	// adding the same signatures again doesn't duplicate them
+	require.NoError(t, journal.Add(ctx, signatures...))
+
+	got, err := journal.GetByOutpoint(ctx, outpoint)
+	require.NoError(t, err)
+	require.Equal(t, []application.Signature{signatures[0]}, got)
+
+	got, err = journal.ListRecent(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []application.Signature{signatures[1]}, got)
+
+	require.NoError(t, journal.MarkFailed(ctx, "tx1"))
+
+	got, err = journal.GetByOutpoint(ctx, outpoint)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.True(t, got[0].Failed)
+
+	got, err = journal.GetByOutpoint(ctx, otherOutpoint)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.False(t, got[0].Failed)
+
+	got, err = journal.GetByOutpoint(ctx, wire.OutPoint{Hash: chainhash.Hash{3}})
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,70 @@
+package badgerdb
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const spendingStoreDir = "spendings"
+
+type spending struct {
+	Amount    int64
+	Assets    map[string]uint64
+	CreatedAt int64 `badgerhold:"index"`
+}
+
+type spendingStore struct {
+	db *store
+}
+
+// NewSpendingStore opens the store in the given datadir, in memory if empty
+func NewSpendingStore(datadir string) (application.SpendingStore, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, spendingStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spending store: %w", err)
+	}
+	return &spendingStore{db}, nil
+}
+
+func (s *spendingStore) Add(_ context.Context, record application.Spending) error {
+	return s.db.Insert(badgerhold.NextSequence(), spending{
+		Amount:    record.Amount,
+		Assets:    record.Assets,
+		CreatedAt: record.CreatedAt,
+	})
+}
+
+func (s *spendingStore) List(_ context.Context) ([]application.Spending, error) {
+	var spendings []spending
+	if err := s.db.Find(&spendings, (&badgerhold.Query{}).SortBy("CreatedAt")); err != nil {
+		return nil, err
+	}
+	result := make([]application.Spending, 0, len(spendings))
+	for _, record := range spendings {
+		result = append(result, application.Spending{
+			Amount:    record.Amount,
+			Assets:    record.Assets,
+			CreatedAt: record.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (s *spendingStore) DeleteBefore(_ context.Context, before int64) error {
+	return s.db.DeleteMatching(
+		&spending{}, badgerhold.Where("CreatedAt").Lt(before).Index("CreatedAt"),
+	)
+}
+
+func (s *spendingStore) Close() {
+	// nolint:all
+	s.db.Close()
+}
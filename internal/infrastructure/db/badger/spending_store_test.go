@@ -0,0 +1,43 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendingStore(t *testing.T) {
+	ctx := context.Background()
+	datadir := t.TempDir()
+
+	spendings, err := NewSpendingStore(datadir)
+	require.NoError(t, err)
+
+	list, err := spendings.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	old := application.Spending{Amount: 1_000, CreatedAt: 10}
+	recent := application.Spending{Amount: 2_000, Assets: map[string]uint64{"asset": 5}, CreatedAt: 20}
+	require.NoError(t, spendings.Add(ctx, recent))
+	require.NoError(t, spendings.Add(ctx, old))
+	// the same amounts may be spent twice at once
+	require.NoError(t, spendings.Add(ctx, recent))
+	spendings.Close()
+
+	// the spendings survive a restart
+	spendings, err = NewSpendingStore(datadir)
+	require.NoError(t, err)
+	defer spendings.Close()
+
+	list, err = spendings.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []application.Spending{old, recent, recent}, list)
+
+	require.NoError(t, spendings.DeleteBefore(ctx, 20))
+	list, err = spendings.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []application.Spending{recent, recent}, list)
+}
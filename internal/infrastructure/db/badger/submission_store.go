@@ -0,0 +1,82 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const submissionStoreDir = "submissions"
+
+type submission struct {
+	Id          string
+	Kind        string
+	Status      string
+	Tx          string
+	Checkpoints []string
+	ArkdPubKey  string
+	CreatedAt   int64
+}
+
+type submissionStore struct {
+	db *store
+}
+
+// NewSubmissionStore opens the store in the given datadir, in memory if empty
+func NewSubmissionStore(datadir string) (application.SubmissionStore, error) {
+	dir := datadir
+	if dir != "" {
+		dir = filepath.Join(datadir, submissionStoreDir)
+	}
+	db, err := createDB(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open submission store: %w", err)
+	}
+	return &submissionStore{db}, nil
+}
+
+func (s *submissionStore) Add(_ context.Context, sub application.Submission) error {
+	return s.db.Upsert(submissionKey(sub.Kind, sub.Id), submission{
+		Id:          sub.Id,
+		Kind:        string(sub.Kind),
+		Status:      string(sub.Status),
+		Tx:          sub.Tx,
+		Checkpoints: sub.Checkpoints,
+		ArkdPubKey:  sub.ArkdPubKey,
+		CreatedAt:   sub.CreatedAt,
+	})
+}
+
+func (s *submissionStore) Get(
+	_ context.Context, kind application.SubmissionKind, id string,
+) (*application.Submission, error) {
+	var sub submission
+	if err := s.db.Get(submissionKey(kind, id), &sub); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, application.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	return &application.Submission{
+		Id:          sub.Id,
+		Kind:        application.SubmissionKind(sub.Kind),
+		Status:      application.SubmissionStatus(sub.Status),
+		Tx:          sub.Tx,
+		Checkpoints: sub.Checkpoints,
+		ArkdPubKey:  sub.ArkdPubKey,
+		CreatedAt:   sub.CreatedAt,
+	}, nil
+}
+
+func (s *submissionStore) Close() {
+	// nolint:all
+	s.db.Close()
+}
+
+func submissionKey(kind application.SubmissionKind, id string) string {
+	return fmt.Sprintf("%s:%s", kind, id)
+}
@@ -0,0 +1,39 @@
+package badgerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSubmissionStore("")
+	require.NoError(t, err)
+	defer store.Close()
+
+	_, err = store.Get(ctx, application.SubmissionKindTx, "txid")
+	require.ErrorIs(t, err, application.ErrSubmissionNotFound)
+
+	submission := application.Submission{
+		Id:          "txid",
+		Kind:        application.SubmissionKindTx,
+		Status:      application.SubmissionStatusSubmitted,
+		Tx:          "ark-tx",
+		Checkpoints: []string{"checkpoint-a"},
+		ArkdPubKey:  "arkd",
+		CreatedAt:   1,
+	}
+	require.NoError(t, store.Add(ctx, submission))
+
+	got, err := store.Get(ctx, application.SubmissionKindTx, "txid")
+	require.NoError(t, err)
+	require.Equal(t, submission, *got)
+
+	// txs and intents don't share the same namespace
+	_, err = store.Get(ctx, application.SubmissionKindIntent, "txid")
+	require.ErrorIs(t, err, application.ErrSubmissionNotFound)
+}
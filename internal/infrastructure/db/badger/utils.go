@@ -0,0 +1,87 @@
+package badgerdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/options"
+	log "github.com/sirupsen/logrus"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+// store is a badgerhold store whose value log is garbage collected in
+// background until it's closed
+type store struct {
+	*badgerhold.Store
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// Close stops the value log gc before closing the store
+func (s *store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	s.wg.Wait()
+	return s.Store.Close()
+}
+
+// createDB opens a badgerhold store in the given dir, in memory if empty
+func createDB(dbDir string) (*store, error) {
+	isInMemory := len(dbDir) <= 0
+
+	logger := log.WithField("component", "badger")
+
+	opts := badger.DefaultOptions(dbDir)
+	opts.Logger = &badgerLogger{logger}
+
+	if isInMemory {
+		opts.InMemory = true
+	} else {
+		opts.Compression = options.ZSTD
+	}
+
+	db, err := badgerhold.Open(badgerhold.Options{
+		Encoder:          badgerhold.DefaultEncode,
+		Decoder:          badgerhold.DefaultDecode,
+		SequenceBandwith: 100,
+		Options:          opts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &store{Store: db, done: make(chan struct{})}
+	if !isInMemory {
+		ticker := time.NewTicker(30 * time.Minute)
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer ticker.Stop()
+			for {
+				select {
+				case <-s.done:
+					return
+				case <-ticker.C:
+				}
+				if err := db.Badger().RunValueLogGC(0.5); err != nil && err != badger.ErrNoRewrite {
+					logger.Errorf("%s", err)
+				}
+			}
+		}()
+	}
+
+	return s, nil
+}
+
+// badgerLogger demotes badger info logs to debug, they are too verbose
+type badgerLogger struct {
+	*log.Entry
+}
+
+func (l *badgerLogger) Infof(format string, args ...any) {
+	l.Debugf(format, args...)
+}
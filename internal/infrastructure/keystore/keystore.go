@@ -0,0 +1,576 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreFile = "keystore.json"
+
+	// version 1 holds a single key, version 2 an ordered set of keys
+	keystoreVersionSingleKey = 1
+	keystoreVersion          = 2
+	kdfScrypt                = "scrypt"
+	cipherAESGCM             = "aes-256-gcm"
+
+	saltSize = 32
+	keySize  = 32
+)
+
+// default scrypt parameters, ~100ms and 32MB of memory per derivation
+var defaultScryptParams = scryptParams{N: 1 << 15, R: 8, P: 1}
+
+// bounds of the scrypt parameters read from the keystore file, so that a
+// tampered file can't make the unlock exhaust the memory or the cpu
+const (
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+)
+
+type scryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+type cryptoSection struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+// encryptedKey is the on-disk representation of a signer key.
+// The public key is stored in clear so that it can be advertised while the
+// keystore is locked, it is bound to the ciphertext as AEAD additional data.
+type encryptedKey struct {
+	PubKey    string        `json:"pubkey"`
+	CreatedAt int64         `json:"created_at,omitempty"`
+	SunsetAt  int64         `json:"sunset_at,omitempty"`
+	Crypto    cryptoSection `json:"crypto"`
+}
+
+// keystoreData is the content of the keystore file, keys are ordered by
+// creation time: the last one is the current key.
+type keystoreData struct {
+	Version int            `json:"version"`
+	Keys    []encryptedKey `json:"keys"`
+}
+
+// singleKeyData is the version 1 format, migrated to version 2 on load
+type singleKeyData struct {
+	Version int           `json:"version"`
+	PubKey  string        `json:"pubkey"`
+	Crypto  cryptoSection `json:"crypto"`
+}
+
+type keystore struct {
+	path   string
+	params scryptParams
+
+	lock       sync.RWMutex
+	keys       []encryptedKey
+	pubkeys    []*btcec.PublicKey
+	secretKeys []*btcec.PrivateKey
+}
+
+// New loads the keystore file from the given datadir, if any.
+// The returned keystore is always locked.
+func New(datadir string) (application.Keystore, error) {
+	return newKeystore(datadir, defaultScryptParams)
+}
+
+func newKeystore(datadir string, params scryptParams) (*keystore, error) {
+	if err := os.MkdirAll(datadir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create datadir: %w", err)
+	}
+
+	ks := &keystore{
+		path:   filepath.Join(datadir, keystoreFile),
+		params: params,
+	}
+
+	buf, err := os.ReadFile(ks.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ks, nil
+		}
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	keys, err := decodeKeystore(buf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+
+	pubkeys := make([]*btcec.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		if err := key.validate(); err != nil {
+			return nil, fmt.Errorf("invalid keystore: %w", err)
+		}
+		pubkey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid keystore: %w", err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	ks.keys = keys
+	ks.pubkeys = pubkeys
+	return ks, nil
+}
+
+func (k *keystore) IsInitialized() bool {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return len(k.keys) > 0
+}
+
+func (k *keystore) IsLocked() bool {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.secretKeys == nil
+}
+
+func (k *keystore) SignerKeys() ([]application.SignerKey, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	if len(k.keys) == 0 {
+		return nil, application.ErrKeystoreNotInitialized
+	}
+
+	signerKeys := make([]application.SignerKey, 0, len(k.keys))
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		signerKeys = append(signerKeys, application.SignerKey{
+			PublicKey: k.pubkeys[i],
+			CreatedAt: k.keys[i].CreatedAt,
+			SunsetAt:  k.keys[i].SunsetAt,
+			Current:   i == len(k.keys)-1,
+		})
+	}
+	return signerKeys, nil
+}
+
+func (k *keystore) SecretKeys() ([]*btcec.PrivateKey, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	if len(k.keys) == 0 {
+		return nil, application.ErrKeystoreNotInitialized
+	}
+	if k.secretKeys == nil {
+		return nil, application.ErrKeystoreLocked
+	}
+
+	// the keys are copied, Lock wipes only the ones held by the keystore
+	secretKeys := make([]*btcec.PrivateKey, 0, len(k.secretKeys))
+	for i := len(k.secretKeys) - 1; i >= 0; i-- {
+		secretKeys = append(secretKeys, copySecretKey(k.secretKeys[i]))
+	}
+	return secretKeys, nil
+}
+
+func (k *keystore) Create(password string, secretKey *btcec.PrivateKey) error {
+	if len(password) == 0 {
+		return fmt.Errorf("missing password")
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keys) > 0 {
+		return application.ErrKeystoreAlreadyInitialized
+	}
+
+	secretKey, err := newSecretKeyIfNil(secretKey)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := encrypt(secretKey, password, k.params)
+	if err != nil {
+		return err
+	}
+	keys := []encryptedKey{*encrypted}
+	if err := k.write(keys); err != nil {
+		return err
+	}
+
+	k.keys = keys
+	k.pubkeys = []*btcec.PublicKey{secretKey.PubKey()}
+	k.secretKeys = []*btcec.PrivateKey{secretKey}
+	return nil
+}
+
+func (k *keystore) AddKey(password string, secretKey *btcec.PrivateKey) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keys) == 0 {
+		return application.ErrKeystoreNotInitialized
+	}
+	if k.secretKeys == nil {
+		return application.ErrKeystoreLocked
+	}
+
+	// all keys are encrypted with the same password
+	currentKey, err := decrypt(&k.keys[len(k.keys)-1], password)
+	if err != nil {
+		return err
+	}
+	currentKey.Zero()
+
+	secretKey, err = newSecretKeyIfNil(secretKey)
+	if err != nil {
+		return err
+	}
+	for _, pubkey := range k.pubkeys {
+		if pubkey.IsEqual(secretKey.PubKey()) {
+			return application.ErrSignerKeyAlreadyExists
+		}
+	}
+
+	encrypted, err := encrypt(secretKey, password, k.params)
+	if err != nil {
+		return err
+	}
+	keys := append(append([]encryptedKey{}, k.keys...), *encrypted)
+	if err := k.write(keys); err != nil {
+		return err
+	}
+
+	k.keys = keys
+	k.pubkeys = append(k.pubkeys, secretKey.PubKey())
+	k.secretKeys = append(k.secretKeys, secretKey)
+	return nil
+}
+
+func (k *keystore) RetireKey(pubkey *btcec.PublicKey, sunsetAt int64) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keys) == 0 {
+		return application.ErrKeystoreNotInitialized
+	}
+
+	index := -1
+	for i, key := range k.pubkeys {
+		if key.IsEqual(pubkey) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return application.ErrSignerKeyNotFound
+	}
+	if index == len(k.keys)-1 {
+		return application.ErrCurrentSignerKey
+	}
+
+	if sunsetAt <= 0 {
+		sunsetAt = time.Now().Unix()
+	}
+
+	keys := append([]encryptedKey{}, k.keys...)
+	keys[index].SunsetAt = sunsetAt
+	if err := k.write(keys); err != nil {
+		return err
+	}
+
+	k.keys = keys
+	return nil
+}
+
+func (k *keystore) Unlock(password string) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keys) == 0 {
+		return application.ErrKeystoreNotInitialized
+	}
+	if k.secretKeys != nil {
+		return nil
+	}
+
+	secretKeys, err := decryptAll(k.keys, password)
+	if err != nil {
+		return err
+	}
+
+	k.secretKeys = secretKeys
+	return nil
+}
+
+func (k *keystore) Lock() error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keys) == 0 {
+		return application.ErrKeystoreNotInitialized
+	}
+
+	for _, secretKey := range k.secretKeys {
+		secretKey.Zero()
+	}
+	k.secretKeys = nil
+	return nil
+}
+
+func (k *keystore) ChangePassword(currentPassword, newPassword string) error {
+	if len(newPassword) == 0 {
+		return fmt.Errorf("missing new password")
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keys) == 0 {
+		return application.ErrKeystoreNotInitialized
+	}
+
+	secretKeys, err := decryptAll(k.keys, currentPassword)
+	if err != nil {
+		return err
+	}
+	// the decrypted copies are only needed to re-encrypt, the unlocked state
+	// of the keystore is left untouched
+	defer func() {
+		for _, secretKey := range secretKeys {
+			secretKey.Zero()
+		}
+	}()
+
+	keys := make([]encryptedKey, 0, len(k.keys))
+	for i, secretKey := range secretKeys {
+		encrypted, err := encrypt(secretKey, newPassword, k.params)
+		if err != nil {
+			return err
+		}
+		encrypted.CreatedAt = k.keys[i].CreatedAt
+		encrypted.SunsetAt = k.keys[i].SunsetAt
+		keys = append(keys, *encrypted)
+	}
+	if err := k.write(keys); err != nil {
+		return err
+	}
+
+	k.keys = keys
+	return nil
+}
+
+// write atomically replaces the keystore file
+func (k *keystore) write(keys []encryptedKey) error {
+	buf, err := json.MarshalIndent(keystoreData{
+		Version: keystoreVersion,
+		Keys:    keys,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode keystore: %w", err)
+	}
+
+	tmpPath := k.path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf, 0600); err != nil {
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	if err := os.Rename(tmpPath, k.path); err != nil {
+		// nolint:all
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	return nil
+}
+
+func decodeKeystore(buf []byte) ([]encryptedKey, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(buf, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode keystore: %w", err)
+	}
+
+	switch header.Version {
+	case keystoreVersionSingleKey:
+		var data singleKeyData
+		if err := json.Unmarshal(buf, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode keystore: %w", err)
+		}
+		return []encryptedKey{{PubKey: data.PubKey, Crypto: data.Crypto}}, nil
+	case keystoreVersion:
+		var data keystoreData
+		if err := json.Unmarshal(buf, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode keystore: %w", err)
+		}
+		if len(data.Keys) == 0 {
+			return nil, fmt.Errorf("no keys")
+		}
+		return data.Keys, nil
+	default:
+		return nil, fmt.Errorf("unsupported version %d", header.Version)
+	}
+}
+
+// newSecretKeyIfNil copies the given key so that the caller keeps its own
+func newSecretKeyIfNil(secretKey *btcec.PrivateKey) (*btcec.PrivateKey, error) {
+	if secretKey != nil {
+		return copySecretKey(secretKey), nil
+	}
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret key: %w", err)
+	}
+	return key, nil
+}
+
+func copySecretKey(secretKey *btcec.PrivateKey) *btcec.PrivateKey {
+	return &btcec.PrivateKey{Key: secretKey.Key}
+}
+
+func encrypt(secretKey *btcec.PrivateKey, password string, params scryptParams) (*encryptedKey, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	params.Salt = hex.EncodeToString(salt)
+
+	aead, err := newAEAD(password, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	pubkey := secretKey.PubKey().SerializeCompressed()
+	plaintext := secretKey.Serialize()
+	defer clear(plaintext)
+	ciphertext := aead.Seal(nil, nonce, plaintext, pubkey)
+
+	return &encryptedKey{
+		PubKey:    hex.EncodeToString(pubkey),
+		CreatedAt: time.Now().Unix(),
+		Crypto: cryptoSection{
+			KDF:        kdfScrypt,
+			KDFParams:  params,
+			Cipher:     cipherAESGCM,
+			Nonce:      hex.EncodeToString(nonce),
+			Ciphertext: hex.EncodeToString(ciphertext),
+		},
+	}, nil
+}
+
+func decryptAll(keys []encryptedKey, password string) ([]*btcec.PrivateKey, error) {
+	secretKeys := make([]*btcec.PrivateKey, 0, len(keys))
+	for i := range keys {
+		secretKey, err := decrypt(&keys[i], password)
+		if err != nil {
+			for _, secretKey := range secretKeys {
+				secretKey.Zero()
+			}
+			return nil, err
+		}
+		secretKeys = append(secretKeys, secretKey)
+	}
+	return secretKeys, nil
+}
+
+func decrypt(encrypted *encryptedKey, password string) (*btcec.PrivateKey, error) {
+	aead, err := newAEAD(password, encrypted.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(encrypted.Crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(encrypted.Crypto.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+	pubkey, err := hex.DecodeString(encrypted.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore pubkey: %w", err)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, pubkey)
+	if err != nil {
+		return nil, application.ErrInvalidPassword
+	}
+	defer clear(plaintext)
+
+	secretKey, _ := btcec.PrivKeyFromBytes(plaintext)
+	return secretKey, nil
+}
+
+func newAEAD(password string, params scryptParams) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+	defer clear(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *encryptedKey) validate() error {
+	if e.Crypto.KDF != kdfScrypt {
+		return fmt.Errorf("unsupported kdf %s", e.Crypto.KDF)
+	}
+	if e.Crypto.Cipher != cipherAESGCM {
+		return fmt.Errorf("unsupported cipher %s", e.Crypto.Cipher)
+	}
+	return e.Crypto.KDFParams.validate()
+}
+
+func (p scryptParams) validate() error {
+	if p.N <= 1 || p.N > maxScryptN || p.N&(p.N-1) != 0 {
+		return fmt.Errorf("invalid scrypt n %d", p.N)
+	}
+	if p.R < 1 || p.R > maxScryptR {
+		return fmt.Errorf("invalid scrypt r %d", p.R)
+	}
+	if p.P < 1 || p.P > maxScryptP {
+		return fmt.Errorf("invalid scrypt p %d", p.P)
+	}
+	// scrypt allocates 128 * n * r bytes
+	if 128*p.N*p.R > maxScryptMemory {
+		return fmt.Errorf("scrypt parameters n %d and r %d use too much memory", p.N, p.R)
+	}
+	return nil
+}
+
+func (e *encryptedKey) publicKey() (*btcec.PublicKey, error) {
+	buf, err := hex.DecodeString(e.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pubkey: %w", err)
+	}
+	return btcec.ParsePubKey(buf)
+}
@@ -0,0 +1,247 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// lighter scrypt parameters to keep tests fast
+var testScryptParams = scryptParams{N: 1 << 10, R: 8, P: 1}
+
+func TestKeystore(t *testing.T) {
+	t.Run("create and reload", func(t *testing.T) {
+		datadir := t.TempDir()
+		ks, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.False(t, ks.IsInitialized())
+		require.True(t, ks.IsLocked())
+
+		_, err = ks.SignerKeys()
+		require.ErrorIs(t, err, application.ErrKeystoreNotInitialized)
+		_, err = ks.SecretKeys()
+		require.ErrorIs(t, err, application.ErrKeystoreNotInitialized)
+
+		secretKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		require.NoError(t, ks.Create("password", secretKey))
+		require.True(t, ks.IsInitialized())
+		require.False(t, ks.IsLocked())
+
+		err = ks.Create("password", nil)
+		require.ErrorIs(t, err, application.ErrKeystoreAlreadyInitialized)
+
+		info, err := os.Stat(filepath.Join(datadir, keystoreFile))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		reloaded, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.True(t, reloaded.IsInitialized())
+		require.True(t, reloaded.IsLocked())
+
+		signerKeys, err := reloaded.SignerKeys()
+		require.NoError(t, err)
+		require.Len(t, signerKeys, 1)
+		require.True(t, signerKeys[0].Current)
+		require.True(t, signerKeys[0].PublicKey.IsEqual(secretKey.PubKey()))
+
+		_, err = reloaded.SecretKeys()
+		require.ErrorIs(t, err, application.ErrKeystoreLocked)
+
+		require.ErrorIs(t, reloaded.Unlock("wrong"), application.ErrInvalidPassword)
+		require.NoError(t, reloaded.Unlock("password"))
+
+		got, err := reloaded.SecretKeys()
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, secretKey.Serialize(), got[0].Serialize())
+
+		require.NoError(t, reloaded.Lock())
+		require.True(t, reloaded.IsLocked())
+		_, err = reloaded.SecretKeys()
+		require.ErrorIs(t, err, application.ErrKeystoreLocked)
+	})
+
+	t.Run("generate key", func(t *testing.T) {
+		ks, err := newKeystore(t.TempDir(), testScryptParams)
+		require.NoError(t, err)
+		require.NoError(t, ks.Create("password", nil))
+
+		secretKeys, err := ks.SecretKeys()
+		require.NoError(t, err)
+		signerKeys, err := ks.SignerKeys()
+		require.NoError(t, err)
+		require.True(t, signerKeys[0].PublicKey.IsEqual(secretKeys[0].PubKey()))
+	})
+
+	t.Run("lock keeps the keys handed out", func(t *testing.T) {
+		ks, err := newKeystore(t.TempDir(), testScryptParams)
+		require.NoError(t, err)
+		secretKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		serialized := secretKey.Serialize()
+		require.NoError(t, ks.Create("password", secretKey))
+
+		secretKeys, err := ks.SecretKeys()
+		require.NoError(t, err)
+		held := ks.secretKeys[0]
+		require.NoError(t, ks.Lock())
+
+		// only the key held by the keystore is wiped, a signing in flight can
+		// still use its copy
+		require.True(t, held.Key.IsZero())
+		require.Equal(t, serialized, secretKeys[0].Serialize())
+		require.Equal(t, serialized, secretKey.Serialize())
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		datadir := t.TempDir()
+		ks, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.NoError(t, ks.Create("password", nil))
+		require.NoError(t, ks.Lock())
+
+		err = ks.ChangePassword("wrong", "new-password")
+		require.ErrorIs(t, err, application.ErrInvalidPassword)
+
+		require.NoError(t, ks.ChangePassword("password", "new-password"))
+		// changing the password doesn't unlock the keystore
+		require.True(t, ks.IsLocked())
+
+		reloaded, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.ErrorIs(t, reloaded.Unlock("password"), application.ErrInvalidPassword)
+		require.NoError(t, reloaded.Unlock("new-password"))
+	})
+
+	t.Run("tampered pubkey", func(t *testing.T) {
+		datadir := t.TempDir()
+		ks, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.NoError(t, ks.Create("password", nil))
+
+		otherKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		keys := append([]encryptedKey{}, ks.keys...)
+		keys[0].PubKey = hex.EncodeToString(otherKey.PubKey().SerializeCompressed())
+		require.NoError(t, ks.write(keys))
+
+		reloaded, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		// the pubkey is authenticated as additional data
+		require.ErrorIs(t, reloaded.Unlock("password"), application.ErrInvalidPassword)
+	})
+
+	t.Run("tampered scrypt params", func(t *testing.T) {
+		datadir := t.TempDir()
+		ks, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.NoError(t, ks.Create("password", nil))
+
+		for _, params := range []scryptParams{
+			{N: 1 << 30, R: 8, P: 1},
+			{N: 1000, R: 8, P: 1},
+			{N: 1 << 10, R: 0, P: 1},
+			{N: 1 << 10, R: 8, P: 1 << 20},
+			{N: 1 << 20, R: 32, P: 1},
+		} {
+			keys := append([]encryptedKey{}, ks.keys...)
+			params.Salt = keys[0].Crypto.KDFParams.Salt
+			keys[0].Crypto.KDFParams = params
+			require.NoError(t, ks.write(keys))
+
+			_, err := newKeystore(datadir, testScryptParams)
+			require.ErrorContains(t, err, "invalid keystore")
+		}
+	})
+
+	t.Run("rotate keys", func(t *testing.T) {
+		datadir := t.TempDir()
+		ks, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+
+		oldKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		require.NoError(t, ks.Create("password", oldKey))
+
+		err = ks.AddKey("wrong", nil)
+		require.ErrorIs(t, err, application.ErrInvalidPassword)
+		err = ks.AddKey("password", oldKey)
+		require.ErrorIs(t, err, application.ErrSignerKeyAlreadyExists)
+
+		newKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		require.NoError(t, ks.AddKey("password", newKey))
+
+		// the current key can't be retired
+		err = ks.RetireKey(newKey.PubKey(), 0)
+		require.ErrorIs(t, err, application.ErrCurrentSignerKey)
+		require.NoError(t, ks.RetireKey(oldKey.PubKey(), 1700000000))
+
+		otherKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		err = ks.RetireKey(otherKey.PubKey(), 0)
+		require.ErrorIs(t, err, application.ErrSignerKeyNotFound)
+
+		reloaded, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.NoError(t, reloaded.Unlock("password"))
+
+		signerKeys, err := reloaded.SignerKeys()
+		require.NoError(t, err)
+		require.Len(t, signerKeys, 2)
+		require.True(t, signerKeys[0].Current)
+		require.True(t, signerKeys[0].PublicKey.IsEqual(newKey.PubKey()))
+		require.Zero(t, signerKeys[0].SunsetAt)
+		require.False(t, signerKeys[1].Current)
+		require.True(t, signerKeys[1].PublicKey.IsEqual(oldKey.PubKey()))
+		require.Equal(t, int64(1700000000), signerKeys[1].SunsetAt)
+
+		secretKeys, err := reloaded.SecretKeys()
+		require.NoError(t, err)
+		require.Len(t, secretKeys, 2)
+		require.Equal(t, newKey.Serialize(), secretKeys[0].Serialize())
+		require.Equal(t, oldKey.Serialize(), secretKeys[1].Serialize())
+
+		// all keys are re-encrypted with the new password
+		require.NoError(t, reloaded.ChangePassword("password", "new-password"))
+		require.NoError(t, reloaded.Lock())
+		require.NoError(t, reloaded.Unlock("new-password"))
+		secretKeys, err = reloaded.SecretKeys()
+		require.NoError(t, err)
+		require.Len(t, secretKeys, 2)
+	})
+
+	t.Run("migrate single key format", func(t *testing.T) {
+		datadir := t.TempDir()
+		secretKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		encrypted, err := encrypt(secretKey, "password", testScryptParams)
+		require.NoError(t, err)
+		buf, err := json.Marshal(singleKeyData{
+			Version: keystoreVersionSingleKey,
+			PubKey:  encrypted.PubKey,
+			Crypto:  encrypted.Crypto,
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(datadir, keystoreFile), buf, 0600))
+
+		ks, err := newKeystore(datadir, testScryptParams)
+		require.NoError(t, err)
+		require.NoError(t, ks.Unlock("password"))
+
+		secretKeys, err := ks.SecretKeys()
+		require.NoError(t, err)
+		require.Len(t, secretKeys, 1)
+		require.Equal(t, secretKey.Serialize(), secretKeys[0].Serialize())
+	})
+}
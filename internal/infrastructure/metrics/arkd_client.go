@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/go-sdk/client"
+)
+
+// arkdClient measures the latency and the errors of the arkd requests made
+// by the introspector, the streams are passed through
+type arkdClient struct {
+	client.TransportClient
+	url     string
+	metrics *Prometheus
+}
+
+// InstrumentArkdClient wraps the client of the arkd at url
+func (p *Prometheus) InstrumentArkdClient(url string, c client.TransportClient) client.TransportClient {
+	return &arkdClient{c, url, p}
+}
+
+func (c *arkdClient) GetInfo(ctx context.Context) (*client.Info, error) {
+	start := time.Now()
+	info, err := c.TransportClient.GetInfo(ctx)
+	c.metrics.observeArkd(c.url, "GetInfo", start, err)
+	return info, err
+}
+
+func (c *arkdClient) RegisterIntent(ctx context.Context, proof, message string) (string, error) {
+	start := time.Now()
+	intentId, err := c.TransportClient.RegisterIntent(ctx, proof, message)
+	c.metrics.observeArkd(c.url, "RegisterIntent", start, err)
+	return intentId, err
+}
+
+func (c *arkdClient) DeleteIntent(ctx context.Context, proof, message string) error {
+	start := time.Now()
+	err := c.TransportClient.DeleteIntent(ctx, proof, message)
+	c.metrics.observeArkd(c.url, "DeleteIntent", start, err)
+	return err
+}
+
+func (c *arkdClient) ConfirmRegistration(ctx context.Context, intentID string) error {
+	start := time.Now()
+	err := c.TransportClient.ConfirmRegistration(ctx, intentID)
+	c.metrics.observeArkd(c.url, "ConfirmRegistration", start, err)
+	return err
+}
+
+func (c *arkdClient) SubmitTreeNonces(
+	ctx context.Context, batchId, cosignerPubkey string, nonces tree.TreeNonces,
+) error {
+	start := time.Now()
+	err := c.TransportClient.SubmitTreeNonces(ctx, batchId, cosignerPubkey, nonces)
+	c.metrics.observeArkd(c.url, "SubmitTreeNonces", start, err)
+	return err
+}
+
+func (c *arkdClient) SubmitTreeSignatures(
+	ctx context.Context, batchId, cosignerPubkey string, signatures tree.TreePartialSigs,
+) error {
+	start := time.Now()
+	err := c.TransportClient.SubmitTreeSignatures(ctx, batchId, cosignerPubkey, signatures)
+	c.metrics.observeArkd(c.url, "SubmitTreeSignatures", start, err)
+	return err
+}
+
+func (c *arkdClient) SubmitSignedForfeitTxs(
+	ctx context.Context, signedForfeitTxs []string, signedCommitmentTx string,
+) error {
+	start := time.Now()
+	err := c.TransportClient.SubmitSignedForfeitTxs(ctx, signedForfeitTxs, signedCommitmentTx)
+	c.metrics.observeArkd(c.url, "SubmitSignedForfeitTxs", start, err)
+	return err
+}
+
+func (c *arkdClient) SubmitTx(
+	ctx context.Context, signedArkTx string, checkpointTxs []string,
+) (string, string, []string, error) {
+	start := time.Now()
+	arkTxid, finalArkTx, signedCheckpointTxs, err := c.TransportClient.SubmitTx(
+		ctx, signedArkTx, checkpointTxs,
+	)
+	c.metrics.observeArkd(c.url, "SubmitTx", start, err)
+	return arkTxid, finalArkTx, signedCheckpointTxs, err
+}
+
+func (c *arkdClient) FinalizeTx(ctx context.Context, arkTxid string, finalCheckpointTxs []string) error {
+	start := time.Now()
+	err := c.TransportClient.FinalizeTx(ctx, arkTxid, finalCheckpointTxs)
+	c.metrics.observeArkd(c.url, "FinalizeTx", start, err)
+	return err
+}
@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "introspector"
+
+// Prometheus collects the metrics of the service in its own registry
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	rpcRequests      *prometheus.CounterVec
+	rpcDuration      *prometheus.HistogramVec
+	scriptDuration   *prometheus.HistogramVec
+	scriptSteps      prometheus.Histogram
+	opcodes          *prometheus.CounterVec
+	packetEntries    prometheus.Histogram
+	finalizerRoles   *prometheus.CounterVec
+	arkdRequests     *prometheus.CounterVec
+	arkdDuration     *prometheus.HistogramVec
+	finalizeAttempts *prometheus.CounterVec
+}
+
+var _ application.Metrics = (*Prometheus)(nil)
+
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_requests_total",
+			Help:      "Number of rpc requests by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_duration_seconds",
+			Help:      "Duration of the unary rpc requests by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		scriptDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "script_execution_seconds",
+			Help:      "Duration of the arkade script executions by outcome.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{"outcome"}),
+		scriptSteps: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "script_execution_steps",
+			Help:      "Number of opcodes stepped through by an arkade script execution.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		}),
+		opcodes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "opcodes_total",
+			Help:      "Number of times each opcode was stepped through.",
+		}, []string{"opcode"}),
+		packetEntries: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "packet_entries",
+			Help:      "Number of entries of the introspector packets.",
+			Buckets:   []float64{1, 2, 4, 8, 16, 32, 64},
+		}),
+		finalizerRoles: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "finalizer_role_total",
+			Help:      "Number of submitted txs by whether the introspector was the finalizer.",
+		}, []string{"finalizer"}),
+		arkdRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "arkd_requests_total",
+			Help:      "Number of arkd requests by arkd url, method and outcome.",
+		}, []string{"arkd", "method", "outcome"}),
+		arkdDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "arkd_request_duration_seconds",
+			Help:      "Duration of the arkd requests by arkd url and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"arkd", "method"}),
+		finalizeAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "finalize_attempts_total",
+			Help:      "Number of attempts to finalize a tx with arkd by outcome.",
+		}, []string{"outcome"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.rpcRequests, p.rpcDuration, p.scriptDuration, p.scriptSteps, p.opcodes,
+		p.packetEntries, p.finalizerRoles, p.arkdRequests, p.arkdDuration,
+		p.finalizeAttempts,
+	)
+	return p
+}
+
+// Handler serves the metrics in the prometheus text format
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+func (p *Prometheus) ObserveRPC(method string, code codes.Code, duration time.Duration) {
+	p.rpcRequests.WithLabelValues(method, code.String()).Inc()
+	p.rpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) ObserveScript(duration time.Duration, opcodes []string, err error) {
+	p.scriptDuration.WithLabelValues(outcome(err)).Observe(duration.Seconds())
+	p.scriptSteps.Observe(float64(len(opcodes)))
+	for _, opcode := range opcodes {
+		p.opcodes.WithLabelValues(opcode).Inc()
+	}
+}
+
+func (p *Prometheus) ObservePacketEntries(entries int) {
+	p.packetEntries.Observe(float64(entries))
+}
+
+func (p *Prometheus) ObserveFinalizerRole(isFinalizer bool) {
+	p.finalizerRoles.WithLabelValues(strconv.FormatBool(isFinalizer)).Inc()
+}
+
+func (p *Prometheus) ObserveFinalizeAttempt(err error) {
+	p.finalizeAttempts.WithLabelValues(outcome(err)).Inc()
+}
+
+func (p *Prometheus) observeArkd(url, method string, start time.Time, err error) {
+	p.arkdRequests.WithLabelValues(url, method, outcome(err)).Inc()
+	p.arkdDuration.WithLabelValues(url, method).Observe(time.Since(start).Seconds())
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arkade-os/go-sdk/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPrometheus(t *testing.T) {
+	scrape := func(t *testing.T, p *Prometheus) string {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		p.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+		body, err := io.ReadAll(recorder.Result().Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("signing paths", func(t *testing.T) {
+		p := NewPrometheus()
+		p.ObserveRPC("/introspector.v1.IntrospectorService/SubmitTx", codes.InvalidArgument, time.Millisecond)
+		p.ObserveScript(time.Millisecond, []string{"OP_1", "OP_1", "OP_ADD"}, nil)
+		p.ObserveScript(time.Millisecond, []string{"OP_0", "OP_VERIFY"}, fmt.Errorf("failed"))
+		p.ObservePacketEntries(2)
+		p.ObserveFinalizerRole(true)
+		p.ObserveFinalizeAttempt(fmt.Errorf("unavailable"))
+
+		metrics := scrape(t, p)
+		require.Contains(t, metrics,
+			`introspector_rpc_requests_total{code="InvalidArgument",method="/introspector.v1.IntrospectorService/SubmitTx"} 1`)
+		require.Contains(t, metrics, `introspector_script_execution_seconds_count{outcome="success"} 1`)
+		require.Contains(t, metrics, `introspector_script_execution_seconds_count{outcome="error"} 1`)
+		require.Contains(t, metrics, `introspector_script_execution_steps_sum 5`)
+		require.Contains(t, metrics, `introspector_opcodes_total{opcode="OP_1"} 2`)
+		require.Contains(t, metrics, `introspector_packet_entries_sum 2`)
+		require.Contains(t, metrics, `introspector_finalizer_role_total{finalizer="true"} 1`)
+		require.Contains(t, metrics, `introspector_finalize_attempts_total{outcome="error"} 1`)
+	})
+
+	t.Run("arkd client", func(t *testing.T) {
+		p := NewPrometheus()
+		arkd := p.InstrumentArkdClient("arkd:7070", &mockArkdClient{err: fmt.Errorf("unavailable")})
+
+		_, err := arkd.GetInfo(t.Context())
+		require.Error(t, err)
+		require.Error(t, arkd.FinalizeTx(t.Context(), "txid", nil))
+
+		metrics := scrape(t, p)
+		require.Contains(t, metrics,
+			`introspector_arkd_requests_total{arkd="arkd:7070",method="GetInfo",outcome="error"} 1`)
+		require.Contains(t, metrics,
+			`introspector_arkd_request_duration_seconds_count{arkd="arkd:7070",method="FinalizeTx"} 1`)
+	})
+}
+
+type mockArkdClient struct {
+	client.TransportClient
+	err error
+}
+
+func (m *mockArkdClient) GetInfo(context.Context) (*client.Info, error) {
+	return nil, m.err
+}
+
+func (m *mockArkdClient) FinalizeTx(context.Context, string, []string) error {
+	return m.err
+}
@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
+	"github.com/arkade-os/go-sdk/client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+var tracer = otel.Tracer("github.com/ArkLabsHQ/introspector/internal/infrastructure/tracing")
+
+// arkdClient creates a client span for every arkd request and propagates the
+// trace context in the outgoing grpc metadata, the go-sdk client doesn't
+// instrument its connection
+type arkdClient struct {
+	client.TransportClient
+	url string
+}
+
+// InstrumentArkdClient wraps the client of the arkd at url
+func InstrumentArkdClient(url string, c client.TransportClient) client.TransportClient {
+	return &arkdClient{c, url}
+}
+
+func (c *arkdClient) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(
+		ctx, "arkd."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("arkd.url", c.url)),
+	)
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (c *arkdClient) GetInfo(ctx context.Context) (*client.Info, error) {
+	ctx, span := c.start(ctx, "GetInfo")
+	info, err := c.TransportClient.GetInfo(ctx)
+	end(span, err)
+	return info, err
+}
+
+func (c *arkdClient) RegisterIntent(ctx context.Context, proof, message string) (string, error) {
+	ctx, span := c.start(ctx, "RegisterIntent")
+	intentId, err := c.TransportClient.RegisterIntent(ctx, proof, message)
+	end(span, err)
+	return intentId, err
+}
+
+func (c *arkdClient) DeleteIntent(ctx context.Context, proof, message string) error {
+	ctx, span := c.start(ctx, "DeleteIntent")
+	err := c.TransportClient.DeleteIntent(ctx, proof, message)
+	end(span, err)
+	return err
+}
+
+func (c *arkdClient) ConfirmRegistration(ctx context.Context, intentID string) error {
+	ctx, span := c.start(ctx, "ConfirmRegistration")
+	err := c.TransportClient.ConfirmRegistration(ctx, intentID)
+	end(span, err)
+	return err
+}
+
+func (c *arkdClient) SubmitTreeNonces(
+	ctx context.Context, batchId, cosignerPubkey string, nonces tree.TreeNonces,
+) error {
+	ctx, span := c.start(ctx, "SubmitTreeNonces")
+	err := c.TransportClient.SubmitTreeNonces(ctx, batchId, cosignerPubkey, nonces)
+	end(span, err)
+	return err
+}
+
+func (c *arkdClient) SubmitTreeSignatures(
+	ctx context.Context, batchId, cosignerPubkey string, signatures tree.TreePartialSigs,
+) error {
+	ctx, span := c.start(ctx, "SubmitTreeSignatures")
+	err := c.TransportClient.SubmitTreeSignatures(ctx, batchId, cosignerPubkey, signatures)
+	end(span, err)
+	return err
+}
+
+func (c *arkdClient) SubmitSignedForfeitTxs(
+	ctx context.Context, signedForfeitTxs []string, signedCommitmentTx string,
+) error {
+	ctx, span := c.start(ctx, "SubmitSignedForfeitTxs")
+	err := c.TransportClient.SubmitSignedForfeitTxs(ctx, signedForfeitTxs, signedCommitmentTx)
+	end(span, err)
+	return err
+}
+
+func (c *arkdClient) SubmitTx(
+	ctx context.Context, signedArkTx string, checkpointTxs []string,
+) (string, string, []string, error) {
+	ctx, span := c.start(ctx, "SubmitTx")
+	arkTxid, finalArkTx, signedCheckpointTxs, err := c.TransportClient.SubmitTx(
+		ctx, signedArkTx, checkpointTxs,
+	)
+	if err == nil {
+		span.SetAttributes(attribute.String("ark_txid", arkTxid))
+	}
+	end(span, err)
+	return arkTxid, finalArkTx, signedCheckpointTxs, err
+}
+
+func (c *arkdClient) FinalizeTx(ctx context.Context, arkTxid string, finalCheckpointTxs []string) error {
+	ctx, span := c.start(ctx, "FinalizeTx")
+	span.SetAttributes(attribute.String("ark_txid", arkTxid))
+	err := c.TransportClient.FinalizeTx(ctx, arkTxid, finalCheckpointTxs)
+	end(span, err)
+	return err
+}
+
+// metadataCarrier adapts the grpc metadata to the otel propagators
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/arkade-os/go-sdk/client"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestArkdClient(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	mock := &mockArkdClient{}
+	arkd := InstrumentArkdClient("arkd:7070", mock)
+
+	ctx, parent := otel.Tracer("test").Start(t.Context(), "SubmitTx")
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", "1")
+	_, _, _, err := arkd.SubmitTx(ctx, "tx", nil)
+	require.NoError(t, err)
+	parent.End()
+
+	mock.err = fmt.Errorf("unavailable")
+	require.Error(t, arkd.FinalizeTx(t.Context(), "txid", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	submit, finalize := spans[0], spans[2]
+	require.Equal(t, "arkd.SubmitTx", submit.Name())
+	require.Equal(t, parent.SpanContext().TraceID(), submit.SpanContext().TraceID())
+	require.Equal(t, codes.Error, finalize.Status().Code)
+
+	// the span of the arkd call is propagated, the existing metadata is kept
+	require.Equal(t, []string{"1"}, mock.md.Get("x-request-id"))
+	traceparent := mock.md.Get("traceparent")
+	require.Len(t, traceparent, 1)
+	require.Contains(t, traceparent[0], submit.SpanContext().SpanID().String())
+}
+
+type mockArkdClient struct {
+	client.TransportClient
+	md  metadata.MD
+	err error
+}
+
+func (m *mockArkdClient) SubmitTx(ctx context.Context, _ string, _ []string) (string, string, []string, error) {
+	m.md, _ = metadata.FromOutgoingContext(ctx)
+	return "txid", "", nil, m.err
+}
+
+func (m *mockArkdClient) FinalizeTx(context.Context, string, []string) error {
+	return m.err
+}
@@ -17,6 +17,25 @@ type Config struct {
 	NoTLS           bool
 	TLSExtraIPs     []string
 	TLSExtraDomains []string
+	// MetricsPort serves the prometheus metrics in plain http, 0 disables it
+	MetricsPort uint32
+	// AdminPort serves the wallet and admin services apart from the public
+	// ones, on AdminHost. If 0 they are served on Port, which requires
+	// macaroons
+	AdminPort uint32
+	AdminHost string
+	// NoMacaroons serves every rpc without authentication, the wallet and
+	// admin services must be on their own listener
+	NoMacaroons bool
+	// RestrictSigning requires the signer macaroon for the signing rpcs
+	RestrictSigning bool
+	// MaxMsgSize caps the size in bytes of the grpc messages and of the
+	// bodies of the rest requests
+	MaxMsgSize int
+	// RateLimit is the number of requests per second allowed to a client,
+	// with bursts of RateBurst requests. 0 disables the rate limiting
+	RateLimit float64
+	RateBurst int
 }
 
 func (c Config) Validate() error {
@@ -27,6 +46,48 @@ func (c Config) Validate() error {
 	// nolint:all
 	defer lis.Close()
 
+	if c.MetricsPort > 0 {
+		if c.MetricsPort == c.Port {
+			return fmt.Errorf("metrics port must differ from port %d", c.Port)
+		}
+		metricsLis, err := net.Listen("tcp", c.metricsAddress())
+		if err != nil {
+			return fmt.Errorf("invalid metrics port: %s", err)
+		}
+		// nolint:all
+		defer metricsLis.Close()
+	}
+
+	if c.hasAdminListener() {
+		if c.AdminPort == c.Port || c.AdminPort == c.MetricsPort {
+			return fmt.Errorf("admin port must differ from port and metrics port")
+		}
+		adminLis, err := net.Listen("tcp", c.adminAddress())
+		if err != nil {
+			return fmt.Errorf("invalid admin address: %s", err)
+		}
+		// nolint:all
+		defer adminLis.Close()
+	}
+
+	if c.MaxMsgSize <= 0 {
+		return fmt.Errorf("max message size must be positive")
+	}
+
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate limit must not be negative")
+	}
+	if c.RateLimit > 0 && c.RateBurst <= 0 {
+		return fmt.Errorf("rate burst must be positive")
+	}
+
+	if c.NoMacaroons && c.RestrictSigning {
+		return fmt.Errorf("restricting the signing rpcs requires macaroons")
+	}
+	if c.NoMacaroons && !c.hasAdminListener() {
+		return fmt.Errorf("serving without macaroons requires the admin port")
+	}
+
 	if !c.NoTLS {
 		tlsDir := c.tlsDatadir()
 		tlsKeyExists := pathExists(filepath.Join(tlsDir, tlsKeyFile))
@@ -59,14 +120,40 @@ func (c Config) address() string {
 	return fmt.Sprintf(":%d", c.Port)
 }
 
+func (c Config) metricsAddress() string {
+	return fmt.Sprintf(":%d", c.MetricsPort)
+}
+
 func (c Config) gatewayAddress() string {
 	return fmt.Sprintf("127.0.0.1:%d", c.Port)
 }
 
+func (c Config) hasAdminListener() bool {
+	return c.AdminPort > 0
+}
+
+func (c Config) adminAddress() string {
+	return net.JoinHostPort(c.AdminHost, fmt.Sprintf("%d", c.AdminPort))
+}
+
+// adminGatewayAddress is the address the admin gateway dials, the loopback
+// one if the admin listener binds all the interfaces
+func (c Config) adminGatewayAddress() string {
+	host := c.AdminHost
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, fmt.Sprintf("%d", c.AdminPort))
+}
+
 func (c Config) tlsDatadir() string {
 	return filepath.Join(c.Datadir, tlsFolder)
 }
 
+func (c Config) macaroonsDatadir() string {
+	return filepath.Join(c.Datadir, macaroonsFolder)
+}
+
 func (c Config) tlsKey() string {
 	if c.NoTLS {
 		return ""
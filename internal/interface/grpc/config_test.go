@@ -0,0 +1,52 @@
+package grpcservice
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	freePort := func(t *testing.T) uint32 {
+		t.Helper()
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		// nolint:all
+		defer lis.Close()
+		return uint32(lis.Addr().(*net.TCPAddr).Port)
+	}
+	newConfig := func(t *testing.T) Config {
+		return Config{
+			Datadir:    t.TempDir(),
+			Port:       freePort(t),
+			NoTLS:      true,
+			AdminPort:  freePort(t),
+			AdminHost:  "127.0.0.1",
+			MaxMsgSize: 1024,
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, newConfig(t).Validate())
+
+		cfg := newConfig(t)
+		cfg.NoMacaroons = true
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("admin services on the public listener without macaroons", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.AdminPort = 0
+		require.NoError(t, cfg.Validate())
+
+		cfg.NoMacaroons = true
+		require.ErrorContains(t, cfg.Validate(), "requires the admin port")
+	})
+
+	t.Run("admin port same as port", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.AdminPort = cfg.Port
+		require.ErrorContains(t, cfg.Validate(), "admin port must differ")
+	})
+}
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+
+	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type adminHandler struct {
+	version string
+	svc     application.AdminService
+	// effective config by env var name, secrets redacted
+	config map[string]string
+}
+
+func NewAdminHandler(
+	version string, service application.AdminService, config map[string]string,
+) introspectorv1.AdminServiceServer {
+	return &adminHandler{version, service, config}
+}
+
+func (h *adminHandler) GetServiceStatus(
+	ctx context.Context, _ *introspectorv1.GetServiceStatusRequest,
+) (*introspectorv1.GetServiceStatusResponse, error) {
+	serviceStatus, err := h.svc.GetStatus(ctx)
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to get service status")
+	}
+
+	checks := make([]*introspectorv1.ComponentHealth, 0, len(serviceStatus.Health.Checks))
+	for _, check := range serviceStatus.Health.Checks {
+		checks = append(checks, &introspectorv1.ComponentHealth{
+			Component: check.Component,
+			Healthy:   check.Healthy,
+			Detail:    check.Detail,
+		})
+	}
+	arkServers := make([]*introspectorv1.ArkServerStatus, 0, len(serviceStatus.ArkServers))
+	for _, server := range serviceStatus.ArkServers {
+		arkServers = append(arkServers, &introspectorv1.ArkServerStatus{
+			Url:          server.URL,
+			SignerPubkey: server.SignerPublicKey,
+			UpdatedAt:    server.UpdatedAt,
+			LastError:    server.LastError,
+		})
+	}
+
+	return &introspectorv1.GetServiceStatusResponse{
+		Version:       h.version,
+		StartedAt:     serviceStatus.StartedAt,
+		SigningPaused: serviceStatus.SigningPaused,
+		Ready:         serviceStatus.Health.Ready,
+		Checks:        checks,
+		ArkServers:    arkServers,
+	}, nil
+}
+
+func (h *adminHandler) GetKeyInfo(
+	ctx context.Context, _ *introspectorv1.GetKeyInfoRequest,
+) (*introspectorv1.GetKeyInfoResponse, error) {
+	info, err := h.svc.GetKeyInfo(ctx)
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to get key info")
+	}
+
+	now := time.Now()
+	keys := make([]*introspectorv1.KeyInfo, 0, len(info.Keys))
+	for _, key := range info.Keys {
+		keys = append(keys, &introspectorv1.KeyInfo{
+			Pubkey:    hex.EncodeToString(key.PublicKey.SerializeCompressed()),
+			CreatedAt: key.CreatedAt,
+			SunsetAt:  key.SunsetAt,
+			Current:   key.Current,
+			Active:    key.IsActive(now),
+		})
+	}
+
+	return &introspectorv1.GetKeyInfoResponse{
+		Initialized: info.Initialized,
+		Unlocked:    info.Unlocked,
+		Keys:        keys,
+	}, nil
+}
+
+func (h *adminHandler) PauseSigning(
+	ctx context.Context, _ *introspectorv1.PauseSigningRequest,
+) (*introspectorv1.PauseSigningResponse, error) {
+	if err := h.svc.PauseSigning(ctx); err != nil {
+		return nil, toAdminStatusError(err, "failed to pause signing")
+	}
+
+	log.Warn("signing paused")
+	return &introspectorv1.PauseSigningResponse{}, nil
+}
+
+func (h *adminHandler) ResumeSigning(
+	ctx context.Context, _ *introspectorv1.ResumeSigningRequest,
+) (*introspectorv1.ResumeSigningResponse, error) {
+	if err := h.svc.ResumeSigning(ctx); err != nil {
+		return nil, toAdminStatusError(err, "failed to resume signing")
+	}
+
+	log.Info("signing resumed")
+	return &introspectorv1.ResumeSigningResponse{}, nil
+}
+
+func (h *adminHandler) ListSignatures(
+	ctx context.Context, req *introspectorv1.ListSignaturesRequest,
+) (*introspectorv1.ListSignaturesResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+
+	signatures, err := h.svc.ListSignatures(ctx, int(req.GetLimit()))
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to list signatures")
+	}
+
+	list := make([]*introspectorv1.SignatureRecord, 0, len(signatures))
+	for _, signature := range signatures {
+		list = append(list, &introspectorv1.SignatureRecord{
+			Outpoint:   signature.Outpoint.String(),
+			Txid:       signature.Txid,
+			InputIndex: int32(signature.InputIndex),
+			Kind:       string(signature.Kind),
+			ScriptHash: signature.ScriptHash,
+			Sighash:    uint32(signature.SigHash),
+			Failed:     signature.Failed,
+			CreatedAt:  signature.CreatedAt,
+		})
+	}
+
+	return &introspectorv1.ListSignaturesResponse{Signatures: list}, nil
+}
+
+func (h *adminHandler) ListFinalizations(
+	ctx context.Context, _ *introspectorv1.ListFinalizationsRequest,
+) (*introspectorv1.ListFinalizationsResponse, error) {
+	finalizations, err := h.svc.ListFinalizations(ctx)
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to list finalizations")
+	}
+
+	list := make([]*introspectorv1.Finalization, 0, len(finalizations))
+	for _, finalization := range finalizations {
+		list = append(list, &introspectorv1.Finalization{
+			Txid:      finalization.Txid,
+			Status:    string(finalization.Status),
+			Attempts:  int32(finalization.Attempts),
+			LastError: finalization.LastError,
+			CreatedAt: finalization.CreatedAt,
+			UpdatedAt: finalization.UpdatedAt,
+		})
+	}
+
+	return &introspectorv1.ListFinalizationsResponse{Finalizations: list}, nil
+}
+
+func (h *adminHandler) RetryFinalization(
+	ctx context.Context, req *introspectorv1.RetryFinalizationRequest,
+) (*introspectorv1.RetryFinalizationResponse, error) {
+	txid := req.GetTxid()
+	if len(txid) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing txid")
+	}
+
+	if err := h.svc.RetryFinalization(ctx, txid); err != nil {
+		return nil, toAdminStatusError(err, "failed to retry finalization")
+	}
+
+	log.WithField("txid", txid).Info("finalization retry requested")
+	return &introspectorv1.RetryFinalizationResponse{}, nil
+}
+
+func (h *adminHandler) ListIntents(
+	ctx context.Context, _ *introspectorv1.ListIntentsRequest,
+) (*introspectorv1.ListIntentsResponse, error) {
+	records, err := h.svc.ListIntents(ctx)
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to list intents")
+	}
+
+	list := make([]*introspectorv1.IntentRecord, 0, len(records))
+	for _, record := range records {
+		outputs := make([]*introspectorv1.IntentOutput, 0, len(record.Outputs))
+		for _, output := range record.Outputs {
+			outputs = append(outputs, &introspectorv1.IntentOutput{
+				Amount:   output.Amount,
+				Pkscript: output.PkScript,
+				Onchain:  output.Onchain,
+			})
+		}
+		list = append(list, &introspectorv1.IntentRecord{
+			ProofTxid:   record.ProofTxid,
+			Inputs:      record.Inputs,
+			Outputs:     outputs,
+			MessageHash: record.MessageHash,
+			ValidAt:     record.ValidAt,
+			ExpireAt:    record.ExpireAt,
+			CreatedAt:   record.CreatedAt,
+		})
+	}
+
+	return &introspectorv1.ListIntentsResponse{Intents: list}, nil
+}
+
+func (h *adminHandler) GetPolicy(
+	ctx context.Context, _ *introspectorv1.GetPolicyRequest,
+) (*introspectorv1.GetPolicyResponse, error) {
+	policy, err := h.svc.GetPolicy(ctx)
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to get policy")
+	}
+
+	return &introspectorv1.GetPolicyResponse{Policy: toPolicyProto(*policy)}, nil
+}
+
+func (h *adminHandler) UpdatePolicy(
+	ctx context.Context, req *introspectorv1.UpdatePolicyRequest,
+) (*introspectorv1.UpdatePolicyResponse, error) {
+	if req.GetPolicy() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing policy")
+	}
+
+	policy := fromPolicyProto(req.GetPolicy())
+	if err := h.svc.UpdatePolicy(ctx, policy); err != nil {
+		return nil, toAdminStatusError(err, "failed to update policy")
+	}
+
+	log.WithField("allowed_scripts", len(policy.AllowedScriptHashes)).Info("signing policy updated")
+	return &introspectorv1.UpdatePolicyResponse{}, nil
+}
+
+func (h *adminHandler) ReloadPolicy(
+	ctx context.Context, _ *introspectorv1.ReloadPolicyRequest,
+) (*introspectorv1.ReloadPolicyResponse, error) {
+	policy, err := h.svc.ReloadPolicy(ctx)
+	if err != nil {
+		return nil, toAdminStatusError(err, "failed to reload policy")
+	}
+
+	log.WithField("allowed_scripts", len(policy.AllowedScriptHashes)).Info("signing policy reloaded")
+	return &introspectorv1.ReloadPolicyResponse{Policy: toPolicyProto(*policy)}, nil
+}
+
+func (h *adminHandler) GetConfig(
+	_ context.Context, _ *introspectorv1.GetConfigRequest,
+) (*introspectorv1.GetConfigResponse, error) {
+	return &introspectorv1.GetConfigResponse{Config: h.config}, nil
+}
+
+func toAdminStatusError(err error, msg string) error {
+	switch {
+	case errors.Is(err, application.ErrFinalizationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, application.ErrInvalidPolicy):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, application.ErrNoPolicyFile):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, application.ErrFinalizationInProgress),
+		errors.Is(err, application.ErrFinalizationCompleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	log.WithError(err).Error(msg)
+	return status.Error(codes.Internal, msg)
+}
+
+func toPolicyProto(policy application.Policy) *introspectorv1.Policy {
+	scriptRequestTypes := make([]*introspectorv1.ScriptRequestTypes, 0, len(policy.ScriptRequestTypes))
+	for scriptHash, requestTypes := range policy.ScriptRequestTypes {
+		types := make([]string, 0, len(requestTypes))
+		for _, requestType := range requestTypes {
+			types = append(types, string(requestType))
+		}
+		scriptRequestTypes = append(scriptRequestTypes, &introspectorv1.ScriptRequestTypes{
+			ScriptHash:   scriptHash,
+			RequestTypes: types,
+		})
+	}
+	sort.Slice(scriptRequestTypes, func(i, j int) bool {
+		return scriptRequestTypes[i].ScriptHash < scriptRequestTypes[j].ScriptHash
+	})
+
+	return &introspectorv1.Policy{
+		AllowedScriptHashes:  policy.AllowedScriptHashes,
+		MaxPacketEntries:     int32(policy.MaxPacketEntries),
+		AllowedTemplates:     policy.AllowedTemplates,
+		DeniedOpcodes:        policy.DeniedOpcodes,
+		ScriptRequestTypes:   scriptRequestTypes,
+		MaxTxAmount:          policy.MaxTxAmount,
+		MaxTxAssetAmount:     policy.MaxTxAssetAmount,
+		WindowSeconds:        int64(policy.Window / time.Second),
+		MaxWindowAmount:      policy.MaxWindowAmount,
+		MaxWindowAssetAmount: policy.MaxWindowAssetAmount,
+	}
+}
+
+func fromPolicyProto(policy *introspectorv1.Policy) application.Policy {
+	var scriptRequestTypes map[string][]application.RequestType
+	if len(policy.GetScriptRequestTypes()) > 0 {
+		scriptRequestTypes = make(map[string][]application.RequestType)
+		for _, rule := range policy.GetScriptRequestTypes() {
+			for _, requestType := range rule.GetRequestTypes() {
+				scriptRequestTypes[rule.GetScriptHash()] = append(
+					scriptRequestTypes[rule.GetScriptHash()], application.RequestType(requestType),
+				)
+			}
+		}
+	}
+
+	return application.Policy{
+		AllowedScriptHashes:  policy.GetAllowedScriptHashes(),
+		MaxPacketEntries:     int(policy.GetMaxPacketEntries()),
+		AllowedTemplates:     policy.GetAllowedTemplates(),
+		DeniedOpcodes:        policy.GetDeniedOpcodes(),
+		ScriptRequestTypes:   scriptRequestTypes,
+		MaxTxAmount:          policy.GetMaxTxAmount(),
+		MaxTxAssetAmount:     policy.GetMaxTxAssetAmount(),
+		Window:               time.Duration(policy.GetWindowSeconds()) * time.Second,
+		MaxWindowAmount:      policy.GetMaxWindowAmount(),
+		MaxWindowAssetAmount: policy.GetMaxWindowAssetAmount(),
+	}
+}
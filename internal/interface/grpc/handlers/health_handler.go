@@ -3,40 +3,102 @@ package handlers
 import (
 	"context"
 
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"google.golang.org/grpc/codes"
 	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
-type healthHandler struct{}
+// the empty service name reports the readiness of the whole introspector,
+// the name of a component reports only its own health
+var healthComponents = []string{
+	application.HealthComponentKeystore,
+	application.HealthComponentArkd,
+	application.HealthComponentFinalizations,
+}
+
+type healthHandler struct {
+	svc application.HealthService
+}
 
-func NewHealthHandler() grpchealth.HealthServer {
-	return &healthHandler{}
+func NewHealthHandler(svc application.HealthService) grpchealth.HealthServer {
+	return &healthHandler{svc}
 }
 
 func (h *healthHandler) Check(
-	_ context.Context,
-	_ *grpchealth.HealthCheckRequest,
+	ctx context.Context,
+	req *grpchealth.HealthCheckRequest,
 ) (*grpchealth.HealthCheckResponse, error) {
-	return &grpchealth.HealthCheckResponse{
-		Status: grpchealth.HealthCheckResponse_SERVING,
-	}, nil
+	health, err := h.svc.Check(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	servingStatus, ok := toServingStatus(*health, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.GetService())
+	}
+	return &grpchealth.HealthCheckResponse{Status: servingStatus}, nil
 }
 
 func (h *healthHandler) Watch(
-	_ *grpchealth.HealthCheckRequest,
-	_ grpchealth.Health_WatchServer,
+	req *grpchealth.HealthCheckRequest,
+	stream grpchealth.Health_WatchServer,
 ) error {
+	updates, err := h.svc.Watch(stream.Context())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// only the changes of the serving status are sent
+	last := grpchealth.HealthCheckResponse_UNKNOWN
+	for health := range updates {
+		servingStatus, ok := toServingStatus(health, req.GetService())
+		if !ok {
+			servingStatus = grpchealth.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if servingStatus == last {
+			continue
+		}
+		if err := stream.Send(&grpchealth.HealthCheckResponse{Status: servingStatus}); err != nil {
+			return err
+		}
+		last = servingStatus
+	}
 	return nil
 }
 
 func (h *healthHandler) List(
-	_ context.Context,
+	ctx context.Context,
 	_ *grpchealth.HealthListRequest,
 ) (*grpchealth.HealthListResponse, error) {
-	return &grpchealth.HealthListResponse{
-		Statuses: map[string]*grpchealth.HealthCheckResponse{
-			"introspector": {
-				Status: grpchealth.HealthCheckResponse_SERVING,
-			},
-		},
-	}, nil
+	health, err := h.svc.Check(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	statuses := make(map[string]*grpchealth.HealthCheckResponse)
+	for _, service := range append([]string{""}, healthComponents...) {
+		servingStatus, _ := toServingStatus(*health, service)
+		statuses[service] = &grpchealth.HealthCheckResponse{Status: servingStatus}
+	}
+	return &grpchealth.HealthListResponse{Statuses: statuses}, nil
+}
+
+func toServingStatus(
+	health application.Health, service string,
+) (grpchealth.HealthCheckResponse_ServingStatus, bool) {
+	healthy := health.Ready
+	if service != "" {
+		check, ok := health.Check(service)
+		if !ok {
+			return grpchealth.HealthCheckResponse_SERVICE_UNKNOWN, false
+		}
+		healthy = check.Healthy
+	}
+
+	if !healthy {
+		return grpchealth.HealthCheckResponse_NOT_SERVING, true
+	}
+	return grpchealth.HealthCheckResponse_SERVING, true
 }
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,10 +20,14 @@ import (
 type handler struct {
 	version string
 	svc     application.Service
+	// the counts are checked before parsing the txs of a request
+	limits application.RequestLimits
 }
 
-func New(version string, service application.Service) *handler {
-	return &handler{version: version, svc: service}
+func New(
+	version string, service application.Service, limits application.RequestLimits,
+) *handler {
+	return &handler{version: version, svc: service, limits: limits}
 }
 
 func (h *handler) GetInfo(
@@ -29,12 +35,31 @@ func (h *handler) GetInfo(
 ) (*introspectorv1.GetInfoResponse, error) {
 	info, err := h.svc.GetInfo(ctx)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err, "failed to get info")
+	}
+
+	deprecatedSigners := make([]*introspectorv1.DeprecatedSigner, 0, len(info.DeprecatedSigners))
+	for _, signer := range info.DeprecatedSigners {
+		deprecatedSigners = append(deprecatedSigners, &introspectorv1.DeprecatedSigner{
+			Pubkey:   signer.PublicKey,
+			SunsetAt: signer.SunsetAt,
+		})
+	}
+
+	arkServers := make([]*introspectorv1.ArkServer, 0, len(info.ArkServers))
+	for _, server := range info.ArkServers {
+		arkServers = append(arkServers, &introspectorv1.ArkServer{
+			Url:          server.URL,
+			SignerPubkey: server.SignerPublicKey,
+			UpdatedAt:    server.UpdatedAt,
+		})
 	}
 
 	return &introspectorv1.GetInfoResponse{
-		SignerPubkey: info.SignerPublicKey,
-		Version:      h.version,
+		SignerPubkey:      info.SignerPublicKey,
+		Version:           h.version,
+		DeprecatedSigners: deprecatedSigners,
+		ArkServers:        arkServers,
 	}, nil
 }
 
@@ -52,6 +77,12 @@ func (h *handler) SubmitTx(
 		return nil, status.Error(codes.InvalidArgument, "missing checkpoint txs")
 	}
 
+	if err := h.limits.Check(
+		"checkpoint txs", len(checkpoints), h.limits.MaxCheckpoints,
+	); err != nil {
+		return nil, toStatusError(err, "too many checkpoint txs")
+	}
+
 	arkPtx, err := psbt.NewFromRawBytes(strings.NewReader(arkTx), true)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid ark tx")
@@ -71,32 +102,76 @@ func (h *handler) SubmitTx(
 		Checkpoints: checkpointPsbt,
 	}
 
-	approvedTx, err := h.svc.SubmitTx(ctx, offchainTx)
-	if err != nil {
-		log.WithError(err).Error("failed to process transaction")
-		return nil, status.Error(codes.Internal, "failed to process transaction")
+	if req.GetAsync() {
+		txid, err := h.svc.SubmitTxAsync(ctx, offchainTx)
+		if err != nil {
+			return nil, toStatusError(err, "failed to process transaction")
+		}
+		return &introspectorv1.SubmitTxResponse{Txid: txid}, nil
 	}
 
-	encodedArkTx, err := approvedTx.ArkTx.B64Encode()
+	approvedTx, err := h.svc.SubmitTx(ctx, offchainTx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to encode ark tx")
+		return nil, toStatusError(err, "failed to process transaction")
 	}
 
-	encodedCheckpointTxs := make([]string, 0, len(approvedTx.Checkpoints))
-	for _, checkpoint := range approvedTx.Checkpoints {
-		encodedCheckpointTx, err := checkpoint.B64Encode()
-		if err != nil {
-			return nil, status.Error(codes.Internal, "failed to encode checkpoint tx")
-		}
-		encodedCheckpointTxs = append(encodedCheckpointTxs, encodedCheckpointTx)
+	encodedArkTx, encodedCheckpointTxs, err := encodeOffchainTx(approvedTx)
+	if err != nil {
+		return nil, err
 	}
 
 	return &introspectorv1.SubmitTxResponse{
 		SignedArkTx:         encodedArkTx,
 		SignedCheckpointTxs: encodedCheckpointTxs,
+		Txid:                approvedTx.ArkTx.UnsignedTx.TxID(),
 	}, nil
 }
 
+func (h *handler) GetTxStatus(
+	ctx context.Context, req *introspectorv1.GetTxStatusRequest,
+) (*introspectorv1.GetTxStatusResponse, error) {
+	txid := req.GetTxid()
+	if len(txid) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing txid")
+	}
+
+	txStatus, err := h.svc.GetTxStatus(ctx, txid)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get tx status")
+	}
+
+	protoStatus, err := toProtoTxStatus(*txStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &introspectorv1.GetTxStatusResponse{Status: protoStatus}, nil
+}
+
+func (h *handler) WatchTx(
+	req *introspectorv1.WatchTxRequest, stream introspectorv1.IntrospectorService_WatchTxServer,
+) error {
+	txid := req.GetTxid()
+	if len(txid) <= 0 {
+		return status.Error(codes.InvalidArgument, "missing txid")
+	}
+
+	updates, err := h.svc.WatchTx(stream.Context(), txid)
+	if err != nil {
+		return toStatusError(err, "failed to watch tx")
+	}
+
+	for txStatus := range updates {
+		protoStatus, err := toProtoTxStatus(txStatus)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&introspectorv1.WatchTxResponse{Status: protoStatus}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (h *handler) SubmitIntent(
 	ctx context.Context, req *introspectorv1.SubmitIntentRequest,
 ) (*introspectorv1.SubmitIntentResponse, error) {
@@ -111,19 +186,49 @@ func (h *handler) SubmitIntent(
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid intent: %v", err))
 	}
 
-	signedIntentProof, err := h.svc.SubmitIntent(ctx, *intent)
+	signedIntent, err := h.svc.SubmitIntent(ctx, *intent, req.GetStrict())
 	if err != nil {
-		log.WithError(err).Error("failed to process intent")
-		return nil, status.Error(codes.Internal, "failed to process intent")
+		return nil, toStatusError(err, "failed to process intent")
 	}
 
-	encodedProof, err := signedIntentProof.B64Encode()
+	encodedProof, err := signedIntent.Proof.B64Encode()
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to encode proof")
 	}
 
 	return &introspectorv1.SubmitIntentResponse{
 		SignedProof: encodedProof,
+		Entries:     toProtoEntries(signedIntent.Entries),
+	}, nil
+}
+
+func (h *handler) SubmitIntentDeletion(
+	ctx context.Context, req *introspectorv1.SubmitIntentDeletionRequest,
+) (*introspectorv1.SubmitIntentDeletionResponse, error) {
+	unsignedIntent := req.GetIntent()
+
+	if unsignedIntent == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing intent")
+	}
+
+	deletion, err := parseIntentDeletion(unsignedIntent)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid intent: %v", err))
+	}
+
+	signedIntent, err := h.svc.SubmitIntentDeletion(ctx, *deletion)
+	if err != nil {
+		return nil, toStatusError(err, "failed to process intent deletion")
+	}
+
+	encodedProof, err := signedIntent.Proof.B64Encode()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to encode proof")
+	}
+
+	return &introspectorv1.SubmitIntentDeletionResponse{
+		SignedProof: encodedProof,
+		Entries:     toProtoEntries(signedIntent.Entries),
 	}, nil
 }
 
@@ -133,12 +238,26 @@ func (h *handler) SubmitFinalization(
 	signedIntent := req.GetSignedIntent()
 	forfeitTxs := req.GetForfeits()
 	connectorTree := req.GetConnectorTree()
+	vtxoTree := req.GetVtxoTree()
 	commitmentTx := req.GetCommitmentTx()
 
 	if signedIntent == nil {
 		return nil, status.Error(codes.InvalidArgument, "missing signed intent")
 	}
 
+	for _, limit := range []struct {
+		name       string
+		count, max int
+	}{
+		{"forfeit txs", len(forfeitTxs), h.limits.MaxForfeits},
+		{"connector tree nodes", len(connectorTree), h.limits.MaxTreeNodes},
+		{"vtxo tree nodes", len(vtxoTree), h.limits.MaxTreeNodes},
+	} {
+		if err := h.limits.Check(limit.name, limit.count, limit.max); err != nil {
+			return nil, toStatusError(err, "finalization too large")
+		}
+	}
+
 	intent, err := parseIntent(signedIntent)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid signed intent: %v", err))
@@ -178,17 +297,21 @@ func (h *handler) SubmitFinalization(
 			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid connector tree: %v", err))
 		}
 
-		if err := verifyTreeRelatedToCommitment(commitmentPtx, connectorTxTree); err != nil {
-			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid connector tree: %v", err))
+		batchFinalization.ConnectorTree = connectorTxTree
+	}
+
+	if len(vtxoTree) > 0 {
+		vtxoTxTree, err := parseTxTree(vtxoTree)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid vtxo tree: %v", err))
 		}
 
-		batchFinalization.ConnectorTree = connectorTxTree
+		batchFinalization.VtxoTree = vtxoTxTree
 	}
 
 	signedBatchFinalization, err := h.svc.SubmitFinalization(ctx, batchFinalization)
 	if err != nil {
-		log.WithError(err).Error("failed to process finalization")
-		return nil, status.Error(codes.Internal, "failed to process finalization")
+		return nil, toStatusError(err, "failed to process finalization")
 	}
 
 	encodedForfeits := make([]string, 0, len(signedBatchFinalization.Forfeits))
@@ -230,8 +353,7 @@ func (h *handler) SubmitOnchainTx(
 
 	signed, err := h.svc.SubmitOnchainTx(ctx, application.OnchainTx{Tx: ptx})
 	if err != nil {
-		log.WithError(err).Error("failed to process onchain tx")
-		return nil, status.Error(codes.Internal, "failed to process onchain tx")
+		return nil, toStatusError(err, "failed to process onchain tx")
 	}
 
 	encoded, err := signed.B64Encode()
@@ -242,24 +364,261 @@ func (h *handler) SubmitOnchainTx(
 	return &introspectorv1.SubmitOnchainTxResponse{SignedTx: encoded}, nil
 }
 
-func verifyTreeRelatedToCommitment(commitmentPtx *psbt.Packet, txTree *tree.TxTree) error {
-	if len(txTree.Root.Inputs) != len(commitmentPtx.UnsignedTx.TxIn) {
-		return fmt.Errorf("invalid number of inputs")
+func (h *handler) RegisterDelegate(
+	ctx context.Context, req *introspectorv1.RegisterDelegateRequest,
+) (*introspectorv1.RegisterDelegateResponse, error) {
+	template := req.GetIntentTemplate()
+	if template == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing intent template")
+	}
+
+	parsed, err := parseIntent(template)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid intent template: %v", err))
 	}
-	if len(txTree.Root.UnsignedTx.TxIn) != 1 {
-		return fmt.Errorf("invalid tx tree root")
+
+	delegate, err := h.svc.RegisterDelegate(ctx, application.DelegateRegistration{
+		Proof:        parsed.Proof,
+		Message:      parsed.Message,
+		VtxoExpireAt: req.GetVtxoExpireAt(),
+	})
+	if err != nil {
+		return nil, toStatusError(err, "failed to register delegate")
 	}
 
-	rootInput := txTree.Root.UnsignedTx.TxIn[0]
-	if rootInput.PreviousOutPoint.Hash.String() != commitmentPtx.UnsignedTx.TxID() {
-		return fmt.Errorf("root is not commitment tx")
+	return &introspectorv1.RegisterDelegateResponse{Delegate: toProtoDelegate(*delegate)}, nil
+}
+
+func (h *handler) GetDelegate(
+	ctx context.Context, req *introspectorv1.GetDelegateRequest,
+) (*introspectorv1.GetDelegateResponse, error) {
+	outpoint := req.GetOutpoint()
+	if len(outpoint) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing outpoint")
 	}
 
-	if int(rootInput.PreviousOutPoint.Index) >= len(commitmentPtx.UnsignedTx.TxOut) {
-		return fmt.Errorf("root input index out of range")
+	delegate, err := h.svc.GetDelegate(ctx, outpoint)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get delegate")
 	}
 
-	return nil
+	return &introspectorv1.GetDelegateResponse{Delegate: toProtoDelegate(*delegate)}, nil
+}
+
+func (h *handler) CreateTreeCosigner(
+	ctx context.Context, _ *introspectorv1.CreateTreeCosignerRequest,
+) (*introspectorv1.CreateTreeCosignerResponse, error) {
+	cosigner, err := h.svc.CreateTreeCosigner(ctx)
+	if err != nil {
+		return nil, toStatusError(err, "failed to create tree cosigner")
+	}
+
+	return &introspectorv1.CreateTreeCosignerResponse{
+		CosignerPubkey: cosigner.PublicKey,
+		ExpireAt:       cosigner.ExpireAt,
+	}, nil
+}
+
+func (h *handler) RegisterScript(
+	ctx context.Context, req *introspectorv1.RegisterScriptRequest,
+) (*introspectorv1.RegisterScriptResponse, error) {
+	script, err := hex.DecodeString(req.GetScript())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid script: must be hex")
+	}
+
+	abi := make([]application.ScriptArgument, 0, len(req.GetAbi()))
+	for _, arg := range req.GetAbi() {
+		abi = append(abi, application.ScriptArgument{Name: arg.GetName(), Type: arg.GetType()})
+	}
+
+	registered, err := h.svc.RegisterScript(ctx, application.ScriptRegistration{
+		Script:  script,
+		Name:    req.GetName(),
+		Version: req.GetVersion(),
+		Abi:     abi,
+	})
+	if err != nil {
+		return nil, toStatusError(err, "failed to register script")
+	}
+
+	return &introspectorv1.RegisterScriptResponse{Script: toProtoScript(*registered)}, nil
+}
+
+func (h *handler) GetScript(
+	ctx context.Context, req *introspectorv1.GetScriptRequest,
+) (*introspectorv1.GetScriptResponse, error) {
+	scriptHash := req.GetScriptHash()
+	if len(scriptHash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing script hash")
+	}
+
+	registered, err := h.svc.GetScript(ctx, scriptHash)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get script")
+	}
+
+	return &introspectorv1.GetScriptResponse{Script: toProtoScript(*registered)}, nil
+}
+
+func toProtoTxStatus(txStatus application.TxStatus) (*introspectorv1.TxStatus, error) {
+	protoStatus := &introspectorv1.TxStatus{
+		Txid:      txStatus.Txid,
+		Stage:     toProtoTxStage(txStatus.Stage),
+		Done:      txStatus.Done,
+		Error:     txStatus.Error,
+		UpdatedAt: txStatus.UpdatedAt,
+	}
+	if txStatus.Tx != nil {
+		encodedArkTx, encodedCheckpointTxs, err := encodeOffchainTx(txStatus.Tx)
+		if err != nil {
+			return nil, err
+		}
+		protoStatus.SignedArkTx = encodedArkTx
+		protoStatus.SignedCheckpointTxs = encodedCheckpointTxs
+	}
+	return protoStatus, nil
+}
+
+func toProtoTxStage(stage application.TxStage) introspectorv1.TxStage {
+	switch stage {
+	case application.TxStageQueued:
+		return introspectorv1.TxStage_TX_STAGE_QUEUED
+	case application.TxStageExecuted:
+		return introspectorv1.TxStage_TX_STAGE_EXECUTED
+	case application.TxStageSigned:
+		return introspectorv1.TxStage_TX_STAGE_SIGNED
+	case application.TxStageSubmitted:
+		return introspectorv1.TxStage_TX_STAGE_SUBMITTED
+	case application.TxStageFinalized:
+		return introspectorv1.TxStage_TX_STAGE_FINALIZED
+	case application.TxStageFailed:
+		return introspectorv1.TxStage_TX_STAGE_FAILED
+	default:
+		return introspectorv1.TxStage_TX_STAGE_UNSPECIFIED
+	}
+}
+
+func toProtoEntries(entries []application.EntryOutcome) []*introspectorv1.IntentEntry {
+	protoEntries := make([]*introspectorv1.IntentEntry, 0, len(entries))
+	for _, entry := range entries {
+		protoEntries = append(protoEntries, &introspectorv1.IntentEntry{
+			Vin:    uint32(entry.InputIndex),
+			Status: toProtoEntryStatus(entry.Status),
+			Reason: entry.Reason,
+		})
+	}
+	return protoEntries
+}
+
+func toProtoEntryStatus(status application.EntryStatus) introspectorv1.IntentEntryStatus {
+	switch status {
+	case application.EntryStatusSigned:
+		return introspectorv1.IntentEntryStatus_INTENT_ENTRY_STATUS_SIGNED
+	case application.EntryStatusSkipped:
+		return introspectorv1.IntentEntryStatus_INTENT_ENTRY_STATUS_SKIPPED
+	case application.EntryStatusRejected:
+		return introspectorv1.IntentEntryStatus_INTENT_ENTRY_STATUS_REJECTED
+	default:
+		return introspectorv1.IntentEntryStatus_INTENT_ENTRY_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoDelegate(delegate application.Delegate) *introspectorv1.Delegate {
+	return &introspectorv1.Delegate{
+		Outpoint:       delegate.Outpoint,
+		Amount:         delegate.Amount,
+		Status:         toProtoDelegateStatus(delegate.Status),
+		RefreshAt:      delegate.RefreshAt,
+		Attempts:       int32(delegate.Attempts),
+		LastError:      delegate.LastError,
+		CommitmentTxid: delegate.CommitmentTxid,
+		CreatedAt:      delegate.CreatedAt,
+		UpdatedAt:      delegate.UpdatedAt,
+	}
+}
+
+func toProtoDelegateStatus(status application.DelegateStatus) introspectorv1.DelegateStatus {
+	switch status {
+	case application.DelegateStatusPending:
+		return introspectorv1.DelegateStatus_DELEGATE_STATUS_PENDING
+	case application.DelegateStatusRunning:
+		return introspectorv1.DelegateStatus_DELEGATE_STATUS_RUNNING
+	case application.DelegateStatusSettled:
+		return introspectorv1.DelegateStatus_DELEGATE_STATUS_SETTLED
+	case application.DelegateStatusFailed:
+		return introspectorv1.DelegateStatus_DELEGATE_STATUS_FAILED
+	default:
+		return introspectorv1.DelegateStatus_DELEGATE_STATUS_UNSPECIFIED
+	}
+}
+
+func encodeOffchainTx(tx *application.OffchainTx) (string, []string, error) {
+	encodedArkTx, err := tx.ArkTx.B64Encode()
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, "failed to encode ark tx")
+	}
+
+	encodedCheckpointTxs := make([]string, 0, len(tx.Checkpoints))
+	for _, checkpoint := range tx.Checkpoints {
+		encodedCheckpointTx, err := checkpoint.B64Encode()
+		if err != nil {
+			return "", nil, status.Error(codes.Internal, "failed to encode checkpoint tx")
+		}
+		encodedCheckpointTxs = append(encodedCheckpointTxs, encodedCheckpointTx)
+	}
+	return encodedArkTx, encodedCheckpointTxs, nil
+}
+
+// toStatusError maps the keystore state errors to codes.Unavailable so that
+// clients can tell a locked or paused signer apart from a rejected request,
+// any other error is logged and hidden behind codes.Internal.
+func toStatusError(err error, msg string) error {
+	if errors.Is(err, application.ErrKeystoreLocked) ||
+		errors.Is(err, application.ErrKeystoreNotInitialized) ||
+		errors.Is(err, application.ErrArkServerUnavailable) ||
+		errors.Is(err, application.ErrSigningPaused) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if errors.Is(err, application.ErrPolicyViolation) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, application.ErrLimitExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, application.ErrInvalidCheckpoint) ||
+		errors.Is(err, application.ErrInvalidConnectorTree) ||
+		errors.Is(err, application.ErrInvalidForfeit) ||
+		errors.Is(err, application.ErrInvalidVtxoTree) ||
+		errors.Is(err, application.ErrInvalidIntent) ||
+		errors.Is(err, application.ErrInvalidIntentDeletion) ||
+		errors.Is(err, application.ErrInvalidDelegate) ||
+		errors.Is(err, application.ErrIntentMismatch) ||
+		errors.Is(err, application.ErrUnknownArkServer) ||
+		errors.Is(err, application.ErrInvalidScript) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, application.ErrIntentNotSigned) ||
+		errors.Is(err, application.ErrDelegateSolverDisabled) ||
+		errors.Is(err, application.ErrTreeCosignerDisabled) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, application.ErrTxNotFound) ||
+		errors.Is(err, application.ErrDelegateNotFound) ||
+		errors.Is(err, application.ErrScriptNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	// the intent must be signed with SubmitIntent before being finalized
+	if errors.Is(err, application.ErrIntentNotFound) ||
+		errors.Is(err, application.ErrIntentExpired) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, application.ErrEquivocation) {
+		log.WithError(err).Warn(msg)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	log.WithError(err).Error(msg)
+	return status.Error(codes.Internal, msg)
 }
 
 func parseTxTree(fromProto []*introspectorv1.TxTreeNode) (*tree.TxTree, error) {
@@ -276,9 +635,6 @@ func parseTxTree(fromProto []*introspectorv1.TxTreeNode) (*tree.TxTree, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tx tree: %w", err)
 	}
-	if err := txTree.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid tx tree: %w", err)
-	}
 
 	return txTree, nil
 }
@@ -311,3 +667,47 @@ func parseIntent(fromProto *introspectorv1.Intent) (*application.Intent, error)
 		Message: registerMessage,
 	}, nil
 }
+
+func parseIntentDeletion(fromProto *introspectorv1.Intent) (*application.IntentDeletion, error) {
+	proof := fromProto.GetProof()
+	message := fromProto.GetMessage()
+
+	if len(proof) <= 0 {
+		return nil, fmt.Errorf("missing proof")
+	}
+
+	if len(message) <= 0 {
+		return nil, fmt.Errorf("missing message")
+	}
+
+	proofPsbt, err := psbt.NewFromRawBytes(strings.NewReader(proof), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+
+	var deleteMessage intent.DeleteMessage
+	if err := deleteMessage.Decode(message); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	return &application.IntentDeletion{
+		Proof:   intent.Proof{Packet: *proofPsbt},
+		Message: deleteMessage,
+	}, nil
+}
+
+func toProtoScript(script application.RegisteredScript) *introspectorv1.RegisteredScript {
+	abi := make([]*introspectorv1.ScriptArgument, 0, len(script.Abi))
+	for _, arg := range script.Abi {
+		abi = append(abi, &introspectorv1.ScriptArgument{Name: arg.Name, Type: arg.Type})
+	}
+	return &introspectorv1.RegisteredScript{
+		ScriptHash:   script.Hash,
+		TemplateHash: script.TemplateHash,
+		Script:       hex.EncodeToString(script.Script),
+		Name:         script.Name,
+		Version:      script.Version,
+		Abi:          abi,
+		CreatedAt:    script.CreatedAt,
+	}
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	"github.com/btcsuite/btcd/btcec/v2"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type walletHandler struct {
+	svc application.WalletService
+}
+
+func NewWalletHandler(service application.WalletService) introspectorv1.WalletServiceServer {
+	return &walletHandler{svc: service}
+}
+
+func (h *walletHandler) GetStatus(
+	ctx context.Context, _ *introspectorv1.GetStatusRequest,
+) (*introspectorv1.GetStatusResponse, error) {
+	walletStatus, err := h.svc.GetStatus(ctx)
+	if err != nil {
+		return nil, toWalletStatusError(err, "failed to get wallet status")
+	}
+
+	return &introspectorv1.GetStatusResponse{
+		Initialized:  walletStatus.Initialized,
+		Unlocked:     walletStatus.Unlocked,
+		SignerPubkey: walletStatus.SignerPublicKey,
+	}, nil
+}
+
+func (h *walletHandler) Create(
+	ctx context.Context, req *introspectorv1.CreateRequest,
+) (*introspectorv1.CreateResponse, error) {
+	password := req.GetPassword()
+	if len(password) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing password")
+	}
+
+	secretKey, err := parseSecretKey(req.GetPrivateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	signerPubkey, err := h.svc.Create(ctx, password, secretKey)
+	if err != nil {
+		return nil, toWalletStatusError(err, "failed to create wallet")
+	}
+
+	return &introspectorv1.CreateResponse{SignerPubkey: signerPubkey}, nil
+}
+
+func (h *walletHandler) Unlock(
+	ctx context.Context, req *introspectorv1.UnlockRequest,
+) (*introspectorv1.UnlockResponse, error) {
+	password := req.GetPassword()
+	if len(password) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing password")
+	}
+
+	if err := h.svc.Unlock(ctx, password); err != nil {
+		return nil, toWalletStatusError(err, "failed to unlock wallet")
+	}
+
+	log.Info("keystore unlocked")
+	return &introspectorv1.UnlockResponse{}, nil
+}
+
+func (h *walletHandler) Lock(
+	ctx context.Context, _ *introspectorv1.LockRequest,
+) (*introspectorv1.LockResponse, error) {
+	if err := h.svc.Lock(ctx); err != nil {
+		return nil, toWalletStatusError(err, "failed to lock wallet")
+	}
+
+	log.Info("keystore locked")
+	return &introspectorv1.LockResponse{}, nil
+}
+
+func (h *walletHandler) ChangePassword(
+	ctx context.Context, req *introspectorv1.ChangePasswordRequest,
+) (*introspectorv1.ChangePasswordResponse, error) {
+	currentPassword := req.GetCurrentPassword()
+	newPassword := req.GetNewPassword()
+	if len(currentPassword) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing current password")
+	}
+	if len(newPassword) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing new password")
+	}
+
+	if err := h.svc.ChangePassword(ctx, currentPassword, newPassword); err != nil {
+		return nil, toWalletStatusError(err, "failed to change password")
+	}
+
+	return &introspectorv1.ChangePasswordResponse{}, nil
+}
+
+func (h *walletHandler) ListSignerKeys(
+	ctx context.Context, _ *introspectorv1.ListSignerKeysRequest,
+) (*introspectorv1.ListSignerKeysResponse, error) {
+	signerKeys, err := h.svc.ListSignerKeys(ctx)
+	if err != nil {
+		return nil, toWalletStatusError(err, "failed to list signer keys")
+	}
+
+	keys := make([]*introspectorv1.SignerKey, 0, len(signerKeys))
+	for _, key := range signerKeys {
+		keys = append(keys, &introspectorv1.SignerKey{
+			Pubkey:    hex.EncodeToString(key.PublicKey.SerializeCompressed()),
+			CreatedAt: key.CreatedAt,
+			SunsetAt:  key.SunsetAt,
+			Current:   key.Current,
+		})
+	}
+
+	return &introspectorv1.ListSignerKeysResponse{Keys: keys}, nil
+}
+
+func (h *walletHandler) AddSignerKey(
+	ctx context.Context, req *introspectorv1.AddSignerKeyRequest,
+) (*introspectorv1.AddSignerKeyResponse, error) {
+	password := req.GetPassword()
+	if len(password) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing password")
+	}
+
+	secretKey, err := parseSecretKey(req.GetPrivateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	signerPubkey, err := h.svc.AddSignerKey(ctx, password, secretKey)
+	if err != nil {
+		return nil, toWalletStatusError(err, "failed to add signer key")
+	}
+
+	log.WithField("signer_pubkey", signerPubkey).Info("signer key rotated")
+	return &introspectorv1.AddSignerKeyResponse{SignerPubkey: signerPubkey}, nil
+}
+
+func (h *walletHandler) RetireSignerKey(
+	ctx context.Context, req *introspectorv1.RetireSignerKeyRequest,
+) (*introspectorv1.RetireSignerKeyResponse, error) {
+	buf, err := hex.DecodeString(req.GetPubkey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pubkey")
+	}
+	pubkey, err := btcec.ParsePubKey(buf)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pubkey")
+	}
+	if req.GetSunsetAt() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid sunset date")
+	}
+
+	if err := h.svc.RetireSignerKey(ctx, pubkey, req.GetSunsetAt()); err != nil {
+		return nil, toWalletStatusError(err, "failed to retire signer key")
+	}
+
+	log.WithField("signer_pubkey", req.GetPubkey()).Info("signer key retired")
+	return &introspectorv1.RetireSignerKeyResponse{}, nil
+}
+
+// parseSecretKey decodes the optional hex-encoded private key to import
+func parseSecretKey(privateKey string) (*btcec.PrivateKey, error) {
+	if len(privateKey) <= 0 {
+		return nil, nil
+	}
+	buf, err := hex.DecodeString(privateKey)
+	if err != nil || len(buf) != 32 {
+		return nil, status.Error(codes.InvalidArgument, "invalid private key")
+	}
+	secretKey, _ := btcec.PrivKeyFromBytes(buf)
+	return secretKey, nil
+}
+
+func toWalletStatusError(err error, msg string) error {
+	switch {
+	case errors.Is(err, application.ErrInvalidPassword):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, application.ErrKeystoreNotInitialized):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, application.ErrKeystoreAlreadyInitialized),
+		errors.Is(err, application.ErrSignerKeyAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, application.ErrKeystoreLocked),
+		errors.Is(err, application.ErrCurrentSignerKey):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, application.ErrSignerKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	log.WithError(err).Error(msg)
+	return status.Error(codes.Internal, msg)
+}
@@ -0,0 +1,59 @@
+package grpcservice
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ArkLabsHQ/introspector/internal/application"
+	log "github.com/sirupsen/logrus"
+)
+
+type healthCheckJSON struct {
+	Component string `json:"component"`
+	Healthy   bool   `json:"healthy"`
+	Detail    string `json:"detail"`
+}
+
+type healthJSON struct {
+	Ready  bool              `json:"ready"`
+	Checks []healthCheckJSON `json:"checks"`
+}
+
+// livenessHandler answers as long as the server is up, a failing dependency
+// is not solved by a restart
+func livenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "SERVING"})
+	})
+}
+
+// readinessHandler answers 503 until all the dependencies are healthy, with
+// the detail of each check
+func readinessHandler(svc application.HealthService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, err := svc.Check(r.Context())
+		if err != nil {
+			log.WithError(err).Warn("failed to check health")
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+			return
+		}
+
+		body := healthJSON{Ready: health.Ready, Checks: make([]healthCheckJSON, 0, len(health.Checks))}
+		for _, check := range health.Checks {
+			body.Checks = append(body.Checks, healthCheckJSON(check))
+		}
+
+		code := http.StatusOK
+		if !health.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, body)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	// nolint:errcheck
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,68 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/ArkLabsHQ/introspector/internal/interface/grpc/permissions"
+	"github.com/arkade-os/arkd/pkg/macaroons"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+func unaryMacaroonAuthHandler(
+	svc *macaroons.Service, restrictSigning bool,
+) grpc.UnaryServerInterceptor {
+	whitelist := permissions.Whitelist(restrictSigning)
+	allPermissions := permissions.AllPermissionsByMethod()
+
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := checkMacaroon(ctx, info.FullMethod, svc, whitelist, allPermissions); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamMacaroonAuthHandler(
+	svc *macaroons.Service, restrictSigning bool,
+) grpc.StreamServerInterceptor {
+	whitelist := permissions.Whitelist(restrictSigning)
+	allPermissions := permissions.AllPermissionsByMethod()
+
+	return func(
+		srv any, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		if err := checkMacaroon(
+			stream.Context(), info.FullMethod, svc, whitelist, allPermissions,
+		); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func checkMacaroon(
+	ctx context.Context, fullMethod string, svc *macaroons.Service,
+	whitelist map[string]struct{}, allPermissions map[string][]bakery.Op,
+) error {
+	if svc == nil {
+		return nil
+	}
+	if _, ok := whitelist[fullMethod]; ok {
+		return nil
+	}
+
+	uriPermissions, ok := allPermissions[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s: unknown permissions", fullMethod)
+	}
+	if err := svc.ValidateMacaroon(ctx, uriPermissions, fullMethod); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
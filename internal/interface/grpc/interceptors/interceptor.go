@@ -6,16 +6,29 @@ import (
 	"google.golang.org/grpc"
 )
 
-// UnaryInterceptor returns the unary interceptor
-func UnaryInterceptor(svc *macaroons.Service) grpc.ServerOption {
+// UnaryInterceptor returns the unary interceptor, the macaroons are not
+// checked if svc is nil and the requests are not rate limited if limiter is nil
+func UnaryInterceptor(
+	svc *macaroons.Service, restrictSigning bool, limiter *RateLimiter, metrics RPCMetrics,
+) grpc.ServerOption {
 	return grpc.UnaryInterceptor(middleware.ChainUnaryServer(
+		unaryMetrics(metrics),
 		unaryLogger,
+		unaryMacaroonAuthHandler(svc, restrictSigning),
+		unaryRateLimiter(limiter, svc, restrictSigning),
 	))
 }
 
-// StreamInterceptor returns the stream interceptor with a logrus log
-func StreamInterceptor(svc *macaroons.Service) grpc.ServerOption {
+// StreamInterceptor returns the stream interceptor with a logrus log, the
+// macaroons are not checked if svc is nil and the requests are not rate
+// limited if limiter is nil
+func StreamInterceptor(
+	svc *macaroons.Service, restrictSigning bool, limiter *RateLimiter, metrics RPCMetrics,
+) grpc.ServerOption {
 	return grpc.StreamInterceptor(middleware.ChainStreamServer(
+		streamMetrics(metrics),
 		streamLogger,
+		streamMacaroonAuthHandler(svc, restrictSigning),
+		streamRateLimiter(limiter, svc, restrictSigning),
 	))
 }
@@ -0,0 +1,38 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RPCMetrics records the outcome of the rpc requests
+type RPCMetrics interface {
+	ObserveRPC(method string, code codes.Code, duration time.Duration)
+}
+
+func unaryMetrics(metrics RPCMetrics) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.ObserveRPC(info.FullMethod, status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
+
+func streamMetrics(metrics RPCMetrics) grpc.StreamServerInterceptor {
+	return func(
+		srv any, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		metrics.ObserveRPC(info.FullMethod, status.Code(err), time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,175 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ArkLabsHQ/introspector/internal/interface/grpc/permissions"
+	"github.com/arkade-os/arkd/pkg/macaroons"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+)
+
+// idle buckets are dropped after this delay, they would be full again anyway
+const rateLimiterIdleTimeout = 10 * time.Minute
+
+// RateLimiter is a token bucket per client, the clients are identified by
+// their macaroon on the authenticated rpcs, by their ip otherwise
+type RateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter returns nil, ie. no rate limiting, if requestsPerSecond is 0
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &RateLimiter{
+		limit:     rate.Limit(requestsPerSecond),
+		burst:     burst,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *RateLimiter) allow(key string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastSweep) > rateLimiterIdleTimeout {
+		for k, b := range r.buckets {
+			if now.Sub(b.lastSeen) > rateLimiterIdleTimeout {
+				delete(r.buckets, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(r.limit, r.burst)}
+		r.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter.AllowN(now, 1)
+}
+
+// unaryRateLimiter runs after the macaroon check so that the macaroon of an
+// authenticated rpc is a valid identity
+func unaryRateLimiter(
+	limiter *RateLimiter, svc *macaroons.Service, restrictSigning bool,
+) grpc.UnaryServerInterceptor {
+	authenticated := svc != nil
+	whitelist := permissions.Whitelist(restrictSigning)
+
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := checkRateLimit(ctx, info.FullMethod, limiter, authenticated, whitelist); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamRateLimiter(
+	limiter *RateLimiter, svc *macaroons.Service, restrictSigning bool,
+) grpc.StreamServerInterceptor {
+	authenticated := svc != nil
+	whitelist := permissions.Whitelist(restrictSigning)
+
+	return func(
+		srv any, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		if err := checkRateLimit(
+			stream.Context(), info.FullMethod, limiter, authenticated, whitelist,
+		); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func checkRateLimit(
+	ctx context.Context, fullMethod string, limiter *RateLimiter,
+	authenticated bool, whitelist map[string]struct{},
+) error {
+	if limiter == nil {
+		return nil
+	}
+
+	key := clientIP(ctx)
+	if _, ok := whitelist[fullMethod]; authenticated && !ok {
+		if id := macaroonID(ctx); id != "" {
+			key = id
+		}
+	}
+	if !limiter.allow(key) {
+		return status.Errorf(codes.ResourceExhausted, "%s: rate limit exceeded", fullMethod)
+	}
+	return nil
+}
+
+// clientIP returns the ip of the peer, or the one forwarded by the rest
+// gateway if the peer is the gateway dialing the loopback address
+func clientIP(ctx context.Context) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if parsed := net.ParseIP(ip); parsed == nil || !parsed.IsLoopback() {
+		return "ip:" + ip
+	}
+
+	// the gateway appends the remote address of the http request to the
+	// x-forwarded-for values sent by the client
+	md, _ := metadata.FromIncomingContext(ctx)
+	if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+		hops := strings.Split(forwarded[len(forwarded)-1], ",")
+		ip = strings.TrimSpace(hops[len(hops)-1])
+	}
+	return "ip:" + ip
+}
+
+// macaroonID returns the id of the macaroon of the request, the caveats being
+// left out since anyone can add some to a macaroon
+func macaroonID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("macaroon")
+	if len(values) != 1 {
+		return ""
+	}
+	raw, err := hex.DecodeString(values[0])
+	if err != nil {
+		return ""
+	}
+	var mac macaroon.Macaroon
+	if err := mac.UnmarshalBinary(raw); err != nil {
+		return ""
+	}
+	return "macaroon:" + hex.EncodeToString(mac.Id())
+}
@@ -0,0 +1,62 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/macaroon.v2"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require.Nil(t, NewRateLimiter(0, 10))
+
+	limiter := NewRateLimiter(0.001, 2)
+	require.True(t, limiter.allow("a"))
+	require.True(t, limiter.allow("a"))
+	require.False(t, limiter.allow("a"))
+	// every client has its own bucket
+	require.True(t, limiter.allow("b"))
+}
+
+func TestClientIdentity(t *testing.T) {
+	withPeer := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+		})
+	}
+
+	require.Equal(t, "ip:10.0.0.1", clientIP(withPeer("10.0.0.1")))
+
+	// x-forwarded-for is only trusted from the gateway
+	ctx := metadata.NewIncomingContext(
+		withPeer("10.0.0.1"), metadata.Pairs("x-forwarded-for", "1.1.1.1"),
+	)
+	require.Equal(t, "ip:10.0.0.1", clientIP(ctx))
+
+	ctx = metadata.NewIncomingContext(
+		withPeer("127.0.0.1"), metadata.Pairs("x-forwarded-for", "1.1.1.1, 2.2.2.2"),
+	)
+	require.Equal(t, "ip:2.2.2.2", clientIP(ctx))
+
+	mac, err := macaroon.New([]byte("root key"), []byte("id"), "introspector", macaroon.LatestVersion)
+	require.NoError(t, err)
+	attenuated := mac.Clone()
+	require.NoError(t, attenuated.AddFirstPartyCaveat([]byte("time-before 2100-01-01")))
+
+	for _, m := range []*macaroon.Macaroon{mac, attenuated} {
+		raw, err := m.MarshalBinary()
+		require.NoError(t, err)
+		ctx := metadata.NewIncomingContext(
+			context.Background(), metadata.Pairs("macaroon", hex.EncodeToString(raw)),
+		)
+		require.Equal(t, "macaroon:"+hex.EncodeToString([]byte("id")), macaroonID(ctx))
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("macaroon", "zz"))
+	require.Empty(t, macaroonID(ctx))
+}
@@ -0,0 +1,98 @@
+package grpcservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ArkLabsHQ/introspector/internal/interface/grpc/permissions"
+	"github.com/arkade-os/arkd/pkg/kvdb"
+	"github.com/arkade-os/arkd/pkg/macaroons"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	macaroonsLocation = "introspector"
+	macaroonsDbFile   = "macaroons.db"
+	macaroonsFolder   = "macaroons"
+
+	adminMacaroonFile    = "admin.macaroon"
+	signerMacaroonFile   = "signer.macaroon"
+	readOnlyMacaroonFile = "readonly.macaroon"
+)
+
+// the root key is protected by the permissions of the datadir, like the
+// macaroon files baked with it, so it's encrypted with a constant password
+var macaroonsPassword = []byte("introspector")
+
+var macaroonFiles = map[string][]bakery.Op{
+	adminMacaroonFile:    permissions.AdminPermissions(),
+	signerMacaroonFile:   permissions.SignerPermissions(),
+	readOnlyMacaroonFile: permissions.ReadOnlyPermissions(),
+}
+
+// setupMacaroons opens the root key store in the datadir and bakes the
+// macaroons whose file is missing
+func setupMacaroons(
+	ctx context.Context, datadir string,
+) (*macaroons.Service, kvdb.Backend, error) {
+	macaroonDir := filepath.Join(datadir, macaroonsFolder)
+	if err := os.MkdirAll(macaroonDir, 0700); err != nil {
+		return nil, nil, fmt.Errorf("failed to create macaroons dir: %w", err)
+	}
+
+	db, err := kvdb.Create(
+		kvdb.BoltBackendName, filepath.Join(datadir, macaroonsDbFile), true,
+		kvdb.DefaultDBTimeout,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open macaroons db: %w", err)
+	}
+
+	svc, err := newMacaroonService(ctx, db, macaroonDir)
+	if err != nil {
+		// nolint
+		db.Close()
+		return nil, nil, err
+	}
+	return svc, db, nil
+}
+
+func newMacaroonService(
+	ctx context.Context, db kvdb.Backend, macaroonDir string,
+) (*macaroons.Service, error) {
+	rootKeyStore, err := macaroons.NewRootKeyStorage(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open macaroons root key store: %w", err)
+	}
+	svc, err := macaroons.NewService(
+		rootKeyStore, macaroonsLocation, false, macaroons.IPLockChecker,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create macaroons service: %w", err)
+	}
+	pwd := append([]byte{}, macaroonsPassword...)
+	if err := svc.CreateUnlock(&pwd); err != nil {
+		return nil, fmt.Errorf("failed to unlock macaroons root key store: %w", err)
+	}
+
+	for file, ops := range macaroonFiles {
+		path := filepath.Join(macaroonDir, file)
+		if pathExists(path) {
+			continue
+		}
+
+		// the role scopes the root key id, baking a macaroon revokes the
+		// previous one with the same role
+		role := file[:len(file)-len(filepath.Ext(file))]
+		mac, err := svc.BakeMacaroon(ctx, ops, role)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bake %s: %w", file, err)
+		}
+		if err := os.WriteFile(path, mac, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return svc, nil
+}
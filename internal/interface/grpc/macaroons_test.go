@@ -0,0 +1,58 @@
+package grpcservice
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
+	"github.com/ArkLabsHQ/introspector/internal/interface/grpc/permissions"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestSetupMacaroons(t *testing.T) {
+	datadir := t.TempDir()
+
+	svc, db, err := setupMacaroons(t.Context(), datadir)
+	require.NoError(t, err)
+
+	read := func(file string) []byte {
+		mac, err := os.ReadFile(filepath.Join(datadir, macaroonsFolder, file))
+		require.NoError(t, err)
+		return mac
+	}
+	admin := read(adminMacaroonFile)
+
+	allPermissions := permissions.AllPermissionsByMethod()
+	validate := func(mac []byte, method string) error {
+		ctx := metadata.NewIncomingContext(
+			t.Context(), metadata.Pairs("macaroon", hex.EncodeToString(mac)),
+		)
+		return svc.ValidateMacaroon(ctx, allPermissions[method], method)
+	}
+
+	unlock := introspectorv1.WalletService_Unlock_FullMethodName
+	listFinalizations := introspectorv1.AdminService_ListFinalizations_FullMethodName
+	submitTx := introspectorv1.IntrospectorService_SubmitTx_FullMethodName
+
+	require.NoError(t, validate(admin, unlock))
+	require.NoError(t, validate(admin, submitTx))
+	require.NoError(t, validate(read(signerMacaroonFile), submitTx))
+	require.Error(t, validate(read(signerMacaroonFile), listFinalizations))
+	require.NoError(t, validate(read(readOnlyMacaroonFile), listFinalizations))
+	require.Error(t, validate(read(readOnlyMacaroonFile), unlock))
+
+	require.NoError(t, svc.Close())
+	require.NoError(t, db.Close())
+
+	// the existing macaroons are kept and still valid after a restart
+	svc, db, err = setupMacaroons(t.Context(), datadir)
+	require.NoError(t, err)
+	defer db.Close()
+	defer svc.Close()
+
+	require.Equal(t, admin, read(adminMacaroonFile))
+	require.NoError(t, validate(admin, unlock))
+}
@@ -0,0 +1,104 @@
+package permissions
+
+import (
+	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
+	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	EntitySigner = "signer"
+	EntityWallet = "wallet"
+	EntityAdmin  = "admin"
+)
+
+var (
+	signerWrite = bakery.Op{Entity: EntitySigner, Action: "write"}
+	walletRead  = bakery.Op{Entity: EntityWallet, Action: "read"}
+	walletWrite = bakery.Op{Entity: EntityWallet, Action: "write"}
+	adminRead   = bakery.Op{Entity: EntityAdmin, Action: "read"}
+	adminWrite  = bakery.Op{Entity: EntityAdmin, Action: "write"}
+)
+
+// AdminPermissions are the permissions of the admin macaroon, it grants
+// access to every rpc
+func AdminPermissions() []bakery.Op {
+	return []bakery.Op{signerWrite, walletRead, walletWrite, adminRead, adminWrite}
+}
+
+// SignerPermissions are the permissions of the signer macaroon handed to the
+// wallet backends allowed to submit txs and intents
+func SignerPermissions() []bakery.Op {
+	return []bakery.Op{signerWrite}
+}
+
+// ReadOnlyPermissions are the permissions of the readonly macaroon, it grants
+// access to the read rpcs of the wallet and admin services
+func ReadOnlyPermissions() []bakery.Op {
+	return []bakery.Op{walletRead, adminRead}
+}
+
+// Whitelist returns the rpcs served without macaroon, the signing rpcs are
+// public unless restrictSigning is set
+func Whitelist(restrictSigning bool) map[string]struct{} {
+	whitelist := map[string]struct{}{
+		introspectorv1.IntrospectorService_GetInfo_FullMethodName:     {},
+		introspectorv1.IntrospectorService_GetTxStatus_FullMethodName: {},
+		introspectorv1.IntrospectorService_WatchTx_FullMethodName:     {},
+		introspectorv1.IntrospectorService_GetDelegate_FullMethodName: {},
+		introspectorv1.IntrospectorService_GetScript_FullMethodName:   {},
+		grpchealth.Health_Check_FullMethodName:                        {},
+		grpchealth.Health_List_FullMethodName:                         {},
+		grpchealth.Health_Watch_FullMethodName:                        {},
+	}
+	if !restrictSigning {
+		for method := range signingMethods() {
+			whitelist[method] = struct{}{}
+		}
+	}
+	return whitelist
+}
+
+// AllPermissionsByMethod returns the permissions required by every rpc that
+// can be restricted
+func AllPermissionsByMethod() map[string][]bakery.Op {
+	permissions := map[string][]bakery.Op{
+		introspectorv1.WalletService_GetStatus_FullMethodName:        {walletRead},
+		introspectorv1.WalletService_Create_FullMethodName:           {walletWrite},
+		introspectorv1.WalletService_Unlock_FullMethodName:           {walletWrite},
+		introspectorv1.WalletService_Lock_FullMethodName:             {walletWrite},
+		introspectorv1.WalletService_ChangePassword_FullMethodName:   {walletWrite},
+		introspectorv1.WalletService_ListSignerKeys_FullMethodName:   {walletRead},
+		introspectorv1.WalletService_AddSignerKey_FullMethodName:     {walletWrite},
+		introspectorv1.WalletService_RetireSignerKey_FullMethodName:  {walletWrite},
+		introspectorv1.AdminService_GetServiceStatus_FullMethodName:  {adminRead},
+		introspectorv1.AdminService_GetKeyInfo_FullMethodName:        {adminRead},
+		introspectorv1.AdminService_PauseSigning_FullMethodName:      {adminWrite},
+		introspectorv1.AdminService_ResumeSigning_FullMethodName:     {adminWrite},
+		introspectorv1.AdminService_ListSignatures_FullMethodName:    {adminRead},
+		introspectorv1.AdminService_ListFinalizations_FullMethodName: {adminRead},
+		introspectorv1.AdminService_RetryFinalization_FullMethodName: {adminWrite},
+		introspectorv1.AdminService_ListIntents_FullMethodName:       {adminRead},
+		introspectorv1.AdminService_GetPolicy_FullMethodName:         {adminRead},
+		introspectorv1.AdminService_UpdatePolicy_FullMethodName:      {adminWrite},
+		introspectorv1.AdminService_ReloadPolicy_FullMethodName:      {adminWrite},
+		introspectorv1.AdminService_GetConfig_FullMethodName:         {adminRead},
+	}
+	for method, ops := range signingMethods() {
+		permissions[method] = ops
+	}
+	return permissions
+}
+
+func signingMethods() map[string][]bakery.Op {
+	return map[string][]bakery.Op{
+		introspectorv1.IntrospectorService_SubmitTx_FullMethodName:             {signerWrite},
+		introspectorv1.IntrospectorService_SubmitIntent_FullMethodName:         {signerWrite},
+		introspectorv1.IntrospectorService_SubmitIntentDeletion_FullMethodName: {signerWrite},
+		introspectorv1.IntrospectorService_SubmitFinalization_FullMethodName:   {signerWrite},
+		introspectorv1.IntrospectorService_SubmitOnchainTx_FullMethodName:      {signerWrite},
+		introspectorv1.IntrospectorService_RegisterDelegate_FullMethodName:     {signerWrite},
+		introspectorv1.IntrospectorService_CreateTreeCosigner_FullMethodName:   {signerWrite},
+		introspectorv1.IntrospectorService_RegisterScript_FullMethodName:       {signerWrite},
+	}
+}
@@ -0,0 +1,48 @@
+package permissions
+
+import (
+	"testing"
+
+	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// every served rpc must be either public or mapped to its permissions,
+// otherwise the auth interceptor denies it
+func TestAllMethodsCovered(t *testing.T) {
+	services := []grpc.ServiceDesc{
+		introspectorv1.IntrospectorService_ServiceDesc,
+		introspectorv1.WalletService_ServiceDesc,
+		introspectorv1.AdminService_ServiceDesc,
+		grpchealth.Health_ServiceDesc,
+	}
+	whitelist := Whitelist(true)
+	permissions := AllPermissionsByMethod()
+
+	for _, service := range services {
+		methods := make([]string, 0)
+		for _, method := range service.Methods {
+			methods = append(methods, method.MethodName)
+		}
+		for _, stream := range service.Streams {
+			methods = append(methods, stream.StreamName)
+		}
+
+		for _, method := range methods {
+			fullMethod := "/" + service.ServiceName + "/" + method
+			_, public := whitelist[fullMethod]
+			_, restricted := permissions[fullMethod]
+			require.True(t, public != restricted, "%s must be either public or restricted", fullMethod)
+		}
+	}
+}
+
+func TestWhitelist(t *testing.T) {
+	submitTx := introspectorv1.IntrospectorService_SubmitTx_FullMethodName
+
+	require.Contains(t, Whitelist(false), submitTx)
+	require.NotContains(t, Whitelist(true), submitTx)
+	require.NotContains(t, Whitelist(false), introspectorv1.WalletService_Unlock_FullMethodName)
+}
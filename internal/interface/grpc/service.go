@@ -6,12 +6,17 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
 	"github.com/ArkLabsHQ/introspector/internal/application"
 	"github.com/ArkLabsHQ/introspector/internal/config"
+	"github.com/ArkLabsHQ/introspector/internal/infrastructure/tracing"
 	interfaces "github.com/ArkLabsHQ/introspector/internal/interface"
 	"github.com/ArkLabsHQ/introspector/internal/interface/grpc/handlers"
+	"github.com/ArkLabsHQ/introspector/internal/interface/grpc/interceptors"
+	"github.com/arkade-os/arkd/pkg/kvdb"
+	"github.com/arkade-os/arkd/pkg/macaroons"
 	"github.com/meshapi/grpc-api-gateway/gateway"
 	log "github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -37,6 +42,16 @@ type service struct {
 	appSvc     application.Service
 	server     *http.Server
 	grpcServer *grpc.Server
+	// nil if the metrics endpoint is disabled
+	metricsServer *http.Server
+	// nil if the traces are not exported
+	shutdownTracing func(context.Context) error
+	// nil if the operator services are served by the public server
+	adminServer     *http.Server
+	adminGrpcServer *grpc.Server
+	// nil if the macaroons are disabled
+	macaroonSvc *macaroons.Service
+	macaroonDb  kvdb.Backend
 }
 
 func NewService(
@@ -48,6 +63,14 @@ func NewService(
 		NoTLS:           cfg.NoTLS,
 		TLSExtraIPs:     cfg.TLSExtraIPs,
 		TLSExtraDomains: cfg.TLSExtraDomains,
+		MetricsPort:     cfg.MetricsPort,
+		AdminPort:       cfg.AdminPort,
+		AdminHost:       cfg.AdminHost,
+		NoMacaroons:     cfg.NoMacaroons,
+		RestrictSigning: cfg.RestrictSigning,
+		MaxMsgSize:      cfg.MaxMsgSize,
+		RateLimit:       cfg.RateLimit,
+		RateBurst:       cfg.RateBurst,
 	}
 
 	if err := config.Validate(); err != nil {
@@ -75,6 +98,12 @@ func (s *service) Start() error {
 		return err
 	}
 	log.Infof("started listening at %s", s.config.address())
+	if s.adminServer != nil {
+		log.Infof("serving operator services at %s", s.config.adminAddress())
+	}
+	if s.metricsServer != nil {
+		log.Infof("serving metrics at %s/metrics", s.config.metricsAddress())
+	}
 
 	return nil
 }
@@ -90,6 +119,28 @@ func (s *service) Stop() {
 		// nolint
 		s.server.Shutdown(context.Background())
 	}
+	if s.adminGrpcServer != nil {
+		s.adminGrpcServer.Stop()
+	}
+	if s.adminServer != nil {
+		// nolint
+		s.adminServer.Shutdown(context.Background())
+	}
+	if s.metricsServer != nil {
+		// nolint
+		s.metricsServer.Shutdown(context.Background())
+	}
+	if s.macaroonSvc != nil {
+		// nolint
+		s.macaroonSvc.Close()
+		// nolint
+		s.macaroonDb.Close()
+	}
+	if s.shutdownTracing != nil {
+		if err := s.shutdownTracing(context.Background()); err != nil {
+			log.WithError(err).Warn("failed to flush traces")
+		}
+	}
 	log.Info("shutdown service")
 }
 
@@ -99,31 +150,78 @@ func (s *service) start() error {
 		return err
 	}
 
+	if s.cfg.TracingConfig.Endpoint != "" {
+		shutdown, err := tracing.Setup(context.Background(), s.cfg.TracingConfig, s.version)
+		if err != nil {
+			return err
+		}
+		s.shutdownTracing = shutdown
+		log.Infof("exporting traces to %s", s.cfg.TracingConfig.Endpoint)
+	}
+
 	if err := s.newServer(tlsConfig); err != nil {
 		return err
 	}
 
 	// Start main server
+	s.listen(s.server)
+	if s.adminServer != nil {
+		s.listen(s.adminServer)
+	}
+
+	if s.config.MetricsPort > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", s.cfg.Metrics().Handler())
+		s.metricsServer = &http.Server{
+			Addr:              s.config.metricsAddress(),
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		// nolint:all
+		go s.metricsServer.ListenAndServe()
+	}
+
+	return nil
+}
+
+func (s *service) listen(server *http.Server) {
 	if s.config.insecure() {
 		// nolint:all
-		go s.server.ListenAndServe()
+		go server.ListenAndServe()
 	} else {
 		// nolint:all
-		go s.server.ListenAndServeTLS("", "")
+		go server.ListenAndServeTLS("", "")
 	}
-
-	return nil
 }
 
 func (s *service) newServer(tlsConfig *tls.Config) error {
 	ctx := context.Background()
 
+	if !s.config.NoMacaroons {
+		macaroonSvc, macaroonDb, err := setupMacaroons(ctx, s.config.Datadir)
+		if err != nil {
+			return err
+		}
+		s.macaroonSvc = macaroonSvc
+		s.macaroonDb = macaroonDb
+		log.Debugf("macaroons available at path: %s", s.config.macaroonsDatadir())
+	}
+
 	otelHandler := otelgrpc.NewServerHandler(
 		otelgrpc.WithTracerProvider(otel.GetTracerProvider()),
 	)
 
+	// the public and the admin servers share the buckets of the clients
+	limiter := interceptors.NewRateLimiter(s.config.RateLimit, s.config.RateBurst)
 	grpcConfig := []grpc.ServerOption{
 		grpc.StatsHandler(otelHandler),
+		grpc.MaxRecvMsgSize(s.config.MaxMsgSize),
+		interceptors.UnaryInterceptor(
+			s.macaroonSvc, s.config.RestrictSigning, limiter, s.cfg.Metrics(),
+		),
+		interceptors.StreamInterceptor(
+			s.macaroonSvc, s.config.RestrictSigning, limiter, s.cfg.Metrics(),
+		),
 	}
 	creds := insecure.NewCredentials()
 	if !s.config.insecure() {
@@ -133,18 +231,84 @@ func (s *service) newServer(tlsConfig *tls.Config) error {
 
 	// Server grpc.
 	grpcServer := grpc.NewServer(grpcConfig...)
+	// the operator services share the public server unless they have their
+	// own listener
+	adminGrpcServer := grpcServer
+	if s.config.hasAdminListener() {
+		adminGrpcServer = grpc.NewServer(grpcConfig...)
+	}
 
 	appSvc, err := s.cfg.AppService(ctx)
 	if err != nil {
 		return err
 	}
 	s.appSvc = appSvc
-	appHandler := handlers.New(s.version, appSvc)
+	appHandler := handlers.New(s.version, appSvc, s.cfg.RequestLimits)
 	introspectorv1.RegisterIntrospectorServiceServer(grpcServer, appHandler)
 
-	healthHandler := handlers.NewHealthHandler()
+	walletSvc, err := s.cfg.WalletService()
+	if err != nil {
+		return err
+	}
+	walletHandler := handlers.NewWalletHandler(walletSvc)
+	introspectorv1.RegisterWalletServiceServer(adminGrpcServer, walletHandler)
+
+	adminSvc, err := s.cfg.AdminService(ctx)
+	if err != nil {
+		return err
+	}
+	adminHandler := handlers.NewAdminHandler(s.version, adminSvc, s.cfg.Dump())
+	introspectorv1.RegisterAdminServiceServer(adminGrpcServer, adminHandler)
+
+	healthSvc, err := s.cfg.HealthService(ctx)
+	if err != nil {
+		return err
+	}
+	healthHandler := handlers.NewHealthHandler(healthSvc)
 	grpchealth.RegisterHealthServer(grpcServer, healthHandler)
 
+	// Reverse proxy grpc-gateway.
+	gwmux, err := s.newGateway(s.config.gatewayAddress())
+	if err != nil {
+		return err
+	}
+	adminGwmux := gwmux
+	if s.config.hasAdminListener() {
+		if adminGwmux, err = s.newGateway(s.config.adminGatewayAddress()); err != nil {
+			return err
+		}
+	}
+
+	// Register services on main gateway
+	introspectorv1.RegisterIntrospectorServiceHandler(ctx, gwmux.mux, gwmux.conn)
+	introspectorv1.RegisterWalletServiceHandler(ctx, adminGwmux.mux, adminGwmux.conn)
+	introspectorv1.RegisterAdminServiceHandler(ctx, adminGwmux.mux, adminGwmux.conn)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", router(grpcServer, gwmux.mux, s.config.MaxMsgSize))
+	mux.Handle("GET /healthz", livenessHandler())
+	mux.Handle("GET /readyz", readinessHandler(healthSvc))
+
+	s.grpcServer = grpcServer
+	s.server = s.newHTTPServer(s.config.address(), mux, tlsConfig)
+	if s.config.hasAdminListener() {
+		s.adminGrpcServer = adminGrpcServer
+		s.adminServer = s.newHTTPServer(
+			s.config.adminAddress(),
+			router(adminGrpcServer, adminGwmux.mux, s.config.MaxMsgSize), tlsConfig,
+		)
+	}
+
+	return nil
+}
+
+// gatewayMux is the REST reverse proxy of the grpc server dialed by conn
+type gatewayMux struct {
+	mux  *gateway.ServeMux
+	conn *grpc.ClientConn
+}
+
+func (s *service) newGateway(address string) (*gatewayMux, error) {
 	// Creds for grpc gateway reverse proxy.
 	gatewayCreds := insecure.NewCredentials()
 	if !s.config.insecure() {
@@ -152,12 +316,16 @@ func (s *service) newServer(tlsConfig *tls.Config) error {
 			InsecureSkipVerify: true, // #nosec
 		})
 	}
-	gatewayOpts := grpc.WithTransportCredentials(gatewayCreds)
 	conn, err := grpc.NewClient(
-		s.config.gatewayAddress(), gatewayOpts,
+		address,
+		grpc.WithTransportCredentials(gatewayCreds),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(s.config.MaxMsgSize),
+			grpc.MaxCallSendMsgSize(s.config.MaxMsgSize),
+		),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	customMatcher := func(key string) (string, bool) {
@@ -168,37 +336,30 @@ func (s *service) newServer(tlsConfig *tls.Config) error {
 			return key, false
 		}
 	}
-	// Reverse proxy grpc-gateway.
-	gwmux := gateway.NewServeMux(
+	mux := gateway.NewServeMux(
 		gateway.WithIncomingHeaderMatcher(customMatcher),
-		gateway.WithHealthzEndpoint(grpchealth.NewHealthClient(conn)),
 	)
+	return &gatewayMux{mux, conn}, nil
+}
 
-	// Register public services on main gateway
-	introspectorv1.RegisterIntrospectorServiceHandler(ctx, gwmux, conn)
-
-	grpcGateway := http.Handler(gwmux)
-	handler := router(grpcServer, grpcGateway)
-	mux := http.NewServeMux()
-	mux.Handle("/", handler)
-
-	httpServerHandler := http.Handler(mux)
+func (s *service) newHTTPServer(
+	address string, handler http.Handler, tlsConfig *tls.Config,
+) *http.Server {
 	if s.config.insecure() {
-		httpServerHandler = h2c.NewHandler(httpServerHandler, &http2.Server{})
+		handler = h2c.NewHandler(handler, &http2.Server{})
 	}
-
-	s.grpcServer = grpcServer
-	s.server = &http.Server{
-		Addr:      s.config.address(),
-		Handler:   httpServerHandler,
+	return &http.Server{
+		Addr:      address,
+		Handler:   handler,
 		TLSConfig: tlsConfig,
 	}
-
-	return nil
 }
 
+// router serves the rest requests with the gateway, their bodies being capped
+// to maxBodySize, and the others with the grpc server. Both are rate limited
+// by the grpc interceptor, the gateway forwarding the ip of the client
 func router(
-	grpcServer *grpc.Server, grpcGateway http.Handler,
+	grpcServer *grpc.Server, grpcGateway http.Handler, maxBodySize int,
 ) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if isOptionRequest(r) {
@@ -213,6 +374,7 @@ func router(
 			w.Header().Set("Access-Control-Allow-Headers", "*")
 			w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
 
+			r.Body = http.MaxBytesReader(w, r.Body, int64(maxBodySize))
 			grpcGateway.ServeHTTP(w, r)
 			return
 		}
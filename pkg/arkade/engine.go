@@ -9,6 +9,12 @@
 //
 // When updating btcd dependencies, review upstream txscript changes for security
 // patches that may need to be backported to this fork.
+//
+// The package also encodes the Introspector Packet, which maps the inputs of a
+// transaction to their Arkade Script and witness. Each entry starts with its
+// vin and an entry type byte: EntryTypeInline is followed by the script bytes,
+// EntryTypeReference by the ArkadeScriptHash of a script registered with the
+// introspector.
 package arkade
 
 import (
@@ -158,6 +164,10 @@ type Engine struct {
 	// NOTE: This is only meant to be used in debugging, and SHOULD NOT BE
 	// USED during regular operation.
 	stepCallback func(*StepInfo) error
+
+	// opcodeCallback is an optional function called with the name of every
+	// opcode stepped through, unlike stepCallback it doesn't copy the stacks.
+	opcodeCallback func(string)
 }
 
 // StepInfo houses the current VM state information that is passed back to the
@@ -599,6 +609,9 @@ func (vm *Engine) Step() (done bool, err error) {
 	// Execute the opcode while taking into account several things such as
 	// disabled opcodes, illegal opcodes, maximum allowed operations per script,
 	// maximum script element sizes, and conditionals.
+	if vm.opcodeCallback != nil {
+		vm.opcodeCallback(vm.tokenizer.op.name)
+	}
 	err = vm.executeOpcode(vm.tokenizer.op, vm.tokenizer.Data())
 	if err != nil {
 		return true, err
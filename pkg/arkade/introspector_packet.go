@@ -22,13 +22,43 @@ const (
 	MaxScriptLength = 10_000
 	// MaxWitnessLength is the maximum serialized witness size per entry.
 	MaxWitnessLength = 1_000_000
+	// ScriptHashLength is the size of the script hash of an entry referencing
+	// a registered script.
+	ScriptHashLength = 32
+)
+
+// The entry type byte follows the vin of each entry and tells how its script
+// is encoded.
+const (
+	// EntryTypeInline is followed by the varint length and the script bytes.
+	EntryTypeInline = 0x00
+	// EntryTypeReference is followed by the ArkadeScriptHash of a registered
+	// script.
+	EntryTypeReference = 0x01
 )
 
 // IntrospectorEntry represents a single entry in the Introspector Packet.
+// The script is either inlined or referenced by its ArkadeScriptHash, the
+// bytes being registered with the introspector beforehand.
 type IntrospectorEntry struct {
-	Vin     uint16         // Transaction input index (u16 LE)
-	Script  []byte         // Arkade Script bytecode
-	Witness wire.TxWitness // Script witness stack items
+	Vin        uint16         // Transaction input index (u16 LE)
+	Script     []byte         // Arkade Script bytecode, empty if referenced
+	ScriptHash []byte         // ArkadeScriptHash of the referenced script
+	Witness    wire.TxWitness // Script witness stack items
+}
+
+// IsReference returns true if the entry references its script by hash
+// instead of inlining it.
+func (e IntrospectorEntry) IsReference() bool {
+	return len(e.Script) == 0 && len(e.ScriptHash) > 0
+}
+
+// Hash returns the ArkadeScriptHash of the entry script, inlined or referenced.
+func (e IntrospectorEntry) Hash() []byte {
+	if e.IsReference() {
+		return append([]byte(nil), e.ScriptHash...)
+	}
+	return ArkadeScriptHash(e.Script)
 }
 
 // IntrospectorPacket is a set of IntrospectorEntry items encoded as a TLV
@@ -54,9 +84,22 @@ func (p IntrospectorPacket) Validate() error {
 	}
 	seen := make(map[uint16]bool, len(p))
 	for i, entry := range p {
-		if len(entry.Script) == 0 {
+		if !entry.IsReference() && len(entry.Script) == 0 {
 			return fmt.Errorf("empty script at entry %d", i)
 		}
+		if entry.IsReference() && len(entry.ScriptHash) != ScriptHashLength {
+			return fmt.Errorf(
+				"invalid script hash length at entry %d, expected=%d got=%d",
+				i, ScriptHashLength, len(entry.ScriptHash),
+			)
+		}
+		if len(entry.Script) > MaxScriptLength {
+			return fmt.Errorf("max introspector script length exceeded at entry %d", i)
+		}
+		if !entry.IsReference() && len(entry.ScriptHash) > 0 &&
+			!bytes.Equal(entry.ScriptHash, ArkadeScriptHash(entry.Script)) {
+			return fmt.Errorf("script hash mismatch at entry %d", i)
+		}
 		if seen[entry.Vin] {
 			return fmt.Errorf("duplicate vin %d at entry %d", entry.Vin, i)
 		}
@@ -86,12 +129,21 @@ func (p IntrospectorPacket) Serialize() ([]byte, error) {
 			return nil, fmt.Errorf("failed to write vin for entry %d: %w", i, err)
 		}
 
-		// Write script_len + script
-		if err := wire.WriteVarInt(&buf, 0, uint64(len(entry.Script))); err != nil {
-			return nil, fmt.Errorf("failed to write script_len for entry %d: %w", i, err)
-		}
-		if _, err := buf.Write(entry.Script); err != nil {
-			return nil, fmt.Errorf("failed to write script for entry %d: %w", i, err)
+		// Write entry_type, then script_len + script for an inlined script
+		// or the script_hash of a referenced one
+		if entry.IsReference() {
+			buf.WriteByte(EntryTypeReference)
+			if _, err := buf.Write(entry.ScriptHash); err != nil {
+				return nil, fmt.Errorf("failed to write script_hash for entry %d: %w", i, err)
+			}
+		} else {
+			buf.WriteByte(EntryTypeInline)
+			if err := wire.WriteVarInt(&buf, 0, uint64(len(entry.Script))); err != nil {
+				return nil, fmt.Errorf("failed to write script_len for entry %d: %w", i, err)
+			}
+			if _, err := buf.Write(entry.Script); err != nil {
+				return nil, fmt.Errorf("failed to write script for entry %d: %w", i, err)
+			}
 		}
 
 		// Write witness (serialized as wire format, length-prefixed)
@@ -131,17 +183,31 @@ func DeserializeIntrospectorPacket(data []byte) (IntrospectorPacket, error) {
 			return nil, fmt.Errorf("failed to read vin for entry %d: %w", i, err)
 		}
 
-		// Read script
-		scriptLen, err := wire.ReadVarInt(r, 0)
+		// Read entry type, then script or script hash
+		entryType, err := r.ReadByte()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read script_len for entry %d: %w", i, err)
-		}
-		if scriptLen > MaxScriptLength {
-			return nil, fmt.Errorf("max introspector script length exceeded, max=%d got=%d", MaxScriptLength, scriptLen)
+			return nil, fmt.Errorf("failed to read entry_type for entry %d: %w", i, err)
 		}
-		entry.Script = make([]byte, scriptLen)
-		if _, err := io.ReadFull(r, entry.Script); err != nil {
-			return nil, fmt.Errorf("failed to read script for entry %d: %w", i, err)
+		switch entryType {
+		case EntryTypeInline:
+			scriptLen, err := wire.ReadVarInt(r, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read script_len for entry %d: %w", i, err)
+			}
+			if scriptLen > MaxScriptLength {
+				return nil, fmt.Errorf("max introspector script length exceeded, max=%d got=%d", MaxScriptLength, scriptLen)
+			}
+			entry.Script = make([]byte, scriptLen)
+			if _, err := io.ReadFull(r, entry.Script); err != nil {
+				return nil, fmt.Errorf("failed to read script for entry %d: %w", i, err)
+			}
+		case EntryTypeReference:
+			entry.ScriptHash = make([]byte, ScriptHashLength)
+			if _, err := io.ReadFull(r, entry.ScriptHash); err != nil {
+				return nil, fmt.Errorf("failed to read script_hash for entry %d: %w", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown entry_type %d for entry %d", entryType, i)
 		}
 
 		// Read witness (raw bytes, then decode to TxWitness)
@@ -30,6 +30,8 @@ func TestIntrospectorPacket(t *testing.T) {
 				for i := range f.Packet {
 					require.Equal(t, f.Packet[i].Vin, got[i].Vin)
 					require.Equal(t, f.Packet[i].Script, got[i].Script)
+					require.Equal(t, f.Packet[i].ScriptHash, got[i].ScriptHash)
+					require.Equal(t, f.Packet[i].Hash(), got[i].Hash())
 					require.Len(t, got[i].Witness, len(f.Packet[i].Witness))
 					for j := range f.Packet[i].Witness {
 						require.Equal(t, f.Packet[i].Witness[j], got[i].Witness[j])
@@ -45,6 +47,9 @@ func TestIntrospectorPacket(t *testing.T) {
 				if f.HasEntries {
 					_, err := NewPacket(f.Entries...)
 					require.Error(t, err)
+					if f.Error != "" {
+						require.ErrorContains(t, err, f.Error)
+					}
 				}
 				if f.Encoded != "" {
 					data, err := hex.DecodeString(f.Encoded)
@@ -79,6 +84,7 @@ type validFixture struct {
 type invalidFixture struct {
 	Name       string              `json:"name"`
 	Encoded    string              `json:"encoded"`
+	Error      string              `json:"error"`
 	HasEntries bool                `json:"-"`
 	Entries    []IntrospectorEntry `json:"-"`
 }
@@ -89,23 +95,31 @@ type fixtures struct {
 }
 
 type rawEntry struct {
-	Vin     uint16   `json:"vin"`
-	Script  string   `json:"script"`
-	Witness []string `json:"witness"`
+	Vin        uint16   `json:"vin"`
+	Script     string   `json:"script"`
+	ScriptHash string   `json:"script_hash"`
+	Witness    []string `json:"witness"`
 }
 
 func decodeEntries(raw []rawEntry) []IntrospectorEntry {
 	entries := make([]IntrospectorEntry, len(raw))
 	for j, e := range raw {
-		script, _ := hex.DecodeString(e.Script)
+		var script, scriptHash []byte
+		if e.Script != "" {
+			script, _ = hex.DecodeString(e.Script)
+		}
+		if e.ScriptHash != "" {
+			scriptHash, _ = hex.DecodeString(e.ScriptHash)
+		}
 		witness := make(wire.TxWitness, len(e.Witness))
 		for k, w := range e.Witness {
 			witness[k], _ = hex.DecodeString(w)
 		}
 		entries[j] = IntrospectorEntry{
-			Vin:     e.Vin,
-			Script:  script,
-			Witness: witness,
+			Vin:        e.Vin,
+			Script:     script,
+			ScriptHash: scriptHash,
+			Witness:    witness,
 		}
 	}
 	return entries
@@ -125,6 +139,7 @@ func readFixtures(t testing.TB) fixtures {
 		Invalid []struct {
 			Name    string      `json:"name"`
 			Encoded string      `json:"encoded"`
+			Error   string      `json:"error"`
 			Entries *[]rawEntry `json:"entries"`
 		} `json:"invalid"`
 	}
@@ -145,6 +160,7 @@ func readFixtures(t testing.TB) fixtures {
 		inv := invalidFixture{
 			Name:    rf.Name,
 			Encoded: rf.Encoded,
+			Error:   rf.Error,
 		}
 		if rf.Entries != nil {
 			inv.HasEntries = true
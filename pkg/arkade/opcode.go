@@ -2918,8 +2918,7 @@ func opcodeInspectInputArkadeScriptHash(op *opcode, data []byte, vm *Engine) err
 			fmt.Sprintf("no introspector entry for vin %d", index))
 	}
 
-	scriptHash := ArkadeScriptHash(entry.Script)
-	vm.dstack.PushByteArray(scriptHash)
+	vm.dstack.PushByteArray(entry.Hash())
 	return nil
 }
 
@@ -35,6 +35,14 @@ func WithDebugCallback(callback func(*StepInfo, *Engine) error) ExecuteOption {
 	}
 }
 
+// WithOpcodeCallback calls the callback with the name of every opcode stepped
+// through, it's cheap enough to be used during regular operation.
+func WithOpcodeCallback(callback func(opcode string)) ExecuteOption {
+	return func(engine *Engine) {
+		engine.opcodeCallback = callback
+	}
+}
+
 // ArkPrevOutFetcher extends txscript.PrevOutputFetcher with the ability to
 // look up previous ark transactions by outpoint. Both methods are keyed by
 // the spending input's outpoint but serve different purposes:
@@ -74,7 +82,7 @@ func ReadArkadeScript(ptx *psbt.Packet, signerPublicKey *btcec.PublicKey, entry
 		return nil, fmt.Errorf("input does not specify any TaprootLeafScript")
 	}
 
-	scriptHash := ArkadeScriptHash(entry.Script)
+	scriptHash := entry.Hash()
 	expectedPublicKey := ComputeArkadeScriptPublicKey(signerPublicKey, scriptHash)
 	expectedPublicKeyXonly := schnorr.SerializePubKey(expectedPublicKey)
 
@@ -123,6 +131,11 @@ func ReadArkadeScript(ptx *psbt.Packet, signerPublicKey *btcec.PublicKey, entry
 }
 
 func (s *ArkadeScript) Execute(spendingTx *wire.MsgTx, prevOutFetcher ArkPrevOutFetcher, inputIndex int, opts ...ExecuteOption) error {
+	// the bytes of a referenced script must be resolved before execution
+	if len(s.script) == 0 {
+		return fmt.Errorf("missing bytes of referenced script %x", s.hash)
+	}
+
 	prevOut := prevOutFetcher.FetchPrevOutput(spendingTx.TxIn[inputIndex].PreviousOutPoint)
 	inputAmount := int64(0)
 	if prevOut != nil {
@@ -46,6 +46,29 @@ func TestReadArkadeScript(t *testing.T) {
 		}
 	})
 
+	t.Run("referenced script", func(t *testing.T) {
+		f := fix.Valid[0]
+		ptx := decodePSBT(t, f.Psbt)
+		signerPubKey := decodeXOnlyPubKey(t, f.SignerPublicKey)
+		entry := decodeEntry(t, f.Entry)
+
+		inlined, err := ReadArkadeScript(ptx, signerPubKey, entry)
+		require.NoError(t, err)
+
+		referenced, err := ReadArkadeScript(ptx, signerPubKey, IntrospectorEntry{
+			Vin:        entry.Vin,
+			ScriptHash: ArkadeScriptHash(entry.Script),
+			Witness:    entry.Witness,
+		})
+		require.NoError(t, err)
+		require.Equal(t, inlined.Hash(), referenced.Hash())
+		require.True(t, inlined.PubKey().IsEqual(referenced.PubKey()))
+
+		// the script bytes must be resolved to be executed
+		err = referenced.Execute(ptx.UnsignedTx, nil, int(entry.Vin))
+		require.ErrorContains(t, err, "missing bytes of referenced script")
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		for _, f := range fix.Invalid {
 			t.Run(f.Name, func(t *testing.T) {
@@ -61,6 +84,29 @@ func TestReadArkadeScript(t *testing.T) {
 	})
 }
 
+func TestExecuteWithOpcodeCallback(t *testing.T) {
+	script, err := txscript.NewScriptBuilder().
+		AddOp(OP_1).AddOp(OP_1).AddOp(OP_ADD).AddOp(OP_2).AddOp(OP_EQUAL).
+		Script()
+	require.NoError(t, err)
+
+	prevOut := &wire.TxOut{Value: 1000, PkScript: []byte{OP_1}}
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 0}})
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{OP_1}})
+	fetcher := newTestArkPrevOutFetcher(
+		txscript.NewCannedPrevOutputFetcher(prevOut.PkScript, prevOut.Value), nil, nil,
+	)
+
+	var opcodes []string
+	arkadeScript := &ArkadeScript{script: script}
+	err = arkadeScript.Execute(tx, fetcher, 0, WithOpcodeCallback(func(opcode string) {
+		opcodes = append(opcodes, opcode)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"OP_1", "OP_1", "OP_ADD", "OP_2", "OP_EQUAL"}, opcodes)
+}
+
 type scriptFixtureEntry struct {
 	Vin     int      `json:"vin"`
 	Script  string   `json:"script"`
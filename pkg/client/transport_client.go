@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"io"
+	"strings"
 
 	introspectorv1 "github.com/ArkLabsHQ/introspector/api-spec/protobuf/gen/introspector/v1"
 	"github.com/arkade-os/arkd/pkg/ark-lib/tree"
@@ -9,8 +11,26 @@ import (
 )
 
 type Info struct {
-	Version         string
+	Version           string
+	SignerPublicKey   string
+	DeprecatedSigners []DeprecatedSigner
+	// ArkServers are the arkd instances served, the first is the default one
+	ArkServers []ArkServer
+}
+
+// ArkServer is an arkd instance served by the introspector.
+// SignerPublicKey is empty and UpdatedAt is 0 until the introspector reaches it.
+type ArkServer struct {
+	URL             string
 	SignerPublicKey string
+	UpdatedAt       int64
+}
+
+// DeprecatedSigner is a retired introspector key that still signs for the
+// VTXOs bound to it until SunsetAt (0 if not set).
+type DeprecatedSigner struct {
+	PublicKey string
+	SunsetAt  int64
 }
 
 type Intent struct {
@@ -18,19 +38,109 @@ type Intent struct {
 	Message string
 }
 
+// IntentEntry is the outcome of an entry of the introspector packet, Status is
+// one of signed, skipped (not the introspector's key) or rejected
+type IntentEntry struct {
+	Vin    uint32
+	Status string
+	Reason string
+}
+
+// Delegate is the refresh progress of a delegate VTXO, Status is one of
+// pending, running, settled or failed
+type Delegate struct {
+	Outpoint  string
+	Amount    int64
+	Status    string
+	RefreshAt int64
+	Attempts  int
+	LastError string
+	// CommitmentTxid is set once settled
+	CommitmentTxid string
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+// ScriptArgument is a witness argument of a registered script, Type being a
+// hint like signature, pubkey, bytes or int
+type ScriptArgument struct {
+	Name string
+	Type string
+}
+
+// RegisteredScript is an arkade script stored in the introspector registry,
+// Hash being its hex ArkadeScriptHash and Script its hex bytes
+type RegisteredScript struct {
+	Hash         string
+	TemplateHash string
+	Script       string
+	Name         string
+	Version      string
+	Abi          []ScriptArgument
+	CreatedAt    int64
+}
+
+// TxStatus is the progress of a submitted ark tx, Stage is one of queued,
+// executed, signed, submitted, finalized or failed
+type TxStatus struct {
+	Txid  string
+	Stage string
+	// Done is set once the status won't change anymore
+	Done  bool
+	Error string
+	// signed txs, set from the signed stage
+	SignedTx          string
+	SignedCheckpoints []string
+	UpdatedAt         int64
+}
+
+// TxStatusEvent carries either an update of the status or the error that
+// ended the stream
+type TxStatusEvent struct {
+	Status *TxStatus
+	Err    error
+}
+
 type TransportClient interface {
 	GetInfo(ctx context.Context) (*Info, error)
 	SubmitTx(ctx context.Context, tx string, checkpoints []string) (
 		signedTx string, signedCheckpoints []string, err error,
 	)
+	// SubmitTxAsync returns the txid without waiting for the tx to be processed
+	SubmitTxAsync(ctx context.Context, tx string, checkpoints []string) (txid string, err error)
+	GetTxStatus(ctx context.Context, txid string) (*TxStatus, error)
+	// WatchTx streams the status updates of the tx until it's done
+	WatchTx(ctx context.Context, txid string) (<-chan TxStatusEvent, error)
 	SubmitIntent(ctx context.Context, intent Intent) (signedProof string, err error)
+	// SubmitIntentWithEntries also returns the outcome of each entry, in strict
+	// mode it fails if any entry is not signed
+	SubmitIntentWithEntries(
+		ctx context.Context, intent Intent, strict bool,
+	) (signedProof string, entries []IntentEntry, err error)
+	// SubmitIntentDeletion signs a delete proof, the intent message being the delete one
+	SubmitIntentDeletion(
+		ctx context.Context, intent Intent,
+	) (signedProof string, entries []IntentEntry, err error)
 	SubmitFinalization(
 		ctx context.Context,
 		intent Intent,
 		forfeits []string,
-		connectorTree tree.FlatTxTree, commitmentTx string,
+		connectorTree, vtxoTree tree.FlatTxTree, commitmentTx string,
 	) (signedForfeits []string, signedCommitmentTx string, err error)
 	SubmitOnchainTx(ctx context.Context, tx string) (signedTx string, err error)
+	// RegisterDelegate hands the refresh of the vtxo spent by the intent
+	// template to the introspector, vtxoExpireAt being 0 to refresh it now
+	RegisterDelegate(ctx context.Context, template Intent, vtxoExpireAt int64) (*Delegate, error)
+	GetDelegate(ctx context.Context, outpoint string) (*Delegate, error)
+	// CreateTreeCosigner returns a key to list in the cosigners of the register
+	// message, the introspector cosigns the vtxo tree once the intent is signed
+	CreateTreeCosigner(ctx context.Context) (pubkey string, expireAt int64, err error)
+	// RegisterScript stores the hex script in the registry, the introspector
+	// packet entries can then reference it by hash
+	RegisterScript(
+		ctx context.Context, script, name, version string, abi []ScriptArgument,
+	) (*RegisteredScript, error)
+	GetScript(ctx context.Context, scriptHash string) (*RegisteredScript, error)
 }
 
 // grpcClient implements TransportClient using gRPC
@@ -52,9 +162,28 @@ func (c *grpcClient) GetInfo(ctx context.Context) (*Info, error) {
 		return nil, err
 	}
 
+	deprecatedSigners := make([]DeprecatedSigner, 0, len(resp.GetDeprecatedSigners()))
+	for _, signer := range resp.GetDeprecatedSigners() {
+		deprecatedSigners = append(deprecatedSigners, DeprecatedSigner{
+			PublicKey: signer.GetPubkey(),
+			SunsetAt:  signer.GetSunsetAt(),
+		})
+	}
+
+	arkServers := make([]ArkServer, 0, len(resp.GetArkServers()))
+	for _, server := range resp.GetArkServers() {
+		arkServers = append(arkServers, ArkServer{
+			URL:             server.GetUrl(),
+			SignerPublicKey: server.GetSignerPubkey(),
+			UpdatedAt:       server.GetUpdatedAt(),
+		})
+	}
+
 	return &Info{
-		Version:         resp.GetVersion(),
-		SignerPublicKey: resp.GetSignerPubkey(),
+		Version:           resp.GetVersion(),
+		SignerPublicKey:   resp.GetSignerPubkey(),
+		DeprecatedSigners: deprecatedSigners,
+		ArkServers:        arkServers,
 	}, nil
 }
 
@@ -72,28 +201,129 @@ func (c *grpcClient) SubmitTx(ctx context.Context, tx string, checkpoints []stri
 	return resp.GetSignedArkTx(), resp.GetSignedCheckpointTxs(), nil
 }
 
+func (c *grpcClient) SubmitTxAsync(ctx context.Context, tx string, checkpoints []string) (string, error) {
+	req := &introspectorv1.SubmitTxRequest{
+		ArkTx:         tx,
+		CheckpointTxs: checkpoints,
+		Async:         true,
+	}
+
+	resp, err := c.client.SubmitTx(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetTxid(), nil
+}
+
+func (c *grpcClient) GetTxStatus(ctx context.Context, txid string) (*TxStatus, error) {
+	req := &introspectorv1.GetTxStatusRequest{Txid: txid}
+
+	resp, err := c.client.GetTxStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return castTxStatus(resp.GetStatus()), nil
+}
+
+func (c *grpcClient) WatchTx(ctx context.Context, txid string) (<-chan TxStatusEvent, error) {
+	req := &introspectorv1.WatchTxRequest{Txid: txid}
+
+	stream, err := c.client.WatchTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan TxStatusEvent)
+	go func() {
+		defer close(ch)
+		for {
+			event := TxStatusEvent{}
+			resp, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+				event.Err = err
+			} else {
+				event.Status = castTxStatus(resp.GetStatus())
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+			if event.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
 func (c *grpcClient) SubmitIntent(ctx context.Context, intent Intent) (string, error) {
+	signedProof, _, err := c.SubmitIntentWithEntries(ctx, intent, false)
+	return signedProof, err
+}
+
+func (c *grpcClient) SubmitIntentWithEntries(
+	ctx context.Context, intent Intent, strict bool,
+) (string, []IntentEntry, error) {
 	req := &introspectorv1.SubmitIntentRequest{
 		Intent: &introspectorv1.Intent{
 			Proof:   intent.Proof,
 			Message: intent.Message,
 		},
+		Strict: strict,
 	}
 
 	resp, err := c.client.SubmitIntent(ctx, req)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return resp.GetSignedProof(), nil
+	return resp.GetSignedProof(), toIntentEntries(resp.GetEntries()), nil
+}
+
+func (c *grpcClient) SubmitIntentDeletion(ctx context.Context, intent Intent) (string, []IntentEntry, error) {
+	req := &introspectorv1.SubmitIntentDeletionRequest{
+		Intent: &introspectorv1.Intent{
+			Proof:   intent.Proof,
+			Message: intent.Message,
+		},
+	}
+
+	resp, err := c.client.SubmitIntentDeletion(ctx, req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return resp.GetSignedProof(), toIntentEntries(resp.GetEntries()), nil
+}
+
+func toIntentEntries(protoEntries []*introspectorv1.IntentEntry) []IntentEntry {
+	entries := make([]IntentEntry, 0, len(protoEntries))
+	for _, entry := range protoEntries {
+		status := strings.TrimPrefix(entry.GetStatus().String(), "INTENT_ENTRY_STATUS_")
+		entries = append(entries, IntentEntry{
+			Vin:    entry.GetVin(),
+			Status: strings.ToLower(status),
+			Reason: entry.GetReason(),
+		})
+	}
+	return entries
 }
 
 func (c *grpcClient) SubmitFinalization(
 	ctx context.Context,
 	intent Intent, forfeits []string,
-	connectorTree tree.FlatTxTree, commitmentTx string,
+	connectorTree, vtxoTree tree.FlatTxTree, commitmentTx string,
 ) (signedForfeits []string, signedCommitmentTx string, err error) {
 	connectorTreeNodes := castTxTree(connectorTree)
+	vtxoTreeNodes := castTxTree(vtxoTree)
 
 	req := &introspectorv1.SubmitFinalizationRequest{
 		SignedIntent: &introspectorv1.Intent{
@@ -102,6 +332,7 @@ func (c *grpcClient) SubmitFinalization(
 		},
 		Forfeits:      forfeits,
 		ConnectorTree: connectorTreeNodes,
+		VtxoTree:      vtxoTreeNodes,
 		CommitmentTx:  commitmentTx,
 	}
 
@@ -124,6 +355,122 @@ func (c *grpcClient) SubmitOnchainTx(ctx context.Context, tx string) (string, er
 	return resp.GetSignedTx(), nil
 }
 
+func (c *grpcClient) RegisterDelegate(
+	ctx context.Context, template Intent, vtxoExpireAt int64,
+) (*Delegate, error) {
+	req := &introspectorv1.RegisterDelegateRequest{
+		IntentTemplate: &introspectorv1.Intent{
+			Proof:   template.Proof,
+			Message: template.Message,
+		},
+		VtxoExpireAt: vtxoExpireAt,
+	}
+
+	resp, err := c.client.RegisterDelegate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return castDelegate(resp.GetDelegate()), nil
+}
+
+func (c *grpcClient) GetDelegate(ctx context.Context, outpoint string) (*Delegate, error) {
+	req := &introspectorv1.GetDelegateRequest{Outpoint: outpoint}
+
+	resp, err := c.client.GetDelegate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return castDelegate(resp.GetDelegate()), nil
+}
+
+func (c *grpcClient) CreateTreeCosigner(ctx context.Context) (string, int64, error) {
+	resp, err := c.client.CreateTreeCosigner(ctx, &introspectorv1.CreateTreeCosignerRequest{})
+	if err != nil {
+		return "", 0, err
+	}
+
+	return resp.GetCosignerPubkey(), resp.GetExpireAt(), nil
+}
+
+func (c *grpcClient) RegisterScript(
+	ctx context.Context, script, name, version string, abi []ScriptArgument,
+) (*RegisteredScript, error) {
+	args := make([]*introspectorv1.ScriptArgument, 0, len(abi))
+	for _, arg := range abi {
+		args = append(args, &introspectorv1.ScriptArgument{Name: arg.Name, Type: arg.Type})
+	}
+	req := &introspectorv1.RegisterScriptRequest{
+		Script:  script,
+		Name:    name,
+		Version: version,
+		Abi:     args,
+	}
+
+	resp, err := c.client.RegisterScript(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return castScript(resp.GetScript()), nil
+}
+
+func (c *grpcClient) GetScript(ctx context.Context, scriptHash string) (*RegisteredScript, error) {
+	req := &introspectorv1.GetScriptRequest{ScriptHash: scriptHash}
+
+	resp, err := c.client.GetScript(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return castScript(resp.GetScript()), nil
+}
+
+func castDelegate(delegate *introspectorv1.Delegate) *Delegate {
+	status := strings.TrimPrefix(delegate.GetStatus().String(), "DELEGATE_STATUS_")
+	return &Delegate{
+		Outpoint:       delegate.GetOutpoint(),
+		Amount:         delegate.GetAmount(),
+		Status:         strings.ToLower(status),
+		RefreshAt:      delegate.GetRefreshAt(),
+		Attempts:       int(delegate.GetAttempts()),
+		LastError:      delegate.GetLastError(),
+		CommitmentTxid: delegate.GetCommitmentTxid(),
+		CreatedAt:      delegate.GetCreatedAt(),
+		UpdatedAt:      delegate.GetUpdatedAt(),
+	}
+}
+
+func castScript(script *introspectorv1.RegisteredScript) *RegisteredScript {
+	abi := make([]ScriptArgument, 0, len(script.GetAbi()))
+	for _, arg := range script.GetAbi() {
+		abi = append(abi, ScriptArgument{Name: arg.GetName(), Type: arg.GetType()})
+	}
+	return &RegisteredScript{
+		Hash:         script.GetScriptHash(),
+		TemplateHash: script.GetTemplateHash(),
+		Script:       script.GetScript(),
+		Name:         script.GetName(),
+		Version:      script.GetVersion(),
+		Abi:          abi,
+		CreatedAt:    script.GetCreatedAt(),
+	}
+}
+
+func castTxStatus(status *introspectorv1.TxStatus) *TxStatus {
+	stage := strings.TrimPrefix(status.GetStage().String(), "TX_STAGE_")
+	return &TxStatus{
+		Txid:              status.GetTxid(),
+		Stage:             strings.ToLower(stage),
+		Done:              status.GetDone(),
+		Error:             status.GetError(),
+		SignedTx:          status.GetSignedArkTx(),
+		SignedCheckpoints: status.GetSignedCheckpointTxs(),
+		UpdatedAt:         status.GetUpdatedAt(),
+	}
+}
+
 func castTxTree(tree tree.FlatTxTree) []*introspectorv1.TxTreeNode {
 	nodes := make([]*introspectorv1.TxTreeNode, 0, len(tree))
 	for _, node := range tree {
@@ -215,7 +215,7 @@ func (h *delegateBatchEventsHandler) OnBatchFinalization(
 	}
 
 	signedForfeits, signedCommitmentTx, err := h.introspectorClient.SubmitFinalization(
-		ctx, h.intent, forfeits, flatConnectorTree, event.Tx,
+		ctx, h.intent, forfeits, flatConnectorTree, nil, event.Tx,
 	)
 	if err != nil {
 		return err
@@ -429,8 +429,16 @@ func (h *boardingBatchEventsHandler) OnBatchFinalization(
 		return err
 	}
 
+	var flatVtxoTree tree.FlatTxTree
+	if vtxoTree != nil {
+		flatVtxoTree, err = vtxoTree.Serialize()
+		if err != nil {
+			return err
+		}
+	}
+
 	_, signedCommitmentTx, err = h.introspectorClient.SubmitFinalization(
-		ctx, h.intent, []string{}, nil, signedCommitmentTx,
+		ctx, h.intent, []string{}, nil, flatVtxoTree, signedCommitmentTx,
 	)
 	if err != nil {
 		return err